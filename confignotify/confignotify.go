@@ -0,0 +1,147 @@
+// Package confignotify turns subscriptions to contract logs or other events
+// into config change notifications, so that oracles enact new configs within
+// seconds instead of waiting for the next poll of their
+// ContractConfigTracker.
+//
+// A Notifier maintains a subscription, resubscribing whenever it fails, and
+// coalesces its events into the channel returned by Notify. Use
+// WithNotifier to plug a Notifier into an OCR2 ContractConfigTracker, and
+// WithOCR1Notifier for an OCR1 ContractConfigTracker. Polling remains in
+// place, so a broken subscription delays config changes by at most a poll
+// interval.
+package confignotify
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/subprocesses"
+)
+
+// SubscribeFunc opens a subscription, e.g. to the ConfigSet logs of a
+// contract. ctx bounds the call to SubscribeFunc, not the lifetime of the
+// subscription. The subscription emits events on events and closes events
+// when it fails. unsubscribe releases the subscription's resources and must
+// be safe to call after events has been closed.
+type SubscribeFunc[T any] func(ctx context.Context) (events <-chan T, unsubscribe func(), err error)
+
+// Notifier turns a subscription into a stream of notifications.
+//
+// Notifications are coalesced: Notify's channel holds at most one pending
+// notification, and events arriving while a notification is pending are
+// dropped. This way, a slow consumer never blocks the subscription, and
+// a burst of events results in a single check for a new config.
+type Notifier struct {
+	chNotify chan struct{}
+
+	cancel       context.CancelFunc
+	subprocesses subprocesses.Subprocesses
+}
+
+// NewNotifier starts a Notifier that subscribes using subscribe. If
+// subscribing fails or the subscription is closed, the Notifier resubscribes
+// after resubscribeInterval. Since events may have been missed in the
+// meantime, it notifies after every successful resubscription. Call Close to
+// stop the Notifier.
+func NewNotifier[T any](
+	subscribe SubscribeFunc[T],
+	resubscribeInterval time.Duration,
+	subscribeTimeout time.Duration,
+	logger commontypes.Logger,
+) *Notifier {
+	ctx, cancel := context.WithCancel(context.Background())
+	n := &Notifier{
+		make(chan struct{}, 1),
+		cancel,
+		subprocesses.Subprocesses{},
+	}
+	n.subprocesses.Go(func() {
+		runSubscription(
+			ctx,
+			subscribe,
+			resubscribeInterval,
+			subscribeTimeout,
+			loghelper.MakeRootLoggerWithContext(logger),
+			n.notify,
+		)
+	})
+	return n
+}
+
+// Notify returns the channel on which notifications are sent. The channel is
+// never closed.
+func (n *Notifier) Notify() <-chan struct{} {
+	return n.chNotify
+}
+
+// Close stops the Notifier and waits for the subscription to be closed
+func (n *Notifier) Close() {
+	n.cancel()
+	n.subprocesses.Wait()
+}
+
+func (n *Notifier) notify() {
+	select {
+	case n.chNotify <- struct{}{}:
+	default:
+		// a notification is already pending
+	}
+}
+
+func runSubscription[T any](
+	ctx context.Context,
+	subscribe SubscribeFunc[T],
+	resubscribeInterval time.Duration,
+	subscribeTimeout time.Duration,
+	logger loghelper.LoggerWithContext,
+	notify func(),
+) {
+	resubscribed := false
+	for {
+		subscribeCtx, subscribeCancel := context.WithTimeout(ctx, subscribeTimeout)
+		events, unsubscribe, err := subscribe(subscribeCtx)
+		subscribeCancel()
+		if err != nil {
+			logger.ErrorIfNotCanceled("Notifier: failed to subscribe. Retrying later", ctx, commontypes.LogFields{
+				"error":               err,
+				"resubscribeInterval": resubscribeInterval,
+			})
+		} else {
+			if resubscribed {
+				logger.Info("Notifier: resubscribed", nil)
+				notify()
+			}
+			forwardEvents(ctx, events, notify)
+			unsubscribe()
+			if ctx.Err() == nil {
+				logger.Warn("Notifier: subscription was closed. Resubscribing later", commontypes.LogFields{
+					"resubscribeInterval": resubscribeInterval,
+				})
+			}
+		}
+		resubscribed = true
+
+		select {
+		case <-time.After(resubscribeInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// forwardEvents returns once events is closed or ctx is done
+func forwardEvents[T any](ctx context.Context, events <-chan T, notify func()) {
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+			notify()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
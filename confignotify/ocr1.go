@@ -0,0 +1,106 @@
+package confignotify
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting/types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+)
+
+// WithOCR1Notifier returns an OCR1 ContractConfigTracker whose
+// SubscribeToNewConfigs is fed by notifier: Upon every notification, the
+// subscription fetches the latest config from tracker, using
+// blockchainTimeout for each call, and emits it. All other methods are
+// delegated to tracker. As for WithNotifier, notifier must not be shared with
+// other consumers.
+func WithOCR1Notifier(
+	tracker types.ContractConfigTracker,
+	notifier *Notifier,
+	blockchainTimeout time.Duration,
+	logger commontypes.Logger,
+) types.ContractConfigTracker {
+	return withOCR1Notifier{
+		tracker,
+		notifier,
+		blockchainTimeout,
+		loghelper.MakeRootLoggerWithContext(logger),
+	}
+}
+
+type withOCR1Notifier struct {
+	types.ContractConfigTracker
+	notifier          *Notifier
+	blockchainTimeout time.Duration
+	logger            loghelper.LoggerWithContext
+}
+
+func (w withOCR1Notifier) SubscribeToNewConfigs(context.Context) (types.ContractConfigSubscription, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := &ocr1Subscription{
+		make(chan types.ContractConfig),
+		cancel,
+		subprocesses.Subprocesses{},
+	}
+	sub.subprocesses.Go(func() {
+		defer close(sub.chConfigs)
+		for {
+			select {
+			case <-w.notifier.Notify():
+			case <-ctx.Done():
+				return
+			}
+			contractConfig, ok := w.latestConfig(ctx)
+			if !ok {
+				continue
+			}
+			select {
+			case sub.chConfigs <- contractConfig:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+	return sub, nil
+}
+
+func (w withOCR1Notifier) latestConfig(ctx context.Context) (types.ContractConfig, bool) {
+	detailsCtx, detailsCancel := context.WithTimeout(ctx, w.blockchainTimeout)
+	defer detailsCancel()
+	changedInBlock, _, err := w.ContractConfigTracker.LatestConfigDetails(detailsCtx)
+	if err != nil {
+		w.logger.ErrorIfNotCanceled("WithOCR1Notifier: error during LatestConfigDetails()", ctx, commontypes.LogFields{
+			"error": err,
+		})
+		return types.ContractConfig{}, false
+	}
+
+	configCtx, configCancel := context.WithTimeout(ctx, w.blockchainTimeout)
+	defer configCancel()
+	contractConfig, err := w.ContractConfigTracker.ConfigFromLogs(configCtx, changedInBlock)
+	if err != nil {
+		w.logger.ErrorIfNotCanceled("WithOCR1Notifier: error during ConfigFromLogs()", ctx, commontypes.LogFields{
+			"error":          err,
+			"changedInBlock": changedInBlock,
+		})
+		return types.ContractConfig{}, false
+	}
+	return contractConfig, true
+}
+
+type ocr1Subscription struct {
+	chConfigs    chan types.ContractConfig
+	cancel       context.CancelFunc
+	subprocesses subprocesses.Subprocesses
+}
+
+func (sub *ocr1Subscription) Configs() <-chan types.ContractConfig {
+	return sub.chConfigs
+}
+
+func (sub *ocr1Subscription) Close() {
+	sub.cancel()
+	sub.subprocesses.Wait()
+}
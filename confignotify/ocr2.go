@@ -0,0 +1,22 @@
+package confignotify
+
+import (
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// WithNotifier returns an OCR2 ContractConfigTracker whose Notify channel is
+// fed by notifier. All other methods are delegated to tracker. notifier must
+// not be shared with other consumers, since every notification is received
+// by only one of them.
+func WithNotifier(tracker types.ContractConfigTracker, notifier *Notifier) types.ContractConfigTracker {
+	return withNotifier{tracker, notifier}
+}
+
+type withNotifier struct {
+	types.ContractConfigTracker
+	notifier *Notifier
+}
+
+func (w withNotifier) Notify() <-chan struct{} {
+	return w.notifier.Notify()
+}
@@ -0,0 +1,236 @@
+// Package httpjson provides a DataSource that fetches a JSON document over
+// HTTP and extracts a numeric value from it. It can be used with both the OCR1
+// protocol and the OCR2 median reporting plugin, making it possible to stand up
+// a working feed without writing any Go code.
+package httpjson
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strings"
+
+	ocr1types "github.com/smartcontractkit/libocr/offchainreporting/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
+)
+
+// DefaultMaxResponseBytes is used if DataSourceArgs.MaxResponseBytes is zero.
+const DefaultMaxResponseBytes = 1024 * 1024 // 1 MiB
+
+type DataSourceArgs struct {
+	// URL from which the JSON document is fetched using a GET request.
+	URL string
+
+	// Header contains additional headers sent with each request, e.g. for
+	// API keys. May be nil.
+	Header http.Header
+
+	// Path locates the numeric value inside the JSON document, e.g.
+	// "data.prices[0].usd". Keys are separated by dots, array indices are
+	// given in brackets. The value may either be a JSON number or a string
+	// containing a decimal number.
+	Path string
+
+	// Multiplier is a decimal number (e.g. "100000000" or "1e8") the
+	// extracted value is multiplied with before being rounded to the nearest
+	// integer (with ties rounded away from zero). If empty, "1" is used.
+	Multiplier string
+
+	// Client is used for making requests. If nil, http.DefaultClient is used.
+	// Timeouts are taken from the context passed to Observe, so there is no
+	// need to set a timeout on the client.
+	Client *http.Client
+
+	// MaxResponseBytes bounds the size of the response body. If zero,
+	// DefaultMaxResponseBytes is used.
+	MaxResponseBytes int64
+}
+
+var _ median.DataSource = (*DataSource)(nil)
+
+// DataSource fetches a JSON document from a URL and extracts a numeric value
+// from it. It implements median.DataSource; use OCR1 to obtain an OCR1
+// DataSource.
+//
+// DataSource is thread-safe.
+type DataSource struct {
+	url              string
+	header           http.Header
+	path             []pathElement
+	multiplier       *big.Rat
+	client           *http.Client
+	maxResponseBytes int64
+}
+
+// NewDataSource validates args and returns a new DataSource.
+func NewDataSource(args DataSourceArgs) (*DataSource, error) {
+	if args.URL == "" {
+		return nil, fmt.Errorf("URL must not be empty")
+	}
+	if _, err := http.NewRequest(http.MethodGet, args.URL, nil); err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+
+	path, err := parsePath(args.Path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Path: %w", err)
+	}
+
+	multiplierString := args.Multiplier
+	if multiplierString == "" {
+		multiplierString = "1"
+	}
+	multiplier, err := parseDecimal(multiplierString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Multiplier: %w", err)
+	}
+
+	client := args.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	maxResponseBytes := args.MaxResponseBytes
+	if maxResponseBytes == 0 {
+		maxResponseBytes = DefaultMaxResponseBytes
+	}
+	if maxResponseBytes < 0 {
+		return nil, fmt.Errorf("MaxResponseBytes (%v) must not be negative", maxResponseBytes)
+	}
+
+	return &DataSource{
+		args.URL,
+		args.Header.Clone(),
+		path,
+		multiplier,
+		client,
+		maxResponseBytes,
+	}, nil
+}
+
+// Observe fetches the JSON document and returns the scaled value found at the
+// configured path. The request is bound to ctx.
+func (ds *DataSource) Observe(ctx context.Context) (*big.Int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ds.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	for key, values := range ds.header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := ds.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %v: %w", ds.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %v when fetching %v", resp.StatusCode, ds.url)
+	}
+
+	// Read one more byte than allowed to detect oversized responses
+	body, err := io.ReadAll(io.LimitReader(resp.Body, ds.maxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	if int64(len(body)) > ds.maxResponseBytes {
+		return nil, fmt.Errorf("response body exceeds MaxResponseBytes (%v)", ds.maxResponseBytes)
+	}
+
+	return ds.valueFromBody(body)
+}
+
+func (ds *DataSource) valueFromBody(body []byte) (*big.Int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	// Avoid losing precision by going through float64
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("error decoding JSON: %w", err)
+	}
+	// Reject trailing data. (decoder.More doesn't catch a stray '}' or ']'.)
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("error decoding JSON: unexpected data after top-level value")
+	}
+
+	raw, err := extract(document, ds.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var value *big.Rat
+	switch v := raw.(type) {
+	case json.Number:
+		value, err = parseDecimal(string(v))
+	case string:
+		value, err = parseDecimal(v)
+	default:
+		err = fmt.Errorf("expected number or string, got %T", raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid value at path %v: %w", formatPath(ds.path), err)
+	}
+
+	return roundToInt(value.Mul(value, ds.multiplier)), nil
+}
+
+// OCR1 returns an adapter that implements the OCR1 DataSource interface.
+func (ds *DataSource) OCR1() ocr1types.DataSource {
+	return ocr1DataSource{ds}
+}
+
+type ocr1DataSource struct {
+	ds *DataSource
+}
+
+var _ ocr1types.DataSource = ocr1DataSource{}
+
+func (o ocr1DataSource) Observe(ctx context.Context) (ocr1types.Observation, error) {
+	value, err := o.ds.Observe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ocr1types.Observation(value), nil
+}
+
+var decimalRegexp = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// parseDecimal parses a decimal number such as "-12.5" or "1e8". Unlike
+// big.Rat.SetString, it doesn't accept fractions like "1/3", numbers in
+// other bases like "0x1p4", or underscores.
+func parseDecimal(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(s)
+	if !decimalRegexp.MatchString(s) {
+		return nil, fmt.Errorf("%q is not a decimal number", s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a decimal number", s)
+	}
+	return r, nil
+}
+
+// roundToInt rounds r to the nearest integer, with ties rounded away from zero.
+func roundToInt(r *big.Rat) *big.Int {
+	num := new(big.Int).Set(r.Num())
+	denom := r.Denom() // always positive
+	quo, rem := new(big.Int).QuoRem(num, denom, new(big.Int))
+	// |rem| * 2 >= denom implies that we need to round away from zero
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(denom) >= 0 {
+		if num.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		} else {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+	return quo
+}
@@ -0,0 +1,208 @@
+package httpjson
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestServer serves body with the given status code and records the
+// headers of the last request
+func newTestServer(t *testing.T, status int, body string) (*httptest.Server, *http.Header) {
+	var lastHeader http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastHeader = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &lastHeader
+}
+
+func observe(t *testing.T, args DataSourceArgs) (*big.Int, error) {
+	t.Helper()
+	ds, err := NewDataSource(args)
+	if err != nil {
+		t.Fatalf("NewDataSource: %v", err)
+	}
+	return ds.Observe(context.Background())
+}
+
+func TestDataSourceObserve(t *testing.T) {
+	const document = `{
+		"data": {
+			"prices": [
+				{"usd": 1234.5678, "eur": "1000.25"},
+				{"usd": -2.5}
+			],
+			"matrix": [[1, 2], [3, 4]],
+			"big": 123456789012345678901234567890.5
+		},
+		"top": 7
+	}`
+
+	for _, tc := range []struct {
+		name       string
+		path       string
+		multiplier string
+		expected   string
+	}{
+		{"top-level key", "top", "", "7"},
+		{"nested object and array", "data.prices[0].usd", "100", "123457"},
+		{"string value", "data.prices[0].eur", "100", "100025"},
+		{"nested arrays", "data.matrix[1][0]", "", "3"},
+		{"multiplier defaults to 1", "data.prices[0].usd", "", "1235"},
+		{"exponent multiplier", "data.prices[0].usd", "1e8", "123456780000"},
+		{"ties round away from zero", "data.prices[1].usd", "", "-3"},
+		{"no precision lost", "data.big", "10", "1234567890123456789012345678905"},
+	} {
+		server, _ := newTestServer(t, http.StatusOK, document)
+		value, err := observe(t, DataSourceArgs{URL: server.URL, Path: tc.path, Multiplier: tc.multiplier})
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if value.String() != tc.expected {
+			t.Errorf("%v: expected %v, got %v", tc.name, tc.expected, value)
+		}
+	}
+}
+
+func TestDataSourceObserveErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		status int
+		body   string
+		path   string
+	}{
+		{"not found", http.StatusNotFound, `{"price": 1}`, "price"},
+		{"server error", http.StatusInternalServerError, `{"price": 1}`, "price"},
+		{"non-200 success", http.StatusAccepted, `{"price": 1}`, "price"},
+		{"invalid JSON", http.StatusOK, `{"price": `, "price"},
+		{"trailing value", http.StatusOK, `{"price": 1} {"price": 2}`, "price"},
+		{"trailing brace", http.StatusOK, `{"price": 1}}`, "price"},
+		{"trailing bracket", http.StatusOK, `[1]]`, "[0]"},
+		{"missing key", http.StatusOK, `{"price": 1}`, "cost"},
+		{"index out of range", http.StatusOK, `{"prices": [1]}`, "prices[1]"},
+		{"index into object", http.StatusOK, `{"prices": {"0": 1}}`, "prices[0]"},
+		{"key into array", http.StatusOK, `{"prices": [1]}`, "prices.usd"},
+		{"boolean", http.StatusOK, `{"price": true}`, "price"},
+		{"null", http.StatusOK, `{"price": null}`, "price"},
+		{"object", http.StatusOK, `{"price": {}}`, "price"},
+		{"fraction string", http.StatusOK, `{"price": "1/3"}`, "price"},
+		{"hex string", http.StatusOK, `{"price": "0x10"}`, "price"},
+		{"hex float string", http.StatusOK, `{"price": "0x1p4"}`, "price"},
+		{"underscores", http.StatusOK, `{"price": "1_000"}`, "price"},
+		{"empty string", http.StatusOK, `{"price": ""}`, "price"},
+		{"infinity", http.StatusOK, `{"price": "Inf"}`, "price"},
+	} {
+		server, _ := newTestServer(t, tc.status, tc.body)
+		if value, err := observe(t, DataSourceArgs{URL: server.URL, Path: tc.path}); err == nil {
+			t.Errorf("%v: expected error, got %v", tc.name, value)
+		}
+	}
+}
+
+func TestDataSourceObserveHeaders(t *testing.T) {
+	server, lastHeader := newTestServer(t, http.StatusOK, `1`)
+	header := http.Header{}
+	header.Set("X-Api-Key", "secret")
+	if _, err := observe(t, DataSourceArgs{URL: server.URL, Header: header}); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastHeader.Get("X-Api-Key"); got != "secret" {
+		t.Errorf("expected X-Api-Key header to be sent, got %q", got)
+	}
+	if got := lastHeader.Get("Accept"); got != "application/json" {
+		t.Errorf("expected Accept header application/json, got %q", got)
+	}
+}
+
+func TestDataSourceObserveMaxResponseBytes(t *testing.T) {
+	body := `{"price": 1, "padding": "` + strings.Repeat("x", 100) + `"}`
+	server, _ := newTestServer(t, http.StatusOK, body)
+
+	if _, err := observe(t, DataSourceArgs{URL: server.URL, Path: "price", MaxResponseBytes: int64(len(body))}); err != nil {
+		t.Errorf("unexpected error for body of exactly MaxResponseBytes: %v", err)
+	}
+	if _, err := observe(t, DataSourceArgs{URL: server.URL, Path: "price", MaxResponseBytes: int64(len(body) - 1)}); err == nil {
+		t.Error("expected error for body exceeding MaxResponseBytes")
+	}
+}
+
+func TestDataSourceObserveContext(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusOK, `1`)
+	ds, err := NewDataSource(DataSourceArgs{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := ds.Observe(ctx); err == nil {
+		t.Error("expected error for cancelled context")
+	}
+}
+
+func TestNewDataSourceErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		args DataSourceArgs
+	}{
+		{"empty URL", DataSourceArgs{}},
+		{"invalid URL", DataSourceArgs{URL: "http://[::1"}},
+		{"invalid path", DataSourceArgs{URL: "http://localhost", Path: "data..price"}},
+		{"invalid multiplier", DataSourceArgs{URL: "http://localhost", Multiplier: "1/3"}},
+		{"negative MaxResponseBytes", DataSourceArgs{URL: "http://localhost", MaxResponseBytes: -1}},
+	} {
+		if _, err := NewDataSource(tc.args); err == nil {
+			t.Errorf("%v: expected error", tc.name)
+		}
+	}
+}
+
+func TestRoundToInt(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected string
+	}{
+		{"0", "0"},
+		{"2", "2"},
+		{"2.4", "2"},
+		{"2.5", "3"},
+		{"2.6", "3"},
+		{"-2.4", "-2"},
+		{"-2.5", "-3"},
+		{"-2.6", "-3"},
+		{"0.5", "1"},
+		{"-0.5", "-1"},
+		{"0.49999999999999999999", "0"},
+	} {
+		value, err := parseDecimal(tc.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rounded := roundToInt(value); rounded.String() != tc.expected {
+			t.Errorf("roundToInt(%v) = %v, expected %v", tc.value, rounded, tc.expected)
+		}
+	}
+}
+
+func TestOCR1Observe(t *testing.T) {
+	server, _ := newTestServer(t, http.StatusOK, `{"price": 42}`)
+	ds, err := NewDataSource(DataSourceArgs{URL: server.URL, Path: "price"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	observation, err := ds.OCR1().Observe(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (*big.Int)(observation).Cmp(big.NewInt(42)) != 0 {
+		t.Errorf("expected 42, got %v", (*big.Int)(observation))
+	}
+}
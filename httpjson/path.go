@@ -0,0 +1,104 @@
+package httpjson
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pathElement is either a key into a JSON object or an index into a JSON
+// array.
+type pathElement struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func (pe pathElement) String() string {
+	if pe.isIndex {
+		return fmt.Sprintf("[%v]", pe.index)
+	}
+	return pe.key
+}
+
+// parsePath parses a path expression such as "data.prices[0].usd". Keys are
+// separated by dots, array indices are given in brackets. The empty path
+// refers to the document root.
+func parsePath(path string) ([]pathElement, error) {
+	var result []pathElement
+	if path == "" {
+		return result, nil
+	}
+	for _, segment := range strings.Split(path, ".") {
+		key := segment
+		var indices []int
+		if bracket := strings.IndexByte(segment, '['); bracket >= 0 {
+			key = segment[:bracket]
+			rest := segment[bracket:]
+			for len(rest) != 0 {
+				if rest[0] != '[' {
+					return nil, fmt.Errorf("unexpected character %q in path segment %q", rest[0], segment)
+				}
+				closing := strings.IndexByte(rest, ']')
+				if closing < 0 {
+					return nil, fmt.Errorf("unterminated index in path segment %q", segment)
+				}
+				index, err := strconv.Atoi(rest[1:closing])
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid index %q in path segment %q", rest[1:closing], segment)
+				}
+				indices = append(indices, index)
+				rest = rest[closing+1:]
+			}
+		}
+		if key == "" && len(indices) == 0 {
+			return nil, fmt.Errorf("empty segment in path %q", path)
+		}
+		if key != "" {
+			result = append(result, pathElement{key, 0, false})
+		}
+		for _, index := range indices {
+			result = append(result, pathElement{"", index, true})
+		}
+	}
+	return result, nil
+}
+
+// extract walks document (as decoded by encoding/json) along path.
+func extract(document interface{}, path []pathElement) (interface{}, error) {
+	current := document
+	for i, pe := range path {
+		if pe.isIndex {
+			array, ok := current.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("expected array at %v, got %T", formatPath(path[:i+1]), current)
+			}
+			if !(pe.index < len(array)) {
+				return nil, fmt.Errorf("index out of range at %v, array has length %v", formatPath(path[:i+1]), len(array))
+			}
+			current = array[pe.index]
+		} else {
+			object, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("expected object at %v, got %T", formatPath(path[:i+1]), current)
+			}
+			value, ok := object[pe.key]
+			if !ok {
+				return nil, fmt.Errorf("key not found at %v", formatPath(path[:i+1]))
+			}
+			current = value
+		}
+	}
+	return current, nil
+}
+
+func formatPath(path []pathElement) string {
+	var sb strings.Builder
+	for i, pe := range path {
+		if i != 0 && !pe.isIndex {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(pe.String())
+	}
+	return sb.String()
+}
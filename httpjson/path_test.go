@@ -0,0 +1,59 @@
+package httpjson
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	for _, tc := range []struct {
+		path     string
+		expected []pathElement
+	}{
+		{"", nil},
+		{"price", []pathElement{{"price", 0, false}}},
+		{"data.price", []pathElement{{"data", 0, false}, {"price", 0, false}}},
+		{"data.prices[0].usd", []pathElement{{"data", 0, false}, {"prices", 0, false}, {"", 0, true}, {"usd", 0, false}}},
+		{"[1]", []pathElement{{"", 1, true}}},
+		{"matrix[1][23]", []pathElement{{"matrix", 0, false}, {"", 1, true}, {"", 23, true}}},
+	} {
+		path, err := parsePath(tc.path)
+		if err != nil {
+			t.Errorf("parsePath(%q): unexpected error: %v", tc.path, err)
+			continue
+		}
+		if !reflect.DeepEqual(path, tc.expected) {
+			t.Errorf("parsePath(%q) = %v, expected %v", tc.path, path, tc.expected)
+		}
+	}
+
+	for _, path := range []string{
+		".",
+		"data.",
+		".data",
+		"data..price",
+		"prices[",
+		"prices[0",
+		"prices[]",
+		"prices[-1]",
+		"prices[a]",
+		"prices[0]x",
+		"prices[0]]",
+	} {
+		if _, err := parsePath(path); err == nil {
+			t.Errorf("parsePath(%q): expected error", path)
+		}
+	}
+}
+
+func TestFormatPath(t *testing.T) {
+	for _, path := range []string{"price", "data.prices[0].usd", "[1]", "matrix[1][23]"} {
+		parsed, err := parsePath(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if formatted := formatPath(parsed); formatted != path {
+			t.Errorf("formatPath(parsePath(%q)) = %q", path, formatted)
+		}
+	}
+}
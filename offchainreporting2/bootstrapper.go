@@ -7,6 +7,7 @@ import (
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/config"
 	"github.com/smartcontractkit/libocr/offchainreporting2/internal/managed"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 	"github.com/smartcontractkit/libocr/subprocesses"
@@ -83,7 +84,7 @@ func (b *Bootstrapper) Start() error {
 			b.bootstrapArgs.V2Bootstrappers,
 			b.bootstrapArgs.ContractConfigTracker,
 			b.bootstrapArgs.Database,
-			b.bootstrapArgs.LocalConfig,
+			config.NewLocalConfigHolder(b.bootstrapArgs.LocalConfig),
 			logger,
 			b.bootstrapArgs.OffchainConfigDigester,
 		)
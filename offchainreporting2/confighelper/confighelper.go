@@ -43,6 +43,8 @@ type PublicConfig struct {
 	MaxDurationShouldAcceptFinalizedReport  time.Duration
 	MaxDurationShouldTransmitAcceptedReport time.Duration
 
+	CommitRevealObservations bool
+
 	F             int
 	OnchainConfig []byte
 	ConfigDigest  types.ConfigDigest
@@ -81,6 +83,7 @@ func PublicConfigFromContractConfig(skipResourceExhaustionChecks bool, change ty
 		internalPublicConfig.MaxDurationReport,
 		internalPublicConfig.MaxDurationShouldAcceptFinalizedReport,
 		internalPublicConfig.MaxDurationShouldTransmitAcceptedReport,
+		internalPublicConfig.CommitRevealObservations,
 		internalPublicConfig.F,
 		internalPublicConfig.OnchainConfig,
 		internalPublicConfig.ConfigDigest,
@@ -142,6 +145,7 @@ func ContractSetConfigArgsForEthereumIntegrationTest(
 			50 * time.Millisecond,
 			50 * time.Millisecond,
 			50 * time.Millisecond,
+			false,
 			f,
 			nil, // The median reporting plugin has an empty onchain config
 			types.ConfigDigest{},
@@ -214,6 +218,7 @@ func ContractSetConfigArgsForTestsWithAuxiliaryArgs(
 			maxDurationReport,
 			maxDurationShouldAcceptFinalizedReport,
 			maxDurationShouldTransmitAcceptedReport,
+			auxiliaryArgs.CommitRevealObservations,
 			f,
 			onchainConfig,
 			types.ConfigDigest{},
@@ -226,7 +231,8 @@ func ContractSetConfigArgsForTestsWithAuxiliaryArgs(
 // AuxiliaryArgs provides keyword-style extra configuration for calls to
 // ContractSetConfigArgsForTests
 type AuxiliaryArgs struct {
-	RNG io.Reader
+	RNG                      io.Reader
+	CommitRevealObservations bool
 }
 
 func (a AuxiliaryArgs) rng() io.Reader {
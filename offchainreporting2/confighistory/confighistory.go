@@ -0,0 +1,104 @@
+// Package confighistory queries the history of enacted configs recorded by
+// oracles whose Database implements types.ConfigHistoryDatabase, e.g. to find
+// out after an incident when each oracle switched configs and whether all
+// oracles saw the same sequence of configs.
+package confighistory
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// Read returns the config history recorded in database, ordered by Sequence
+func Read(ctx context.Context, database types.ConfigDatabase) ([]types.ConfigHistoryEntry, error) {
+	configHistoryDatabase, ok := database.(types.ConfigHistoryDatabase)
+	if !ok {
+		return nil, fmt.Errorf("database doesn't implement types.ConfigHistoryDatabase")
+	}
+	return configHistoryDatabase.ReadConfigHistory(ctx)
+}
+
+// ActiveAt returns the entry for the config that was active at time t. It
+// returns false if no config was active at t, e.g. because t precedes the
+// history.
+func ActiveAt(entries []types.ConfigHistoryEntry, t time.Time) (types.ConfigHistoryEntry, bool) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entry.FirstSeen.After(t) {
+			continue
+		}
+		if entry.ReplacedBy != (types.ConfigDigest{}) && !entry.ReplacedAt.After(t) {
+			return types.ConfigHistoryEntry{}, false
+		}
+		return entry, true
+	}
+	return types.ConfigHistoryEntry{}, false
+}
+
+// Divergence describes the first position at which the sequences of config
+// digests in several oracles' histories differ.
+type Divergence struct {
+	// Position is the index into the histories
+	Position int
+	// ConfigDigests maps each oracle to the config digest at Position, or to
+	// the zero digest if its history is shorter.
+	ConfigDigests map[string]types.ConfigDigest
+}
+
+// Compare compares the sequences of config digests in histories, which are
+// keyed by oracle. It returns nil if all histories contain the same sequence.
+// Note that an oracle that hasn't enacted the latest config yet, or started
+// recording its history later than the others, also causes a Divergence.
+func Compare(histories map[string][]types.ConfigHistoryEntry) *Divergence {
+	maxLen := 0
+	for _, entries := range histories {
+		if len(entries) > maxLen {
+			maxLen = len(entries)
+		}
+	}
+
+	for position := 0; position < maxLen; position++ {
+		configDigests := make(map[string]types.ConfigDigest, len(histories))
+		distinct := map[types.ConfigDigest]bool{}
+		for oracle, entries := range histories {
+			var configDigest types.ConfigDigest
+			if position < len(entries) {
+				configDigest = entries[position].ConfigDigest
+			}
+			configDigests[oracle] = configDigest
+			distinct[configDigest] = true
+		}
+		if len(distinct) > 1 {
+			return &Divergence{position, configDigests}
+		}
+	}
+	return nil
+}
+
+// WriteTable writes entries to out as a human-readable table
+func WriteTable(out io.Writer, entries []types.ConfigHistoryEntry) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SEQUENCE\tCONFIG DIGEST\tCONFIG COUNT\tBLOCK\tFIRST SEEN\tREPLACED BY\tREPLACED AT")
+	for _, e := range entries {
+		replacedBy, replacedAt := "-", "-"
+		if e.ReplacedBy != (types.ConfigDigest{}) {
+			replacedBy = e.ReplacedBy.Hex()
+			replacedAt = e.ReplacedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+			e.Sequence,
+			e.ConfigDigest.Hex(),
+			e.ConfigCount,
+			e.BlockNumber,
+			e.FirstSeen.Format(time.RFC3339),
+			replacedBy,
+			replacedAt,
+		)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,152 @@
+package configtracker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// cachedValue caches the result of fetch for ttl. Concurrent callers that
+// miss the cache wait for a single fetch instead of issuing their own. Errors
+// aren't cached.
+type cachedValue[T any] struct {
+	ttl   time.Duration
+	fetch func(ctx context.Context) (T, error)
+
+	mutex     sync.Mutex
+	valid     bool
+	value     T
+	fetchedAt time.Time
+	// closed when the fetch in progress (if any) is done
+	chFetched chan struct{}
+}
+
+func newCachedValue[T any](ttl time.Duration, fetch func(ctx context.Context) (T, error)) *cachedValue[T] {
+	var zero T
+	return &cachedValue[T]{
+		ttl,
+		fetch,
+		sync.Mutex{},
+		false,
+		zero,
+		time.Time{},
+		nil,
+	}
+}
+
+func (c *cachedValue[T]) get(ctx context.Context) (T, error) {
+	for {
+		c.mutex.Lock()
+		if c.valid && time.Since(c.fetchedAt) < c.ttl {
+			value := c.value
+			c.mutex.Unlock()
+			return value, nil
+		}
+		if c.chFetched != nil {
+			chFetched := c.chFetched
+			c.mutex.Unlock()
+			select {
+			case <-chFetched:
+				// the fetch may have failed, check again
+				continue
+			case <-ctx.Done():
+				var zero T
+				return zero, ctx.Err()
+			}
+		}
+		chFetched := make(chan struct{})
+		c.chFetched = chFetched
+		c.mutex.Unlock()
+
+		value, err := c.fetch(ctx)
+
+		c.mutex.Lock()
+		c.chFetched = nil
+		if err == nil {
+			c.valid = true
+			c.value = value
+			c.fetchedAt = time.Now()
+		}
+		c.mutex.Unlock()
+		close(chFetched)
+		return value, err
+	}
+}
+
+type configDetails struct {
+	changedInBlock uint64
+	configDigest   types.ConfigDigest
+}
+
+// Cache returns a ContractConfigTracker that caches the results of
+// LatestConfigDetails and LatestBlockHeight for ttl, so that several
+// consumers of the same tracker, e.g. an oracle and a bootstrapper, share RPC
+// calls. ttl should be well below ContractConfigTrackerPollInterval.
+func Cache(tracker types.ContractConfigTracker, ttl time.Duration) types.ContractConfigTracker {
+	return &cache{
+		tracker,
+		newCachedValue(ttl, func(ctx context.Context) (configDetails, error) {
+			changedInBlock, configDigest, err := tracker.LatestConfigDetails(ctx)
+			return configDetails{changedInBlock, configDigest}, err
+		}),
+		newCachedValue(ttl, tracker.LatestBlockHeight),
+	}
+}
+
+type cache struct {
+	tracker      types.ContractConfigTracker
+	details      *cachedValue[configDetails]
+	blockHeights *cachedValue[uint64]
+}
+
+func (c *cache) Notify() <-chan struct{} {
+	return c.tracker.Notify()
+}
+
+func (c *cache) LatestConfigDetails(ctx context.Context) (uint64, types.ConfigDigest, error) {
+	details, err := c.details.get(ctx)
+	return details.changedInBlock, details.configDigest, err
+}
+
+func (c *cache) LatestConfig(ctx context.Context, changedInBlock uint64) (types.ContractConfig, error) {
+	return c.tracker.LatestConfig(ctx, changedInBlock)
+}
+
+func (c *cache) LatestBlockHeight(ctx context.Context) (uint64, error) {
+	return c.blockHeights.get(ctx)
+}
+
+// BlockHeightCache caches the latest block height of a chain, so that the
+// ContractConfigTrackers of all feeds on that chain can share a single
+// LatestBlockHeight call per ttl. See ShareBlockHeight.
+type BlockHeightCache struct {
+	blockHeights *cachedValue[uint64]
+}
+
+// NewBlockHeightCache returns a BlockHeightCache that fetches block heights
+// using latestBlockHeight, e.g. the LatestBlockHeight method of any
+// ContractConfigTracker on the chain.
+func NewBlockHeightCache(latestBlockHeight func(ctx context.Context) (uint64, error), ttl time.Duration) *BlockHeightCache {
+	return &BlockHeightCache{newCachedValue(ttl, latestBlockHeight)}
+}
+
+func (c *BlockHeightCache) LatestBlockHeight(ctx context.Context) (uint64, error) {
+	return c.blockHeights.get(ctx)
+}
+
+// ShareBlockHeight returns a ContractConfigTracker that takes block heights
+// from blockHeightCache instead of tracker.
+func ShareBlockHeight(tracker types.ContractConfigTracker, blockHeightCache *BlockHeightCache) types.ContractConfigTracker {
+	return sharedBlockHeight{tracker, blockHeightCache}
+}
+
+type sharedBlockHeight struct {
+	types.ContractConfigTracker
+	blockHeightCache *BlockHeightCache
+}
+
+func (s sharedBlockHeight) LatestBlockHeight(ctx context.Context) (uint64, error) {
+	return s.blockHeightCache.LatestBlockHeight(ctx)
+}
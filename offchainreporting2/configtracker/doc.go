@@ -0,0 +1,14 @@
+// Package configtracker provides wrappers for types.ContractConfigTracker
+// that reduce the load oracles put on RPC providers and protect against
+// re-orgs of config changes. Wrappers can be combined, e.g.
+//
+//	blockHeights := configtracker.NewBlockHeightCache(anyTracker.LatestBlockHeight, time.Second)
+//	tracker = configtracker.ReorgProtection(
+//		configtracker.ShareBlockHeight(configtracker.Cache(tracker, time.Second), blockHeights),
+//		3,
+//	)
+//
+// To spread polls of many feeds over time rather than having all of them
+// poll at the same interval boundaries, set
+// LocalConfig.ContractConfigTrackerPollJitter.
+package configtracker
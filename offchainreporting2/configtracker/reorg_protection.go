@@ -0,0 +1,103 @@
+package configtracker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// ReorgProtection returns a ContractConfigTracker that only reports a config
+// change once it has survived additionalConfirmations blocks: When
+// LatestConfigDetails first returns a new (changedInBlock, configDigest)
+// pair, the wrapper remembers it along with the current block height and
+// keeps returning the previously verified pair. Once the block height has
+// advanced by additionalConfirmations and the tracker still returns the same
+// pair, the pair is verified and returned. If the pair changes in the
+// meantime, e.g. because the config change was re-orged out, verification
+// starts over.
+//
+// This complements LocalConfig.ContractConfigConfirmations, which only
+// considers the block height at which the config change was mined, not
+// whether the tracker kept reporting it.
+//
+// The first pair the wrapper sees is verified right away, so that oracles
+// don't wait for confirmations on startup.
+func ReorgProtection(tracker types.ContractConfigTracker, additionalConfirmations uint64) types.ContractConfigTracker {
+	return &reorgProtection{
+		tracker,
+		additionalConfirmations,
+		sync.Mutex{},
+		nil,
+		nil,
+		0,
+	}
+}
+
+type reorgProtection struct {
+	types.ContractConfigTracker
+	additionalConfirmations uint64
+
+	mutex sync.Mutex
+	// nil until the first pair has been seen
+	verified *configDetails
+	// pair awaiting verification, nil if none
+	candidate *configDetails
+	// block height at which candidate was first seen
+	candidateSeenAt uint64
+}
+
+func (r *reorgProtection) LatestConfigDetails(ctx context.Context) (uint64, types.ConfigDigest, error) {
+	changedInBlock, configDigest, err := r.ContractConfigTracker.LatestConfigDetails(ctx)
+	if err != nil {
+		return 0, types.ConfigDigest{}, err
+	}
+	details := configDetails{changedInBlock, configDigest}
+
+	r.mutex.Lock()
+	verified := r.verified
+	candidate := r.candidate
+	candidateSeenAt := r.candidateSeenAt
+	r.mutex.Unlock()
+
+	if verified == nil {
+		r.setVerified(details)
+		return details.changedInBlock, details.configDigest, nil
+	}
+	if details == *verified {
+		r.setCandidate(nil, 0)
+		return verified.changedInBlock, verified.configDigest, nil
+	}
+
+	blockHeight, err := r.ContractConfigTracker.LatestBlockHeight(ctx)
+	if err != nil {
+		return 0, types.ConfigDigest{}, fmt.Errorf("ReorgProtection: error during LatestBlockHeight: %w", err)
+	}
+
+	if candidate == nil || details != *candidate {
+		r.setCandidate(&details, blockHeight)
+		candidateSeenAt = blockHeight
+	}
+	if blockHeight < candidateSeenAt+r.additionalConfirmations {
+		return verified.changedInBlock, verified.configDigest, nil
+	}
+
+	r.setVerified(details)
+	return details.changedInBlock, details.configDigest, nil
+}
+
+func (r *reorgProtection) setVerified(details configDetails) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.verified = &details
+	r.candidate = nil
+	r.candidateSeenAt = 0
+}
+
+func (r *reorgProtection) setCandidate(details *configDetails, seenAt uint64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.candidate = details
+	r.candidateSeenAt = seenAt
+}
@@ -0,0 +1,133 @@
+// Package dryrun provides TransmissionRecorders for running oracles in dry-run
+// mode (see OracleArgs.DryRunRecorder), e.g. to run a staging committee against
+// the configuration and data of a production contract without spending gas or
+// racing the production transmitters.
+package dryrun
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+var _ types.TransmissionRecorder = (*InMemoryTransmissionRecorder)(nil)
+
+// InMemoryTransmissionRecorder keeps all recorded transmissions in memory.
+// It's intended for tests and for exposing recent transmissions through an
+// API. If MaxTransmissions is positive, only that many of the latest
+// transmissions are kept.
+type InMemoryTransmissionRecorder struct {
+	lock             sync.Mutex
+	maxTransmissions int
+	transmissions    []types.RecordedTransmission
+}
+
+func NewInMemoryTransmissionRecorder(maxTransmissions int) *InMemoryTransmissionRecorder {
+	return &InMemoryTransmissionRecorder{
+		sync.Mutex{},
+		maxTransmissions,
+		nil,
+	}
+}
+
+func (r *InMemoryTransmissionRecorder) Record(ctx context.Context, transmission types.RecordedTransmission) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.transmissions = append(r.transmissions, transmission)
+	if 0 < r.maxTransmissions && r.maxTransmissions < len(r.transmissions) {
+		r.transmissions = append([]types.RecordedTransmission{}, r.transmissions[len(r.transmissions)-r.maxTransmissions:]...)
+	}
+	return nil
+}
+
+// Transmissions returns the recorded transmissions, oldest first.
+func (r *InMemoryTransmissionRecorder) Transmissions() []types.RecordedTransmission {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]types.RecordedTransmission{}, r.transmissions...)
+}
+
+var _ types.TransmissionRecorder = (*JSONLinesTransmissionRecorder)(nil)
+
+// JSONLinesTransmissionRecorder writes every recorded transmission as a
+// single line of JSON (see TransmissionJSON) to a writer, typically a file
+// opened for appending.
+type JSONLinesTransmissionRecorder struct {
+	lock   sync.Mutex
+	writer io.Writer
+}
+
+func NewJSONLinesTransmissionRecorder(writer io.Writer) *JSONLinesTransmissionRecorder {
+	return &JSONLinesTransmissionRecorder{
+		sync.Mutex{},
+		writer,
+	}
+}
+
+// TransmissionJSON is the JSON representation of a types.RecordedTransmission
+// written by JSONLinesTransmissionRecorder. Byte strings are hex-encoded.
+type TransmissionJSON struct {
+	Time                 time.Time       `json:"time"`
+	Stage                string          `json:"stage"`
+	Target               string          `json:"target,omitempty"`
+	ConfigDigest         string          `json:"configDigest"`
+	Epoch                uint32          `json:"epoch"`
+	Round                uint8           `json:"round"`
+	ExtraHash            string          `json:"extraHash"`
+	ReportIndex          uint8           `json:"reportIndex"`
+	Report               string          `json:"report"`
+	AttributedSignatures []SignatureJSON `json:"attributedSignatures"`
+	ScheduledTime        *time.Time      `json:"scheduledTime,omitempty"`
+}
+
+type SignatureJSON struct {
+	Signature string               `json:"signature"`
+	Signer    commontypes.OracleID `json:"signer"`
+}
+
+func MakeTransmissionJSON(transmission types.RecordedTransmission) TransmissionJSON {
+	signatures := make([]SignatureJSON, 0, len(transmission.AttributedSignatures))
+	for _, as := range transmission.AttributedSignatures {
+		signatures = append(signatures, SignatureJSON{hex.EncodeToString(as.Signature), as.Signer})
+	}
+	var scheduledTime *time.Time
+	if !transmission.ScheduledTime.IsZero() {
+		t := transmission.ScheduledTime
+		scheduledTime = &t
+	}
+	return TransmissionJSON{
+		transmission.Time,
+		transmission.Stage.String(),
+		transmission.Target,
+		transmission.ReportContext.ConfigDigest.Hex(),
+		transmission.ReportContext.Epoch,
+		transmission.ReportContext.Round,
+		hex.EncodeToString(transmission.ReportContext.ExtraHash[:]),
+		transmission.ReportContext.ReportIndex,
+		hex.EncodeToString(transmission.Report),
+		signatures,
+		scheduledTime,
+	}
+}
+
+func (r *JSONLinesTransmissionRecorder) Record(ctx context.Context, transmission types.RecordedTransmission) error {
+	line, err := json.Marshal(MakeTransmissionJSON(transmission))
+	if err != nil {
+		return fmt.Errorf("error while marshaling transmission: %w", err)
+	}
+	line = append(line, '\n')
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, err := r.writer.Write(line); err != nil {
+		return fmt.Errorf("error while writing transmission: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"sync"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// LocalConfigHolder holds the current LocalConfig of a running oracle, which
+// may be replaced while the oracle is running. Readers should call Get every
+// time they need a value, rather than caching the result, so that updates
+// take effect promptly. Safe for concurrent use.
+type LocalConfigHolder struct {
+	mutex       sync.RWMutex
+	localConfig types.LocalConfig
+}
+
+func NewLocalConfigHolder(localConfig types.LocalConfig) *LocalConfigHolder {
+	return &LocalConfigHolder{sync.RWMutex{}, localConfig}
+}
+
+func (h *LocalConfigHolder) Get() types.LocalConfig {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return h.localConfig
+}
+
+// Set replaces the LocalConfig. The caller is responsible for validating
+// localConfig.
+func (h *LocalConfigHolder) Set(localConfig types.LocalConfig) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.localConfig = localConfig
+}
@@ -70,6 +70,19 @@ type PublicConfig struct {
 	MaxDurationShouldAcceptFinalizedReport  time.Duration
 	MaxDurationShouldTransmitAcceptedReport time.Duration
 
+	// If CommitRevealObservations is set, followers first send the leader a
+	// signed commitment to their observation and only reveal the observation
+	// once the leader has broadcast more than 2f commitments. This prevents
+	// oracles from copying the observations of others. It costs an extra
+	// network round trip and DeltaGrace per round. If committed oracles
+	// don't reveal, the leader extends the set of commitments by late ones
+	// every DeltaGrace, which delays the round accordingly. Requires a
+	// positive DeltaGrace, and MaxDurationQuery + MaxDurationObservation +
+	// MaxDurationReport + 3*DeltaGrace must be less than DeltaProgress. All
+	// oracles must run a version of libocr that supports this flag before it
+	// is enabled.
+	CommitRevealObservations bool
+
 	// The maximum number of oracles that are assumed to be faulty while the
 	// protocol can retain liveness and safety. Unless you really know what
 	// you’re doing, be sure to set this to floor(n/3) where n is the total
@@ -153,6 +166,7 @@ func publicConfigFromContractConfig(skipResourceExhaustionChecks bool, change ty
 		oc.MaxDurationReport,
 		oc.MaxDurationShouldAcceptFinalizedReport,
 		oc.MaxDurationShouldTransmitAcceptedReport,
+		oc.CommitRevealObservations,
 
 		int(change.F),
 		change.OnchainConfig,
@@ -283,6 +297,12 @@ func checkPublicConfigParameters(cfg PublicConfig) error {
 			cfg.DeltaGrace)
 	}
 
+	// With commit-reveal, the leader retries reveal requests every DeltaGrace
+	if cfg.CommitRevealObservations && !(0 < cfg.DeltaGrace) {
+		return fmt.Errorf("DeltaGrace (%v) must be positive if CommitRevealObservations is set",
+			cfg.DeltaGrace)
+	}
+
 	if !(0 <= cfg.MaxDurationQuery) {
 		return fmt.Errorf("MaxDurationQuery (%v) must be non-negative", cfg.MaxDurationQuery)
 	}
@@ -314,6 +334,17 @@ func checkPublicConfigParameters(cfg PublicConfig) error {
 			sumMaxDurationsReportGeneration, cfg.DeltaProgress)
 	}
 
+	// With commit-reveal, a round takes two grace periods, and the extra
+	// commit/reveal round trip takes up to another DeltaGrace before the
+	// leader retries its reveal request.
+	if cfg.CommitRevealObservations {
+		sumCommitReveal := sumMaxDurationsReportGeneration + 3*cfg.DeltaGrace
+		if !(sumCommitReveal < cfg.DeltaProgress) {
+			return fmt.Errorf("sum of MaxDurationQuery/Observation/Report and 3*DeltaGrace (%v) must be less than DeltaProgress (%v) if CommitRevealObservations is set",
+				sumCommitReveal, cfg.DeltaProgress)
+		}
+	}
+
 	// We cannot easily add a similar check for the MaxDuration variables used
 	// in the transmission protocol (MaxDurationShouldAcceptFinalizedReport,
 	// MaxDurationShouldTransmitAcceptedReport), because we don't know how often
@@ -37,6 +37,7 @@ type offchainConfig struct {
 	MaxDurationShouldAcceptFinalizedReport  time.Duration
 	MaxDurationShouldTransmitAcceptedReport time.Duration
 	SharedSecretEncryptions                 SharedSecretEncryptions
+	CommitRevealObservations                bool
 }
 
 // serialize returns a binary serialization of o
@@ -109,6 +110,7 @@ func deprotoOffchainConfig(
 		time.Duration(offchainConfigProto.GetMaxDurationShouldAcceptFinalizedReportNanoseconds()),
 		time.Duration(offchainConfigProto.GetMaxDurationShouldTransmitAcceptedReportNanoseconds()),
 		sharedSecretEncryptions,
+		offchainConfigProto.GetCommitRevealObservations(),
 	}, nil
 }
 
@@ -175,6 +177,7 @@ func enprotoOffchainConfig(o offchainConfig) OffchainConfigProto {
 		uint64(o.MaxDurationShouldAcceptFinalizedReport),
 		uint64(o.MaxDurationShouldTransmitAcceptedReport),
 		&sharedSecretEncryptions,
+		o.CommitRevealObservations,
 	}
 }
 
@@ -41,13 +41,16 @@ func (c *SharedConfig) TransmissionOrderKey() [16]byte {
 	return result
 }
 
+// SharedConfigFromContractConfig locates the local oracle in change by its
+// OnchainPublicKey and checks that the remaining identity matches. The
+// configured TransmitAccount may be any of transmitAccounts.
 func SharedConfigFromContractConfig(
 	skipResourceExhaustionChecks bool,
 	change types.ContractConfig,
 	offchainKeyring types.OffchainKeyring,
 	onchainKeyring types.OnchainKeyring,
 	peerID string,
-	transmitAccount types.Account,
+	transmitAccounts []types.Account,
 ) (SharedConfig, commontypes.OracleID, error) {
 	publicConfig, encSharedSecret, err := publicConfigFromContractConfig(skipResourceExhaustionChecks, change)
 	if err != nil {
@@ -73,11 +76,11 @@ func SharedConfigFromContractConfig(
 							"mine, but PeerID does not: %v (config) vs %v (mine)",
 						onchainPublicKey, identity.PeerID, peerID)
 				}
-				if identity.TransmitAccount != transmitAccount {
+				if !containsAccount(transmitAccounts, identity.TransmitAccount) {
 					return SharedConfig{}, 0, errors.Errorf(
 						"OnchainPublicKey %x in publicConfig matches "+
 							"mine, but TransmitAccount does not: %v (config) vs %v (mine)",
-						onchainPublicKey, identity.TransmitAccount, transmitAccount)
+						onchainPublicKey, identity.TransmitAccount, transmitAccounts)
 				}
 				oracleID = commontypes.OracleID(i)
 				found = true
@@ -103,6 +106,15 @@ func SharedConfigFromContractConfig(
 
 }
 
+func containsAccount(accounts []types.Account, account types.Account) bool {
+	for _, a := range accounts {
+		if a == account {
+			return true
+		}
+	}
+	return false
+}
+
 func XXXContractSetConfigArgsFromSharedConfig(
 	c SharedConfig,
 	sharedSecretEncryptionPublicKeys []types.ConfigEncryptionPublicKey,
@@ -147,6 +159,7 @@ func XXXContractSetConfigArgsFromSharedConfig(
 			c.SharedSecret,
 			cryptorand.Reader,
 		),
+		c.CommitRevealObservations,
 	}).serialize()
 	err = nil
 	return
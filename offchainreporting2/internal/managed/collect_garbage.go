@@ -7,6 +7,7 @@ import (
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/config"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 )
 
@@ -17,7 +18,7 @@ const olderThan = 24 * time.Hour
 func collectGarbage(
 	ctx context.Context,
 	database types.Database,
-	localConfig types.LocalConfig,
+	localConfig *config.LocalConfigHolder,
 	logger loghelper.LoggerWithContext,
 ) {
 	for {
@@ -32,7 +33,7 @@ func collectGarbage(
 			})
 			// To make sure the context is not leaked we are wrapping the database query.
 			func() {
-				childCtx, childCancel := context.WithTimeout(ctx, localConfig.DatabaseTimeout)
+				childCtx, childCancel := context.WithTimeout(ctx, localConfig.Get().DatabaseTimeout)
 				defer childCancel()
 				err := database.DeletePendingTransmissionsOlderThan(childCtx, time.Now().Add(-olderThan))
 				if err != nil {
@@ -0,0 +1,105 @@
+package managed
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// configHistory records enacted configs if the database implements
+// types.ConfigHistoryDatabase. It is only used from runWithContractConfig's
+// event loop and therefore not thread-safe.
+type configHistory struct {
+	database types.ConfigHistoryDatabase // may be nil
+	logger   loghelper.LoggerWithContext
+
+	// whether latest reflects the database
+	loaded bool
+	// nil if the history is empty
+	latest *types.ConfigHistoryEntry
+}
+
+func newConfigHistory(database types.ConfigDatabase, logger loghelper.LoggerWithContext) *configHistory {
+	configHistoryDatabase, _ := database.(types.ConfigHistoryDatabase)
+	return &configHistory{
+		configHistoryDatabase,
+		logger,
+		false,
+		nil,
+	}
+}
+
+func (h *configHistory) load(ctx context.Context) bool {
+	if h.loaded {
+		return true
+	}
+	entries, err := h.database.ReadConfigHistory(ctx)
+	if err != nil {
+		h.logger.ErrorIfNotCanceled("configHistory: error during ReadConfigHistory", ctx, commontypes.LogFields{
+			"error": err,
+		})
+		return false
+	}
+	if len(entries) != 0 {
+		latest := entries[len(entries)-1]
+		h.latest = &latest
+	}
+	h.loaded = true
+	return true
+}
+
+// record appends contractConfig to the history and marks the previous entry
+// as replaced. If contractConfig is already the latest entry, e.g. because it
+// was restored from the database on startup, record does nothing.
+func (h *configHistory) record(ctx context.Context, contractConfig types.ContractConfig, changedInBlock uint64) {
+	if h.database == nil {
+		return
+	}
+	if !h.load(ctx) {
+		// we don't know the latest sequence number, so we'd risk overwriting
+		// an existing entry
+		return
+	}
+	if h.latest != nil && h.latest.ConfigDigest == contractConfig.ConfigDigest && h.latest.ReplacedBy == (types.ConfigDigest{}) {
+		return
+	}
+
+	now := time.Now()
+	sequence := uint64(1)
+	if h.latest != nil {
+		sequence = h.latest.Sequence + 1
+		replaced := *h.latest
+		replaced.ReplacedBy = contractConfig.ConfigDigest
+		replaced.ReplacedAt = now
+		if err := h.database.WriteConfigHistoryEntry(ctx, replaced); err != nil {
+			h.logger.ErrorIfNotCanceled("configHistory: error during WriteConfigHistoryEntry for replaced config", ctx, commontypes.LogFields{
+				"error":        err,
+				"configDigest": replaced.ConfigDigest,
+			})
+		}
+	}
+
+	entry := types.ConfigHistoryEntry{
+		sequence,
+		contractConfig.ConfigDigest,
+		contractConfig.ConfigCount,
+		changedInBlock,
+		now,
+		types.ConfigDigest{},
+		time.Time{},
+	}
+	if err := h.database.WriteConfigHistoryEntry(ctx, entry); err != nil {
+		h.logger.ErrorIfNotCanceled("configHistory: error during WriteConfigHistoryEntry", ctx, commontypes.LogFields{
+			"error":        err,
+			"configDigest": entry.ConfigDigest,
+		})
+		// force a reload, so that we don't skip sequence numbers
+		h.loaded = false
+		h.latest = nil
+		return
+	}
+	h.latest = &entry
+}
@@ -0,0 +1,302 @@
+package managed
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// handover coordinates the protocol instance that runWithContractConfig's fn
+// runs for a config with the instances for the previous and the next config.
+// See types.LocalConfig.ConfigHandoverWindow for the rules.
+type handover struct {
+	// fn should close chStarted once it is fully up and running
+	chStarted chan struct{}
+	// closed once the successor has closed its chStarted
+	chRetire chan struct{}
+	// fn should close chDrained once chRetire is closed and it has no pending
+	// transmissions left
+	chDrained chan struct{}
+	// closed once fn has returned for all earlier configs
+	chPredecessorsDone <-chan struct{}
+
+	mutex     sync.Mutex
+	successor *types.ConfigDigest
+}
+
+// Successor returns the digest of the config that superseded the instance's
+// config, if the instance is only kept running for a handover.
+func (h *handover) Successor() (types.ConfigDigest, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.successor == nil {
+		return types.ConfigDigest{}, false
+	}
+	return *h.successor, true
+}
+
+func (h *handover) setSuccessor(configDigest types.ConfigDigest) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.successor = &configDigest
+}
+
+const chTransmissionResultsCapacity = 100
+
+// transmissionResultsDemux consumes the TransmissionResults of a
+// ContractTransmitter that is shared by the protocol instances of several
+// configs during a handover, and routes each result to the instance for the
+// config digest in its ReportContext.
+type transmissionResultsDemux struct {
+	mutex       sync.Mutex
+	subscribers map[types.ConfigDigest]chan types.TransmissionResult
+}
+
+func newTransmissionResultsDemux() *transmissionResultsDemux {
+	return &transmissionResultsDemux{
+		sync.Mutex{},
+		map[types.ConfigDigest]chan types.TransmissionResult{},
+	}
+}
+
+// run returns once ctx is done
+func (d *transmissionResultsDemux) run(
+	ctx context.Context,
+	chResults <-chan types.TransmissionResult,
+	target string,
+	logger loghelper.LoggerWithContext,
+) {
+	for {
+		select {
+		case result := <-chResults:
+			d.mutex.Lock()
+			ch, ok := d.subscribers[result.ReportContext.ConfigDigest]
+			d.mutex.Unlock()
+			if !ok {
+				logger.Debug("transmissionResultsDemux: dropping result for config that isn't running", commontypes.LogFields{
+					"target":       target,
+					"configDigest": result.ReportContext.ConfigDigest,
+				})
+				continue
+			}
+			select {
+			case ch <- result:
+			default:
+				logger.Warn("transmissionResultsDemux: dropping result, channel is overflowing", commontypes.LogFields{
+					"target":       target,
+					"configDigest": result.ReportContext.ConfigDigest,
+				})
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// subscribe returns the channel on which results for configDigest are
+// delivered until unsubscribe is called. The channel is never closed.
+func (d *transmissionResultsDemux) subscribe(configDigest types.ConfigDigest) (results <-chan types.TransmissionResult, unsubscribe func()) {
+	ch := make(chan types.TransmissionResult, chTransmissionResultsCapacity)
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.subscribers[configDigest] = ch
+	return ch, func() {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+		if d.subscribers[configDigest] == ch {
+			delete(d.subscribers, configDigest)
+		}
+	}
+}
+
+const chTransmissionAdminCapacity = 10
+
+// transmissionAdminRouter consumes the oracle's TransmissionAdminRequests and
+// routes each of them to a single protocol instance: Requests for a specific
+// pending transmission go to the instance for its config digest, all others to
+// the instance for the newest config.
+type transmissionAdminRouter struct {
+	mutex sync.Mutex
+	// ordered from oldest to newest config
+	configDigests []types.ConfigDigest
+	subscribers   map[types.ConfigDigest]chan protocol.TransmissionAdminRequest
+}
+
+func newTransmissionAdminRouter() *transmissionAdminRouter {
+	return &transmissionAdminRouter{
+		sync.Mutex{},
+		nil,
+		map[types.ConfigDigest]chan protocol.TransmissionAdminRequest{},
+	}
+}
+
+// run returns once ctx is done
+func (r *transmissionAdminRouter) run(
+	ctx context.Context,
+	chRequests <-chan protocol.TransmissionAdminRequest,
+	logger loghelper.LoggerWithContext,
+) {
+	for {
+		select {
+		case req := <-chRequests:
+			ch, configDigest, ok := r.route(req)
+			if !ok {
+				if req, ok := req.(protocol.CancelQueuedTransmissionRequest); ok {
+					// the transmission isn't queued by any running instance
+					req.ChResponse <- false
+					continue
+				}
+				logger.Debug("transmissionAdminRouter: dropping request, no protocol instance is running", nil)
+				continue
+			}
+			select {
+			case ch <- req:
+			default:
+				logger.Warn("transmissionAdminRouter: dropping request, channel is overflowing", commontypes.LogFields{
+					"configDigest": configDigest,
+				})
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *transmissionAdminRouter) route(req protocol.TransmissionAdminRequest) (chan<- protocol.TransmissionAdminRequest, types.ConfigDigest, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var configDigest types.ConfigDigest
+	if req, ok := req.(protocol.CancelQueuedTransmissionRequest); ok {
+		configDigest = req.Key.ConfigDigest
+	} else if len(r.configDigests) != 0 {
+		configDigest = r.configDigests[len(r.configDigests)-1]
+	} else {
+		return nil, types.ConfigDigest{}, false
+	}
+	ch, ok := r.subscribers[configDigest]
+	return ch, configDigest, ok
+}
+
+// subscribe returns the channel on which requests for the instance for
+// configDigest are delivered until unsubscribe is called. The channel is never
+// closed.
+func (r *transmissionAdminRouter) subscribe(configDigest types.ConfigDigest) (requests <-chan protocol.TransmissionAdminRequest, unsubscribe func()) {
+	ch := make(chan protocol.TransmissionAdminRequest, chTransmissionAdminCapacity)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.configDigests = append(removeConfigDigest(r.configDigests, configDigest), configDigest)
+	r.subscribers[configDigest] = ch
+	return ch, func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		if r.subscribers[configDigest] == ch {
+			delete(r.subscribers, configDigest)
+			r.configDigests = removeConfigDigest(r.configDigests, configDigest)
+		}
+	}
+}
+
+func removeConfigDigest(configDigests []types.ConfigDigest, configDigest types.ConfigDigest) []types.ConfigDigest {
+	result := make([]types.ConfigDigest, 0, len(configDigests))
+	for _, cd := range configDigests {
+		if cd != configDigest {
+			result = append(result, cd)
+		}
+	}
+	return result
+}
+
+// sharedTarget is a TransmissionTarget whose ContractTransmitter is shared by
+// all protocol instances of the oracle
+type sharedTarget struct {
+	target types.TransmissionTarget
+	// nil unless target.ContractTransmitter is a TransmissionResultReporter
+	results *transmissionResultsDemux
+}
+
+// instanceTarget returns the view of t for a single protocol instance.
+// Transmit waits for chTransmitReady. Once the instance is superseded, it
+// stops transmitting to t as soon as t reports a transmission for the
+// successor's config digest. results carries only the instance's results.
+// filter is used if t has no TransmissionFilter of its own.
+func (t sharedTarget) instanceTarget(
+	chTransmitReady <-chan struct{},
+	filter types.TransmissionFilter,
+	handover *handover,
+	results <-chan types.TransmissionResult,
+	logger loghelper.LoggerWithContext,
+) types.TransmissionTarget {
+	if t.target.TransmissionFilter != nil {
+		filter = t.target.TransmissionFilter
+	}
+	transmitter := instanceTransmitter{t.target.ContractTransmitter, chTransmitReady}
+	var contractTransmitter types.ContractTransmitter = transmitter
+	if t.results != nil {
+		contractTransmitter = instanceResultReporter{transmitter, results}
+	}
+	return types.TransmissionTarget{
+		t.target.Name,
+		contractTransmitter,
+		handoverFilter{filter, t.target.ContractTransmitter, handover, t.target.Name, logger},
+	}
+}
+
+type instanceTransmitter struct {
+	types.ContractTransmitter
+	chTransmitReady <-chan struct{}
+}
+
+func (t instanceTransmitter) Transmit(
+	ctx context.Context,
+	reportContext types.ReportContext,
+	report types.Report,
+	signatures []types.AttributedOnchainSignature,
+) error {
+	select {
+	case <-t.chTransmitReady:
+	case <-ctx.Done():
+		return fmt.Errorf("instance for previous config is still transmitting: %w", ctx.Err())
+	}
+	return t.ContractTransmitter.Transmit(ctx, reportContext, report, signatures)
+}
+
+type instanceResultReporter struct {
+	instanceTransmitter
+	chResults <-chan types.TransmissionResult
+}
+
+var _ types.TransmissionResultReporter = instanceResultReporter{}
+
+func (t instanceResultReporter) TransmissionResults() <-chan types.TransmissionResult {
+	return t.chResults
+}
+
+type handoverFilter struct {
+	filter              types.TransmissionFilter
+	contractTransmitter types.ContractTransmitter
+	handover            *handover
+	target              string
+	logger              loghelper.LoggerWithContext
+}
+
+func (f handoverFilter) ShouldTransmitAcceptedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	if successor, ok := f.handover.Successor(); ok {
+		configDigest, _, err := f.contractTransmitter.LatestConfigDigestAndEpoch(ctx)
+		if err != nil {
+			return false, fmt.Errorf("error during LatestConfigDigestAndEpoch while handing over to %v: %w", successor, err)
+		}
+		if configDigest == successor {
+			f.logger.Info("handoverFilter: target has switched to the new config, not transmitting report of old config", commontypes.LogFields{
+				"target":          f.target,
+				"newConfigDigest": successor,
+			})
+			return false, nil
+		}
+	}
+	return f.filter.ShouldTransmitAcceptedReport(ctx, ts, report)
+}
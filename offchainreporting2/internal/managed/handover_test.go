@@ -0,0 +1,320 @@
+package managed
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/config"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Trace(string, commontypes.LogFields)    {}
+func (nopLogger) Debug(string, commontypes.LogFields)    {}
+func (nopLogger) Info(string, commontypes.LogFields)     {}
+func (nopLogger) Warn(string, commontypes.LogFields)     {}
+func (nopLogger) Error(string, commontypes.LogFields)    {}
+func (nopLogger) Critical(string, commontypes.LogFields) {}
+
+var testLogger = loghelper.MakeRootLoggerWithContext(nopLogger{})
+
+const testTimeout = 5 * time.Second
+
+// routedTo returns the config digest that router routes req to, and checks that
+// req arrives on ch
+func routedTo(t *testing.T, router *transmissionAdminRouter, req protocol.TransmissionAdminRequest, ch <-chan protocol.TransmissionAdminRequest) types.ConfigDigest {
+	t.Helper()
+	chRouted, configDigest, ok := router.route(req)
+	if !ok {
+		t.Fatalf("no route for %v", req)
+	}
+	chRouted <- req
+	select {
+	case <-ch:
+	default:
+		t.Fatalf("request routed to %v didn't arrive on expected channel", configDigest)
+	}
+	return configDigest
+}
+
+func TestTransmissionAdminRouterRoute(t *testing.T) {
+	older, newer := types.ConfigDigest{1}, types.ConfigDigest{2}
+	router := newTransmissionAdminRouter()
+
+	list := protocol.ListTransmissionQueueRequest{nil}
+	cancelFor := func(configDigest types.ConfigDigest) protocol.CancelQueuedTransmissionRequest {
+		return protocol.CancelQueuedTransmissionRequest{types.PendingTransmissionKey{types.ReportTimestamp{configDigest, 1, 1}, 0, ""}, nil}
+	}
+
+	if _, _, ok := router.route(list); ok {
+		t.Fatal("expected no route without subscribers")
+	}
+
+	chOlder, unsubscribeOlder := router.subscribe(older)
+	chNewer, unsubscribeNewer := router.subscribe(newer)
+
+	for _, tc := range []struct {
+		name     string
+		req      protocol.TransmissionAdminRequest
+		ch       <-chan protocol.TransmissionAdminRequest
+		expected types.ConfigDigest
+	}{
+		{"list goes to newest instance", list, chNewer, newer},
+		{"cancel goes to older instance", cancelFor(older), chOlder, older},
+		{"cancel goes to newer instance", cancelFor(newer), chNewer, newer},
+	} {
+		if configDigest := routedTo(t, router, tc.req, tc.ch); configDigest != tc.expected {
+			t.Errorf("%v: routed to %v", tc.name, configDigest)
+		}
+	}
+
+	if _, _, ok := router.route(cancelFor(types.ConfigDigest{3})); ok {
+		t.Error("expected no route for cancel of a config that isn't running")
+	}
+
+	unsubscribeNewer()
+	if configDigest := routedTo(t, router, list, chOlder); configDigest != older {
+		t.Errorf("expected list to go to remaining instance, routed to %v", configDigest)
+	}
+
+	// resubscribing makes older the newest instance again
+	_, unsubscribeNewer = router.subscribe(newer)
+	chOlder2, unsubscribeOlder2 := router.subscribe(older)
+	if configDigest := routedTo(t, router, list, chOlder2); configDigest != older {
+		t.Errorf("expected list to go to resubscribed instance, routed to %v", configDigest)
+	}
+	// a stale unsubscribe doesn't remove the new subscription
+	unsubscribeOlder()
+	if configDigest := routedTo(t, router, list, chOlder2); configDigest != older {
+		t.Errorf("stale unsubscribe removed subscription, routed to %v", configDigest)
+	}
+	unsubscribeOlder2()
+	unsubscribeNewer()
+	if _, _, ok := router.route(list); ok {
+		t.Error("expected no route after all instances unsubscribed")
+	}
+}
+
+func TestTransmissionAdminRouterRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	router := newTransmissionAdminRouter()
+	chRequests := make(chan protocol.TransmissionAdminRequest)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		router.run(ctx, chRequests, testLogger)
+	}()
+
+	// cancel of a transmission that no instance has queued is answered by
+	// the router
+	chResponse := make(chan bool, 1)
+	chRequests <- protocol.CancelQueuedTransmissionRequest{types.PendingTransmissionKey{types.ReportTimestamp{types.ConfigDigest{1}, 1, 1}, 0, ""}, chResponse}
+	select {
+	case response := <-chResponse:
+		if response {
+			t.Error("expected false response")
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("timed out waiting for response")
+	}
+
+	chAdmin, unsubscribe := router.subscribe(types.ConfigDigest{1})
+	defer unsubscribe()
+	req := protocol.ListTransmissionQueueRequest{make(chan protocol.ListTransmissionQueueResponse, 1)}
+	chRequests <- req
+	select {
+	case routed := <-chAdmin:
+		if routed != protocol.TransmissionAdminRequest(req) {
+			t.Errorf("unexpected request %v", routed)
+		}
+	case <-time.After(testTimeout):
+		t.Fatal("timed out waiting for request")
+	}
+
+	cancel()
+	<-done
+}
+
+func TestTransmissionResultsDemux(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	older, newer := types.ConfigDigest{1}, types.ConfigDigest{2}
+	demux := newTransmissionResultsDemux()
+	chOlder, unsubscribeOlder := demux.subscribe(older)
+	defer unsubscribeOlder()
+	chNewer, unsubscribeNewer := demux.subscribe(newer)
+	defer unsubscribeNewer()
+
+	chResults := make(chan types.TransmissionResult)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		demux.run(ctx, chResults, "", testLogger)
+	}()
+
+	result := func(configDigest types.ConfigDigest, round uint8) types.TransmissionResult {
+		return types.TransmissionResult{types.ReportContext{types.ReportTimestamp{configDigest, 1, round}, [32]byte{}, 0}, true}
+	}
+	// results for configs that aren't running are dropped
+	chResults <- result(types.ConfigDigest{3}, 1)
+	chResults <- result(newer, 2)
+	chResults <- result(older, 3)
+
+	for _, tc := range []struct {
+		ch       <-chan types.TransmissionResult
+		expected types.TransmissionResult
+	}{
+		{chNewer, result(newer, 2)},
+		{chOlder, result(older, 3)},
+	} {
+		select {
+		case r := <-tc.ch:
+			if r != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, r)
+			}
+		case <-time.After(testTimeout):
+			t.Fatalf("timed out waiting for %v", tc.expected)
+		}
+	}
+
+	cancel()
+	<-done
+	select {
+	case r := <-chOlder:
+		t.Errorf("unexpected result %v", r)
+	case r := <-chNewer:
+		t.Errorf("unexpected result %v", r)
+	default:
+	}
+}
+
+func newTestHandover() *handover {
+	chPredecessorsDone := make(chan struct{})
+	close(chPredecessorsDone)
+	return &handover{make(chan struct{}), make(chan struct{}), make(chan struct{}), chPredecessorsDone, sync.Mutex{}, nil}
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestHandOver(t *testing.T) {
+	const window = 200 * time.Millisecond
+
+	for _, tc := range []struct {
+		name          string
+		started       bool
+		drained       bool
+		retired       bool
+		beforeWindow  bool
+		cancelRunning bool
+	}{
+		{"successor starts and instance drains", true, true, true, true, false},
+		{"successor starts, instance doesn't drain", true, false, true, false, false},
+		{"successor doesn't start", false, false, false, false, false},
+		{"runWithContractConfig exits", false, false, false, true, true},
+	} {
+		ctx, cancel := context.WithCancel(context.Background())
+		rwcc := &runWithContractConfigState{
+			ctx:         ctx,
+			localConfig: config.NewLocalConfigHolder(types.LocalConfig{ConfigHandoverWindow: window}),
+			logger:      testLogger,
+		}
+		oldHandover := newTestHandover()
+		chStarted := make(chan struct{})
+		chOldCancelled := make(chan struct{})
+
+		begin := time.Now()
+		rwcc.handOver(func() { close(chOldCancelled) }, oldHandover, types.ConfigDigest{1}, types.ConfigDigest{2}, chStarted)
+		if tc.cancelRunning {
+			cancel()
+		}
+		if tc.started {
+			close(chStarted)
+		}
+		if tc.drained {
+			select {
+			case <-oldHandover.chRetire:
+			case <-time.After(testTimeout):
+				t.Fatalf("%v: timed out waiting for chRetire", tc.name)
+			}
+			close(oldHandover.chDrained)
+		}
+
+		select {
+		case <-chOldCancelled:
+		case <-time.After(testTimeout):
+			t.Fatalf("%v: timed out waiting for old instance to be cancelled", tc.name)
+		}
+		if elapsed := time.Since(begin); (elapsed < window) != tc.beforeWindow {
+			t.Errorf("%v: old instance cancelled after %v, window is %v", tc.name, elapsed, window)
+		}
+		if isClosed(oldHandover.chRetire) != tc.retired {
+			t.Errorf("%v: expected chRetire closed to be %v", tc.name, tc.retired)
+		}
+
+		cancel()
+		rwcc.otherSubs.Wait()
+	}
+}
+
+// latestConfigDigestTransmitter reports a fixed config digest. All other
+// functions panic.
+type latestConfigDigestTransmitter struct {
+	types.ContractTransmitter
+	configDigest types.ConfigDigest
+	err          error
+}
+
+func (c latestConfigDigestTransmitter) LatestConfigDigestAndEpoch(context.Context) (types.ConfigDigest, uint32, error) {
+	return c.configDigest, 0, c.err
+}
+
+type constantFilter bool
+
+func (f constantFilter) ShouldTransmitAcceptedReport(context.Context, types.ReportTimestamp, types.Report) (bool, error) {
+	return bool(f), nil
+}
+
+func TestHandoverFilter(t *testing.T) {
+	old, successor := types.ConfigDigest{1}, types.ConfigDigest{2}
+
+	for _, tc := range []struct {
+		name           string
+		successor      bool
+		latest         types.ConfigDigest
+		latestErr      error
+		shouldTransmit bool
+		err            bool
+	}{
+		{"no successor", false, successor, nil, true, false},
+		{"target still on old config", true, old, nil, true, false},
+		{"target switched to successor", true, successor, nil, false, false},
+		{"error reading target", true, old, errors.New("boom"), false, true},
+	} {
+		h := newTestHandover()
+		if tc.successor {
+			h.setSuccessor(successor)
+		}
+		filter := handoverFilter{constantFilter(true), latestConfigDigestTransmitter{nil, tc.latest, tc.latestErr}, h, "", testLogger}
+		shouldTransmit, err := filter.ShouldTransmitAcceptedReport(context.Background(), types.ReportTimestamp{old, 1, 1}, nil)
+		if shouldTransmit != tc.shouldTransmit || (err != nil) != tc.err {
+			t.Errorf("%v: expected (%v, error: %v), got (%v, %v)", tc.name, tc.shouldTransmit, tc.err, shouldTransmit, err)
+		}
+	}
+}
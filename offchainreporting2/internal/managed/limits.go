@@ -9,10 +9,11 @@ import (
 	"time"
 
 	"github.com/smartcontractkit/libocr/offchainreporting2/internal/config"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/protocol"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 )
 
-func limits(cfg config.PublicConfig, reportingPluginLimits types.ReportingPluginLimits, maxSigLen int) (types.BinaryNetworkEndpointLimits, error) {
+func limits(cfg config.PublicConfig, reportingPluginLimits types.ReportingPluginLimits, maxSigLen int, outcomeStates bool, multiReports bool) (types.BinaryNetworkEndpointLimits, error) {
 	overflow := false
 
 	// These two helper functions add/multiply together a bunch of numbers and set overflow to true if the result
@@ -45,19 +46,48 @@ func limits(cfg config.PublicConfig, reportingPluginLimits types.ReportingPlugin
 	maxLenObserveReq := add(reportingPluginLimits.MaxQueryLength, overhead)
 	maxLenObserve := add(reportingPluginLimits.MaxObservationLength, overhead)
 	maxLenReportReq := add(mul(add(reportingPluginLimits.MaxObservationLength, ed25519.SignatureSize), cfg.N()), overhead)
-	maxLenReport := add(reportingPluginLimits.MaxReportLength, ed25519.SignatureSize, overhead)
-	maxLenFinal := add(reportingPluginLimits.MaxReportLength, mul(maxSigLen, cfg.N()), overhead)
+	reportsPerRound := 1
+	if multiReports {
+		reportsPerRound = types.MaxReportsPerRound
+	}
+	maxLenReport := add(mul(add(reportingPluginLimits.MaxReportLength, ed25519.SignatureSize), reportsPerRound), overhead)
+	maxLenFinal := add(mul(add(reportingPluginLimits.MaxReportLength, mul(maxSigLen, cfg.N())), reportsPerRound), overhead)
+	// With outcome states, reports carry a signed outcome state and finals
+	// carry an outcome state along with a quorum of signatures on it.
+	if outcomeStates {
+		maxLenReport = add(maxLenReport, types.MaxOutcomeStateLength, ed25519.SignatureSize)
+		maxLenFinal = add(maxLenFinal, types.MaxOutcomeStateLength, mul(ed25519.SignatureSize, cfg.N()))
+	}
 	maxLenFinalEcho := maxLenFinal
 
-	maxMessageSize := max(maxLenObserveReq, maxLenObserve, maxLenReportReq, maxLenReport, maxLenFinal, maxLenFinalEcho)
+	// With commit-reveal, an observation is replaced by a commitment and a
+	// reveal, and the leader additionally sends a reveal request, which it
+	// may extend by late commitments up to protocol.MaxRevealReqsPerRound
+	// times in total.
+	maxLenObserveCommit := 0
+	maxLenRevealReq := 0
+	maxLenObserveReveal := 0
+	commitRevealMessagesPerRound := 0
+	if cfg.CommitRevealObservations {
+		const commitmentSize = 32
+		const nonceSize = 32
+		maxLenObserveCommit = add(commitmentSize, ed25519.SignatureSize, overhead)
+		maxLenRevealReq = add(mul(add(commitmentSize, ed25519.SignatureSize), cfg.N()), overhead)
+		maxLenObserveReveal = add(reportingPluginLimits.MaxObservationLength, ed25519.SignatureSize, nonceSize, overhead)
+		maxLenReportReq = add(maxLenReportReq, mul(add(nonceSize, commitmentSize, ed25519.SignatureSize), cfg.N()))
+		commitRevealMessagesPerRound = add(2, protocol.MaxRevealReqsPerRound(cfg.N(), cfg.F))
+	}
+
+	maxMessageSize := max(maxLenObserveReq, maxLenObserve, maxLenReportReq, maxLenReport, maxLenFinal, maxLenFinalEcho, maxLenObserveCommit, maxLenRevealReq, maxLenObserveReveal)
 
 	messagesRate := (1.0*float64(time.Second)/float64(cfg.DeltaResend) +
 		1.0*float64(time.Second)/float64(cfg.DeltaProgress) +
 		1.0*float64(time.Second)/float64(cfg.DeltaRound) +
 		3.0*float64(time.Second)/float64(cfg.DeltaRound) +
-		2.0*float64(time.Second)/float64(cfg.DeltaRound)) * 2.0
+		2.0*float64(time.Second)/float64(cfg.DeltaRound) +
+		float64(commitRevealMessagesPerRound)*float64(time.Second)/float64(cfg.DeltaRound)) * 2.0
 
-	messagesCapacity := mul(add(2, 6), 2)
+	messagesCapacity := mul(add(2, 6, commitRevealMessagesPerRound), 2)
 
 	bytesRate := float64(time.Second)/float64(cfg.DeltaResend)*float64(maxLenNewEpoch) +
 		float64(time.Second)/float64(cfg.DeltaProgress)*float64(maxLenNewEpoch) +
@@ -66,9 +96,12 @@ func limits(cfg config.PublicConfig, reportingPluginLimits types.ReportingPlugin
 		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenReportReq) +
 		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenReport) +
 		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenFinal) +
-		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenFinalEcho)
+		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenFinalEcho) +
+		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenObserveCommit) +
+		float64(protocol.MaxRevealReqsPerRound(cfg.N(), cfg.F))*float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenRevealReq) +
+		float64(time.Second)/float64(cfg.DeltaRound)*float64(maxLenObserveReveal)
 
-	bytesCapacity := mul(add(maxLenNewEpoch, maxLenObserveReq, maxLenObserve, maxLenReportReq, maxLenReport, maxLenFinal, maxLenFinalEcho), 2)
+	bytesCapacity := mul(add(maxLenNewEpoch, maxLenObserveReq, maxLenObserve, maxLenReportReq, maxLenReport, maxLenFinal, maxLenFinalEcho, maxLenObserveCommit, mul(maxLenRevealReq, protocol.MaxRevealReqsPerRound(cfg.N(), cfg.F)), maxLenObserveReveal), 2)
 
 	if overflow {
 		// this should not happen due to us checking the limits in types.go
@@ -18,7 +18,7 @@ func RunManagedBootstrapper(
 	v2bootstrappers []commontypes.BootstrapperLocator,
 	contractConfigTracker types.ContractConfigTracker,
 	database types.ConfigDatabase,
-	localConfig types.LocalConfig,
+	localConfig *config.LocalConfigHolder,
 	logger loghelper.LoggerWithContext,
 	offchainConfigDigester types.OffchainConfigDigester,
 ) {
@@ -27,7 +27,7 @@ func RunManagedBootstrapper(
 
 		contractConfigTracker,
 		database,
-		func(ctx context.Context, contractConfig types.ContractConfig, logger loghelper.LoggerWithContext) {
+		func(ctx context.Context, contractConfig types.ContractConfig, logger loghelper.LoggerWithContext, handover *handover) {
 			config, err := config.PublicConfigFromContractConfig(true, contractConfig)
 			if err != nil {
 				logger.Error("ManagedBootstrapper: error while decoding ContractConfig", commontypes.LogFields{
@@ -63,6 +63,10 @@ func RunManagedBootstrapper(
 				"ManagedBootstrapper: error during bootstrapper.Close()",
 			)
 
+			close(handover.chStarted)
+			// bootstrappers don't transmit, there's nothing to drain
+			close(handover.chDrained)
+
 			<-ctx.Done()
 		},
 		localConfig,
@@ -10,6 +10,7 @@ import (
 	"github.com/smartcontractkit/libocr/offchainreporting2/internal/protocol"
 	"github.com/smartcontractkit/libocr/offchainreporting2/internal/serialization"
 	"github.com/smartcontractkit/libocr/offchainreporting2/internal/shim"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/middleware"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 	"github.com/smartcontractkit/libocr/subprocesses"
 	"go.uber.org/multierr"
@@ -19,14 +20,20 @@ import (
 // setting up telemetry, garbage collection, configuration updates, translating
 // from commontypes.BinaryNetworkEndpoint to protocol.NetworkEndpoint, and
 // creation/teardown of reporting plugins.
+//
+// Reports are transmitted through contractTransmitter and additionally to all
+// additionalTransmissionTargets.
 func RunManagedOracle(
 	ctx context.Context,
 
+	chTransmissionAdmin <-chan protocol.TransmissionAdminRequest,
 	v2bootstrappers []commontypes.BootstrapperLocator,
 	configTracker types.ContractConfigTracker,
 	contractTransmitter types.ContractTransmitter,
+	additionalTransmissionTargets []types.TransmissionTarget,
 	database types.Database,
-	localConfig types.LocalConfig,
+	dryRunRecorder types.TransmissionRecorder,
+	localConfig *config.LocalConfigHolder,
 	logger loghelper.LoggerWithContext,
 	monitoringEndpoint commontypes.MonitoringEndpoint,
 	netEndpointFactory types.BinaryNetworkEndpointFactory,
@@ -34,6 +41,7 @@ func RunManagedOracle(
 	offchainKeyring types.OffchainKeyring,
 	onchainKeyring types.OnchainKeyring,
 	reportingPluginFactory types.ReportingPluginFactory,
+	transmissionScheduler types.TransmissionScheduler,
 ) {
 	subs := subprocesses.Subprocesses{}
 	defer subs.Wait()
@@ -47,6 +55,33 @@ func RunManagedOracle(
 		})
 	}
 
+	// During config handovers, protocol instances share the ContractTransmitters
+	// of all targets. Each TransmissionResultReporter is consumed only once
+	// here and its results are routed to the instance they belong to.
+	sharedTargets := []sharedTarget{}
+	for _, target := range append(
+		[]types.TransmissionTarget{{"", contractTransmitter, nil}},
+		additionalTransmissionTargets...,
+	) {
+		var results *transmissionResultsDemux
+		if reporter, ok := target.ContractTransmitter.(types.TransmissionResultReporter); ok {
+			results = newTransmissionResultsDemux()
+			chResults := reporter.TransmissionResults()
+			name := target.Name
+			subs.Go(func() {
+				results.run(ctx, chResults, name, logger)
+			})
+		}
+		sharedTargets = append(sharedTargets, sharedTarget{target, results})
+	}
+
+	// During config handovers, several protocol instances run at once. Route
+	// each TransmissionAdminRequest to one of them.
+	transmissionAdmin := newTransmissionAdminRouter()
+	subs.Go(func() {
+		transmissionAdmin.run(ctx, chTransmissionAdmin, logger)
+	})
+
 	subs.Go(func() {
 		collectGarbage(ctx, database, localConfig, logger)
 	})
@@ -56,15 +91,20 @@ func RunManagedOracle(
 
 		configTracker,
 		database,
-		func(ctx context.Context, contractConfig types.ContractConfig, logger loghelper.LoggerWithContext) {
-			skipResourceExhaustionChecks := localConfig.DevelopmentMode == types.EnableDangerousDevelopmentMode
+		func(ctx context.Context, contractConfig types.ContractConfig, logger loghelper.LoggerWithContext, handover *handover) {
+			fnSubs := subprocesses.Subprocesses{}
+			defer fnSubs.Wait()
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			skipResourceExhaustionChecks := localConfig.Get().DevelopmentMode == types.EnableDangerousDevelopmentMode
 			sharedConfig, oid, err := config.SharedConfigFromContractConfig(
 				skipResourceExhaustionChecks,
 				contractConfig,
 				offchainKeyring,
 				onchainKeyring,
 				netEndpointFactory.PeerID(),
-				contractTransmitter.FromAccount(),
+				transmitAccounts(contractTransmitter),
 			)
 			if err != nil {
 				logger.Error("ManagedOracle: error while updating config", commontypes.LogFields{
@@ -73,6 +113,49 @@ func RunManagedOracle(
 				return
 			}
 
+			// Instances for earlier configs keep transmitting from their
+			// accounts until they have stopped. Only then may we switch the
+			// account and transmit ourselves.
+			chTransmitReady := make(chan struct{})
+			activateAccount := func() bool {
+				multiAccountTransmitter, ok := contractTransmitter.(types.MultiAccountContractTransmitter)
+				if !ok {
+					return true
+				}
+				activeAccount := sharedConfig.OracleIdentities[oid].TransmitAccount
+				if err := multiAccountTransmitter.SetActiveAccount(activeAccount); err != nil {
+					logger.Error("ManagedOracle: error during SetActiveAccount()", commontypes.LogFields{
+						"error":         err,
+						"activeAccount": activeAccount,
+					})
+					return false
+				}
+				logger.Info("ManagedOracle: set active transmit account", commontypes.LogFields{
+					"activeAccount": activeAccount,
+				})
+				return true
+			}
+			select {
+			case <-handover.chPredecessorsDone:
+				if !activateAccount() {
+					return
+				}
+				close(chTransmitReady)
+			default:
+				fnSubs.Go(func() {
+					select {
+					case <-handover.chPredecessorsDone:
+					case <-ctx.Done():
+						return
+					}
+					if !activateAccount() {
+						cancel()
+						return
+					}
+					close(chTransmitReady)
+				})
+			}
+
 			// Run with new config
 			peerIDs := []string{}
 			for _, identity := range sharedConfig.OracleIdentities {
@@ -116,13 +199,16 @@ func RunManagedOracle(
 				return
 			}
 
-			lims, err := limits(sharedConfig.PublicConfig, reportingPluginInfo.Limits, onchainKeyring.MaxSignatureLength())
+			_, outcomeStates := reportingPlugin.(types.OutcomeStateReportingPlugin)
+			_, multiReports := reportingPlugin.(types.MultiReportReportingPlugin)
+			lims, err := limits(sharedConfig.PublicConfig, reportingPluginInfo.Limits, onchainKeyring.MaxSignatureLength(), outcomeStates, multiReports)
 			if err != nil {
 				logger.Error("ManagedOracle: error during limits", commontypes.LogFields{
 					"error":               err,
 					"publicConfig":        sharedConfig.PublicConfig,
 					"reportingPluginInfo": reportingPluginInfo,
 					"maxSigLen":           onchainKeyring.MaxSignatureLength(),
+					"outcomeStates":       outcomeStates,
 				})
 				return
 			}
@@ -183,20 +269,49 @@ func RunManagedOracle(
 				reportQuorum = sharedConfig.F + 1
 			}
 
+			limitCheckedPlugin := middleware.NewLimitCheckReportingPlugin(reportingPlugin, reportingPluginInfo.Limits)
+
+			transmissionTargets := []types.TransmissionTarget{}
+			for _, sharedTarget := range sharedTargets {
+				var results <-chan types.TransmissionResult
+				if sharedTarget.results != nil {
+					var unsubscribe func()
+					results, unsubscribe = sharedTarget.results.subscribe(sharedConfig.ConfigDigest)
+					defer unsubscribe()
+				}
+				transmissionTargets = append(transmissionTargets, sharedTarget.instanceTarget(
+					chTransmitReady,
+					limitCheckedPlugin,
+					handover,
+					results,
+					childLogger,
+				))
+			}
+
+			chInstanceTransmissionAdmin, unsubscribe := transmissionAdmin.subscribe(sharedConfig.ConfigDigest)
+			defer unsubscribe()
+
 			protocol.RunOracle(
 				ctx,
+				handover.chStarted,
+				handover.chRetire,
+				handover.chDrained,
+				chInstanceTransmissionAdmin,
 				sharedConfig,
 				contractTransmitter,
 				database,
+				dryRunRecorder,
 				oid,
 				localConfig,
 				childLogger,
 				netEndpoint,
 				offchainKeyring,
 				onchainKeyring,
-				shim.LimitCheckReportingPlugin{reportingPlugin, reportingPluginInfo.Limits},
+				limitCheckedPlugin,
 				reportQuorum,
 				shim.MakeTelemetrySender(chTelemetrySend, childLogger),
+				transmissionScheduler,
+				transmissionTargets,
 			)
 		},
 		localConfig,
@@ -205,6 +320,15 @@ func RunManagedOracle(
 	)
 }
 
+// transmitAccounts returns all accounts the oracle may be configured to
+// transmit from
+func transmitAccounts(contractTransmitter types.ContractTransmitter) []types.Account {
+	if multiAccountTransmitter, ok := contractTransmitter.(types.MultiAccountContractTransmitter); ok {
+		return multiAccountTransmitter.FromAccounts()
+	}
+	return []types.Account{contractTransmitter.FromAccount()}
+}
+
 func validateReportingPluginLimits(limits types.ReportingPluginLimits) error {
 	var err error
 	if !(0 <= limits.MaxQueryLength && limits.MaxQueryLength <= types.MaxMaxQueryLength) {
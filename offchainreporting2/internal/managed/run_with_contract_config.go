@@ -2,26 +2,39 @@ package managed
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/config"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 	"github.com/smartcontractkit/libocr/subprocesses"
 )
 
 // runWithContractConfig runs fn with a contractConfig and manages its lifecycle
 // as contractConfigs change according to contractConfigTracker. It also saves
-// and restores contract configs using database.
+// and restores contract configs using database. If database implements
+// types.ConfigHistoryDatabase, it also records the history of enacted configs.
+//
+// fn should close handover.chStarted once it is fully up and running. If
+// localConfig.ConfigHandoverWindow is non-zero, fn for the previous
+// contractConfig learns about its successor through its handover and keeps
+// running until the successor has started and fn has closed
+// handover.chDrained, or until the window has passed.
 func runWithContractConfig(
 	ctx context.Context,
 
 	contractConfigTracker types.ContractConfigTracker,
 	database types.ConfigDatabase,
-	fn func(context.Context, types.ContractConfig, loghelper.LoggerWithContext),
-	localConfig types.LocalConfig,
+	fn func(ctx context.Context, contractConfig types.ContractConfig, logger loghelper.LoggerWithContext, handover *handover),
+	localConfig *config.LocalConfigHolder,
 	logger loghelper.LoggerWithContext,
 	offchainConfigDigester types.OffchainConfigDigester,
 ) {
+	chNoPredecessors := make(chan struct{})
+	close(chNoPredecessors)
+
 	rwcc := runWithContractConfigState{
 		ctx,
 
@@ -33,6 +46,11 @@ func runWithContractConfig(
 		logger,
 
 		prefixCheckConfigDigester{offchainConfigDigester},
+		newConfigHistory(database, logger),
+		func() {},
+		false,
+		nil,
+		chNoPredecessors,
 		func() {},
 		subprocesses.Subprocesses{},
 		subprocesses.Subprocesses{},
@@ -46,12 +64,19 @@ type runWithContractConfigState struct {
 	configDigest          types.ConfigDigest
 	contractConfigTracker types.ContractConfigTracker
 	database              types.ConfigDatabase
-	fn                    func(context.Context, types.ContractConfig, loghelper.LoggerWithContext)
-	localConfig           types.LocalConfig
+	fn                    func(context.Context, types.ContractConfig, loghelper.LoggerWithContext, *handover)
+	localConfig           *config.LocalConfigHolder
 	logger                loghelper.LoggerWithContext
 
 	configDigester prefixCheckConfigDigester
+	configHistory  *configHistory
 	fnCancel       context.CancelFunc
+	fnRunning      bool
+	fnHandover     *handover
+	// closed once fn has returned for the current and all earlier configs
+	fnDone <-chan struct{}
+	// cancels the fn for the previous config during a handover
+	retiringCancel context.CancelFunc
 	fnSubs         subprocesses.Subprocesses
 	otherSubs      subprocesses.Subprocesses
 }
@@ -62,7 +87,7 @@ func (rwcc *runWithContractConfigState) run() {
 	rwcc.restoreFromDatabase()
 
 	// Only start tracking config after we attempted to load config from db
-	chNewConfig := make(chan types.ContractConfig, 5)
+	chNewConfig := make(chan ConfigChange, 5)
 	rwcc.otherSubs.Go(func() {
 		TrackConfig(rwcc.ctx, rwcc.configDigester, rwcc.contractConfigTracker, rwcc.configDigest, rwcc.localConfig, rwcc.logger, chNewConfig)
 	})
@@ -72,9 +97,9 @@ func (rwcc *runWithContractConfigState) run() {
 		case change := <-chNewConfig:
 			rwcc.logger.Info("runWithContractConfig: switching between configs", commontypes.LogFields{
 				"oldConfigDigest": rwcc.configDigest.Hex(),
-				"newConfigDigest": change.ConfigDigest.Hex(),
+				"newConfigDigest": change.ContractConfig.ConfigDigest.Hex(),
 			})
-			rwcc.configChanged(change)
+			rwcc.configChanged(change.ContractConfig, change.ChangedInBlock)
 		case <-rwcc.ctx.Done():
 			rwcc.logger.Info("runWithContractConfig: winding down", nil)
 			rwcc.fnSubs.Wait()
@@ -89,14 +114,14 @@ func (rwcc *runWithContractConfigState) restoreFromDatabase() {
 	var contractConfig *types.ContractConfig
 	ok := rwcc.otherSubs.BlockForAtMost(
 		rwcc.ctx,
-		rwcc.localConfig.DatabaseTimeout,
+		rwcc.localConfig.Get().DatabaseTimeout,
 		func(ctx context.Context) {
 			contractConfig = loadConfigFromDatabase(ctx, rwcc.database, rwcc.logger)
 		},
 	)
 	if !ok {
 		rwcc.logger.Error("runWithContractConfig: database timed out while attempting to restore configuration", commontypes.LogFields{
-			"timeout": rwcc.localConfig.DatabaseTimeout,
+			"timeout": rwcc.localConfig.Get().DatabaseTimeout,
 		})
 		return
 	}
@@ -106,21 +131,45 @@ func (rwcc *runWithContractConfigState) restoreFromDatabase() {
 		return
 	}
 
-	rwcc.configChanged(*contractConfig)
+	// the block number isn't stored in the database
+	rwcc.configChanged(*contractConfig, 0)
 }
 
-func (rwcc *runWithContractConfigState) configChanged(contractConfig types.ContractConfig) {
-	// Cease any operation from earlier configs
-	rwcc.logger.Info("runWithContractConfig: winding down old configuration", commontypes.LogFields{
-		"oldConfigDigest": rwcc.configDigest,
-		"newConfigDigest": contractConfig.ConfigDigest,
-	})
-	rwcc.fnCancel()
-	rwcc.fnSubs.Wait()
-	rwcc.logger.Info("runWithContractConfig: closed old configuration", commontypes.LogFields{
-		"oldConfigDigest": rwcc.configDigest,
-		"newConfigDigest": contractConfig.ConfigDigest,
-	})
+func (rwcc *runWithContractConfigState) configChanged(contractConfig types.ContractConfig, changedInBlock uint64) {
+	// A handover from an even earlier config is still in progress. Since we
+	// only hand over from the latest config, cease its operation.
+	rwcc.retiringCancel()
+	rwcc.retiringCancel = func() {}
+
+	chStarted := make(chan struct{})
+	chPredecessorsDone := rwcc.fnDone
+	if rwcc.fnRunning && rwcc.localConfig.Get().ConfigHandoverWindow > 0 {
+		// Keep the old config running until the new one has started. We
+		// start the new config below.
+		rwcc.logger.Info("runWithContractConfig: handing over from old configuration", commontypes.LogFields{
+			"oldConfigDigest":      rwcc.configDigest,
+			"newConfigDigest":      contractConfig.ConfigDigest,
+			"configHandoverWindow": rwcc.localConfig.Get().ConfigHandoverWindow,
+		})
+		rwcc.retiringCancel = rwcc.fnCancel
+		rwcc.fnHandover.setSuccessor(contractConfig.ConfigDigest)
+		rwcc.handOver(rwcc.fnCancel, rwcc.fnHandover, rwcc.configDigest, contractConfig.ConfigDigest, chStarted)
+	} else {
+		// Cease any operation from earlier configs
+		rwcc.logger.Info("runWithContractConfig: winding down old configuration", commontypes.LogFields{
+			"oldConfigDigest": rwcc.configDigest,
+			"newConfigDigest": contractConfig.ConfigDigest,
+		})
+		rwcc.fnCancel()
+		rwcc.fnSubs.Wait()
+		rwcc.logger.Info("runWithContractConfig: closed old configuration", commontypes.LogFields{
+			"oldConfigDigest": rwcc.configDigest,
+			"newConfigDigest": contractConfig.ConfigDigest,
+		})
+	}
+	rwcc.fnCancel = func() {}
+	rwcc.fnRunning = false
+	rwcc.fnHandover = nil
 
 	// note that there is an analogous check in TrackConfig, so this should never trigger.
 	if err := rwcc.configDigester.CheckContractConfig(contractConfig); err != nil {
@@ -134,17 +183,27 @@ func (rwcc *runWithContractConfigState) configChanged(contractConfig types.Contr
 	rwcc.configDigest = contractConfig.ConfigDigest
 
 	fnCtx, fnCancel := context.WithCancel(rwcc.ctx)
+	fnHandover := &handover{chStarted, make(chan struct{}), make(chan struct{}), chPredecessorsDone, sync.Mutex{}, nil}
+	fnDone := make(chan struct{})
 	rwcc.fnCancel = fnCancel
+	rwcc.fnRunning = true
+	rwcc.fnHandover = fnHandover
+	rwcc.fnDone = fnDone
 	rwcc.fnSubs.Go(func() {
+		defer func() {
+			<-chPredecessorsDone
+			close(fnDone)
+		}()
 		defer fnCancel()
 		rwcc.fn(
 			fnCtx,
 			contractConfig,
 			rwcc.logger.MakeChild(commontypes.LogFields{"configDigest": contractConfig.ConfigDigest}),
+			fnHandover,
 		)
 	})
 
-	writeCtx, writeCancel := context.WithTimeout(rwcc.ctx, rwcc.localConfig.DatabaseTimeout)
+	writeCtx, writeCancel := context.WithTimeout(rwcc.ctx, rwcc.localConfig.Get().DatabaseTimeout)
 	defer writeCancel()
 	if err := rwcc.database.WriteConfig(writeCtx, contractConfig); err != nil {
 		rwcc.logger.ErrorIfNotCanceled("runWithContractConfig: error writing new config to database", writeCtx, commontypes.LogFields{
@@ -154,4 +213,46 @@ func (rwcc *runWithContractConfigState) configChanged(contractConfig types.Contr
 		})
 	}
 
+	historyCtx, historyCancel := context.WithTimeout(rwcc.ctx, rwcc.localConfig.Get().DatabaseTimeout)
+	defer historyCancel()
+	rwcc.configHistory.record(historyCtx, contractConfig, changedInBlock)
+}
+
+// handOver cancels the fn for oldConfigDigest once the fn for newConfigDigest
+// has closed chStarted and the old fn has drained its pending transmissions,
+// or once the handover window has passed.
+func (rwcc *runWithContractConfigState) handOver(
+	oldCancel context.CancelFunc,
+	oldHandover *handover,
+	oldConfigDigest types.ConfigDigest,
+	newConfigDigest types.ConfigDigest,
+	chStarted <-chan struct{},
+) {
+	rwcc.otherSubs.Go(func() {
+		defer oldCancel()
+
+		logFields := commontypes.LogFields{
+			"oldConfigDigest": oldConfigDigest,
+			"newConfigDigest": newConfigDigest,
+		}
+		tWindow := time.After(rwcc.localConfig.Get().ConfigHandoverWindow)
+		select {
+		case <-chStarted:
+			rwcc.logger.Info("runWithContractConfig: new configuration has started, draining old configuration", logFields)
+		case <-tWindow:
+			rwcc.logger.Info("runWithContractConfig: handover window has passed, closing old configuration", logFields)
+			return
+		case <-rwcc.ctx.Done():
+			return
+		}
+
+		close(oldHandover.chRetire)
+		select {
+		case <-oldHandover.chDrained:
+			rwcc.logger.Info("runWithContractConfig: old configuration has drained its pending transmissions, closing it", logFields)
+		case <-tWindow:
+			rwcc.logger.Info("runWithContractConfig: handover window has passed, closing old configuration", logFields)
+		case <-rwcc.ctx.Done():
+		}
+	})
 }
@@ -2,23 +2,33 @@ package managed
 
 import (
 	"context"
+	"math/rand"
 	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/config"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 	"github.com/smartcontractkit/libocr/subprocesses"
 )
 
+// ConfigChange is a new config detected by TrackConfig
+type ConfigChange struct {
+	ContractConfig types.ContractConfig
+	// ChangedInBlock is the block in which the config was set, as reported by
+	// ContractConfigTracker.LatestConfigDetails
+	ChangedInBlock uint64
+}
+
 type trackConfigState struct {
 	ctx context.Context
 	// in
 	configDigester prefixCheckConfigDigester
 	configTracker  types.ContractConfigTracker
-	localConfig    types.LocalConfig
+	localConfig    *config.LocalConfigHolder
 	logger         loghelper.LoggerWithContext
 	// out
-	chChanges chan<- types.ContractConfig
+	chChanges chan<- ConfigChange
 	// local
 	subprocesses subprocesses.Subprocesses
 	configDigest types.ConfigDigest
@@ -48,21 +58,27 @@ func (state *trackConfigState) run() {
 			// poll more rapidly if we're awaiting confirmation
 			if awaitingConfirmation {
 				wait := 15 * time.Second
-				if state.localConfig.ContractConfigTrackerPollInterval < wait {
-					wait = state.localConfig.ContractConfigTrackerPollInterval
+				if state.localConfig.Get().ContractConfigTrackerPollInterval < wait {
+					wait = state.localConfig.Get().ContractConfigTrackerPollInterval
 				}
 				tCheckLatestConfigDetails = time.After(wait)
 				state.logger.Info("TrackConfig: awaiting confirmation of new config", commontypes.LogFields{
 					"wait": wait,
 				})
 			} else {
-				tCheckLatestConfigDetails = time.After(state.localConfig.ContractConfigTrackerPollInterval)
+				localConfig := state.localConfig.Get()
+				wait := localConfig.ContractConfigTrackerPollInterval
+				if localConfig.ContractConfigTrackerPollJitter > 0 {
+					wait += time.Duration(rand.Int63n(int64(localConfig.ContractConfigTrackerPollJitter)))
+				}
+				tCheckLatestConfigDetails = time.After(wait)
 			}
 
 			if change != nil {
-				state.configDigest = change.ConfigDigest
+				state.configDigest = change.ContractConfig.ConfigDigest
 				state.logger.Info("TrackConfig: returning config", commontypes.LogFields{
-					"configDigest": change.ConfigDigest.Hex(),
+					"configDigest":   change.ContractConfig.ConfigDigest.Hex(),
+					"changedInBlock": change.ChangedInBlock,
 				})
 				select {
 				case state.chChanges <- *change:
@@ -85,10 +101,10 @@ func (state *trackConfigState) run() {
 }
 
 func (state *trackConfigState) checkLatestConfigDetails() (
-	latestConfigDetails *types.ContractConfig,
+	latestConfigDetails *ConfigChange,
 	awaitingConfirmation bool,
 ) {
-	bhCtx, bhCancel := context.WithTimeout(state.ctx, state.localConfig.BlockchainTimeout)
+	bhCtx, bhCancel := context.WithTimeout(state.ctx, state.localConfig.Get().BlockchainTimeout)
 	defer bhCancel()
 	blockheight, err := state.configTracker.LatestBlockHeight(bhCtx)
 	if err != nil {
@@ -98,7 +114,7 @@ func (state *trackConfigState) checkLatestConfigDetails() (
 		return nil, false
 	}
 
-	detailsCtx, detailsCancel := context.WithTimeout(state.ctx, state.localConfig.BlockchainTimeout)
+	detailsCtx, detailsCancel := context.WithTimeout(state.ctx, state.localConfig.Get().BlockchainTimeout)
 	defer detailsCancel()
 	changedInBlock, latestConfigDigest, err := state.configTracker.LatestConfigDetails(detailsCtx)
 	if err != nil {
@@ -116,10 +132,10 @@ func (state *trackConfigState) checkLatestConfigDetails() (
 	if state.configDigest == latestConfigDigest {
 		return nil, false
 	}
-	if !state.localConfig.SkipContractConfigConfirmations && blockheight < changedInBlock+uint64(state.localConfig.ContractConfigConfirmations)-1 {
+	if !state.localConfig.Get().SkipContractConfigConfirmations && blockheight < changedInBlock+uint64(state.localConfig.Get().ContractConfigConfirmations)-1 {
 		return nil, true
 	}
-	configCtx, configCancel := context.WithTimeout(state.ctx, state.localConfig.BlockchainTimeout)
+	configCtx, configCancel := context.WithTimeout(state.ctx, state.localConfig.Get().BlockchainTimeout)
 	defer configCancel()
 	contractConfig, err := state.configTracker.LatestConfig(configCtx, changedInBlock)
 	if err != nil {
@@ -148,7 +164,7 @@ func (state *trackConfigState) checkLatestConfigDetails() (
 		return nil, false
 	}
 
-	return &contractConfig, false
+	return &ConfigChange{contractConfig, changedInBlock}, false
 }
 
 func TrackConfig(
@@ -157,10 +173,10 @@ func TrackConfig(
 	configDigester prefixCheckConfigDigester,
 	configTracker types.ContractConfigTracker,
 	initialConfigDigest types.ConfigDigest,
-	localConfig types.LocalConfig,
+	localConfig *config.LocalConfigHolder,
 	logger loghelper.LoggerWithContext,
 
-	chChanges chan<- types.ContractConfig,
+	chChanges chan<- ConfigChange,
 ) {
 	state := trackConfigState{
 		ctx,
@@ -41,6 +41,24 @@ func (rep AttestedReportOne) EqualExceptSignature(rep2 AttestedReportOne) bool {
 	return rep.Skip == rep2.Skip && bytes.Equal(rep.Report, rep2.Report)
 }
 
+// isSkip is true if aros is the AttestedReportOne list of a follower that
+// decided not to generate any report in a round.
+func isSkip(aros []AttestedReportOne) bool {
+	return len(aros) == 1 && aros[0].Skip
+}
+
+func attestedReportsEqualExceptSignature(aros []AttestedReportOne, aros2 []AttestedReportOne) bool {
+	if len(aros) != len(aros2) {
+		return false
+	}
+	for i := range aros {
+		if !aros[i].EqualExceptSignature(aros2[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // Verify is used by the leader to check the signature a process attaches to its
 // report message (the c.Sig value.)
 func (aro *AttestedReportOne) Verify(contractSigner types.OnchainKeyring, publicKey types.OnchainPublicKey, repctx types.ReportContext) (err error) {
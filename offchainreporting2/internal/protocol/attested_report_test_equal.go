@@ -28,3 +28,27 @@ func (rep AttestedReportMany) TestEqual(c2 AttestedReportMany) bool {
 
 	return true
 }
+
+func attestedReportsOneTestEqual(aros []AttestedReportOne, aros2 []AttestedReportOne) bool {
+	if len(aros) != len(aros2) {
+		return false
+	}
+	for i := range aros {
+		if !aros[i].TestEqual(aros2[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func attestedReportsManyTestEqual(arms []AttestedReportMany, arms2 []AttestedReportMany) bool {
+	if len(arms) != len(arms2) {
+		return false
+	}
+	for i := range arms {
+		if !arms[i].TestEqual(arms2[i]) {
+			return false
+		}
+	}
+	return true
+}
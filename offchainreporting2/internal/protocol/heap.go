@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"container/heap"
+	"sort"
 
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 )
@@ -27,8 +28,29 @@ func (h *MinHeapTimeToPendingTransmission) Len() int {
 	return h.internal.Len()
 }
 
+// Items returns a copy of all items, ordered by time
+func (h *MinHeapTimeToPendingTransmission) Items() []MinHeapTimeToPendingTransmissionItem {
+	items := append([]MinHeapTimeToPendingTransmissionItem{}, h.internal...)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Time.Before(items[j].Time)
+	})
+	return items
+}
+
+// Remove removes the item with the given key. It returns false if there is no
+// such item.
+func (h *MinHeapTimeToPendingTransmission) Remove(key types.PendingTransmissionKey) bool {
+	for i, item := range h.internal {
+		if item.PendingTransmissionKey == key {
+			heap.Remove(&h.internal, i)
+			return true
+		}
+	}
+	return false
+}
+
 type MinHeapTimeToPendingTransmissionItem struct {
-	types.ReportTimestamp
+	types.PendingTransmissionKey
 	types.PendingTransmission
 }
 
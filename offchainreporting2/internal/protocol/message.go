@@ -1,6 +1,8 @@
 package protocol //
 
 import (
+	"crypto/ed25519"
+
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 )
@@ -184,6 +186,99 @@ func (msg MessageObserve) epoch() uint32 {
 	return msg.Epoch
 }
 
+// MessageObserveCommit replaces MessageObserve if
+// PublicConfig.CommitRevealObservations is set. Participating oracles send this
+// back to the leader in response to MessageObserveReq's. It only contains a
+// commitment to the observation.
+type MessageObserveCommit struct {
+	Epoch            uint32
+	Round            uint8
+	SignedCommitment SignedObservationCommitment
+}
+
+var _ MessageToReportGeneration = (*MessageObserveCommit)(nil)
+
+func (msg MessageObserveCommit) CheckSize(types.ReportingPluginLimits) bool {
+	return len(msg.SignedCommitment.Signature) == ed25519.SignatureSize
+}
+
+func (msg MessageObserveCommit) process(o *oracleState, sender commontypes.OracleID) {
+	o.reportGenerationMessage(msg, sender)
+}
+
+func (msg MessageObserveCommit) processReportGeneration(repgen *reportGenerationState, sender commontypes.OracleID) {
+	repgen.messageObserveCommit(msg, sender)
+}
+
+func (msg MessageObserveCommit) epoch() uint32 {
+	return msg.Epoch
+}
+
+// MessageRevealReq is sent by the leader once it has collected more than 2f
+// observation commitments. It fixes the set of commitments for the round and
+// asks participating oracles to reveal their observations. If not enough
+// oracles reveal, the leader sends another MessageRevealReq that extends the
+// set by commitments that arrived late.
+type MessageRevealReq struct {
+	Epoch                       uint32
+	Round                       uint8
+	AttributedSignedCommitments []AttributedSignedObservationCommitment
+}
+
+var _ MessageToReportGeneration = (*MessageRevealReq)(nil)
+
+func (msg MessageRevealReq) CheckSize(types.ReportingPluginLimits) bool {
+	if !(len(msg.AttributedSignedCommitments) <= types.MaxOracles) {
+		return false
+	}
+	for _, asoc := range msg.AttributedSignedCommitments {
+		if len(asoc.SignedCommitment.Signature) != ed25519.SignatureSize {
+			return false
+		}
+	}
+	return true
+}
+
+func (msg MessageRevealReq) process(o *oracleState, sender commontypes.OracleID) {
+	o.reportGenerationMessage(msg, sender)
+}
+
+func (msg MessageRevealReq) processReportGeneration(repgen *reportGenerationState, sender commontypes.OracleID) {
+	repgen.messageRevealReq(msg, sender)
+}
+
+func (msg MessageRevealReq) epoch() uint32 {
+	return msg.Epoch
+}
+
+// MessageObserveReveal is sent by participating oracles to the leader in
+// response to a MessageRevealReq. It contains the observation along with the
+// nonce that opens the oracle's commitment.
+type MessageObserveReveal struct {
+	Epoch             uint32
+	Round             uint8
+	SignedObservation SignedObservation
+	Nonce             ObservationNonce
+}
+
+var _ MessageToReportGeneration = (*MessageObserveReveal)(nil)
+
+func (msg MessageObserveReveal) CheckSize(reportingPluginLimits types.ReportingPluginLimits) bool {
+	return len(msg.SignedObservation.Observation) <= reportingPluginLimits.MaxObservationLength
+}
+
+func (msg MessageObserveReveal) process(o *oracleState, sender commontypes.OracleID) {
+	o.reportGenerationMessage(msg, sender)
+}
+
+func (msg MessageObserveReveal) processReportGeneration(repgen *reportGenerationState, sender commontypes.OracleID) {
+	repgen.messageObserveReveal(msg, sender)
+}
+
+func (msg MessageObserveReveal) epoch() uint32 {
+	return msg.Epoch
+}
+
 // MessageReportReq corresponds to the "report-req" message from alg. 2. It is
 // sent by the epoch leader with collated observations for the participating
 // oracles to sign.
@@ -192,6 +287,14 @@ type MessageReportReq struct {
 	Round                        uint8
 	Query                        types.Query
 	AttributedSignedObservations []AttributedSignedObservation
+	// Only used if PublicConfig.CommitRevealObservations is set, empty
+	// otherwise. ObservationNonces[i] opens the commitment of the oracle that
+	// made AttributedSignedObservations[i].
+	ObservationNonces []ObservationNonce
+	// Only used if PublicConfig.CommitRevealObservations is set, empty
+	// otherwise. The commitments fixed by the leader's final
+	// MessageRevealReq, which the observations must open.
+	AttributedSignedCommitments []AttributedSignedObservationCommitment
 }
 
 func (msg MessageReportReq) CheckSize(reportingPluginLimits types.ReportingPluginLimits) bool {
@@ -203,12 +306,26 @@ func (msg MessageReportReq) CheckSize(reportingPluginLimits types.ReportingPlugi
 		return false
 	}
 
+	if !(len(msg.ObservationNonces) <= types.MaxOracles) {
+		return false
+	}
+
+	if !(len(msg.AttributedSignedCommitments) <= types.MaxOracles) {
+		return false
+	}
+
 	for _, aso := range msg.AttributedSignedObservations {
 		if !(len(aso.SignedObservation.Observation) <= reportingPluginLimits.MaxObservationLength) {
 			return false
 		}
 	}
 
+	for _, asoc := range msg.AttributedSignedCommitments {
+		if len(asoc.SignedCommitment.Signature) != ed25519.SignatureSize {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -231,15 +348,28 @@ var _ MessageToReportGeneration = (*MessageReportReq)(nil)
 // final form of the report, based on the collated observations, and the sending
 // oracle's signature.
 type MessageReport struct {
-	Epoch          uint32
-	Round          uint8
-	AttestedReport AttestedReportOne
+	Epoch uint32
+	Round uint8
+	// Either a single skip, or one AttestedReportOne per report generated in
+	// the round. Only MultiReportReportingPlugins generate more than one
+	// report.
+	AttestedReports []AttestedReportOne
+	// Empty unless the ReportingPlugin uses outcome states
+	SignedOutcomeState SignedOutcomeState
 }
 
 var _ MessageToReportGeneration = (*MessageReport)(nil)
 
 func (msg MessageReport) CheckSize(reportingPluginLimits types.ReportingPluginLimits) bool {
-	return len(msg.AttestedReport.Report) <= reportingPluginLimits.MaxReportLength
+	if len(msg.AttestedReports) > types.MaxReportsPerRound {
+		return false
+	}
+	for _, ar := range msg.AttestedReports {
+		if len(ar.Report) > reportingPluginLimits.MaxReportLength {
+			return false
+		}
+	}
+	return len(msg.SignedOutcomeState.OutcomeState) <= types.MaxOutcomeStateLength
 }
 
 func (msg MessageReport) process(o *oracleState, sender commontypes.OracleID) {
@@ -259,17 +389,31 @@ func (msg MessageReport) epoch() uint32 {
 // for them to participate in the subsequent transmission of the report to the
 // on-chain contract.
 type MessageFinal struct {
-	Epoch          uint32
-	Round          uint8
-	H              [32]byte
-	AttestedReport AttestedReportMany
+	Epoch uint32
+	Round uint8
+	H     [32]byte
+	// One AttestedReportMany per report generated in the round, see
+	// MessageReport. Empty if no report was generated, which is only possible
+	// if the ReportingPlugin uses outcome states.
+	AttestedReports []AttestedReportMany
+	// Empty unless the ReportingPlugin uses outcome states
+	CertifiedOutcomeState CertifiedOutcomeState
 }
 
 var _ MessageToReportGeneration = (*MessageFinal)(nil)
 
 func (msg MessageFinal) CheckSize(reportingPluginLimits types.ReportingPluginLimits) bool {
-	return len(msg.AttestedReport.AttributedSignatures) <= types.MaxOracles &&
-		len(msg.AttestedReport.Report) <= reportingPluginLimits.MaxReportLength
+	if len(msg.AttestedReports) > types.MaxReportsPerRound {
+		return false
+	}
+	for _, ar := range msg.AttestedReports {
+		if len(ar.AttributedSignatures) > types.MaxOracles ||
+			len(ar.Report) > reportingPluginLimits.MaxReportLength {
+			return false
+		}
+	}
+	return len(msg.CertifiedOutcomeState.AttributedSignatures) <= types.MaxOracles &&
+		len(msg.CertifiedOutcomeState.OutcomeState) <= types.MaxOutcomeStateLength
 }
 
 func (msg MessageFinal) process(o *oracleState, sender commontypes.OracleID) {
@@ -322,6 +466,7 @@ type EventTransmit struct {
 	Epoch          uint32
 	Round          uint8
 	H              [32]byte
+	ReportIndex    uint8
 	AttestedReport AttestedReportMany
 }
 
@@ -17,6 +17,35 @@ func (msg MessageObserve) TestEqual(msg2 MessageObserve) bool {
 		msg.SignedObservation.Equal(msg2.SignedObservation)
 }
 
+func (msg MessageObserveCommit) TestEqual(msg2 MessageObserveCommit) bool {
+	return msg.Epoch == msg2.Epoch &&
+		msg.Round == msg2.Round &&
+		msg.SignedCommitment.Equal(msg2.SignedCommitment)
+}
+
+func (msg MessageRevealReq) TestEqual(msg2 MessageRevealReq) bool {
+	if !(msg.Epoch == msg2.Epoch &&
+		msg.Round == msg2.Round) {
+		return false
+	}
+	if len(msg.AttributedSignedCommitments) != len(msg2.AttributedSignedCommitments) {
+		return false
+	}
+	for i := range msg.AttributedSignedCommitments {
+		if !msg.AttributedSignedCommitments[i].Equal(msg2.AttributedSignedCommitments[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (msg MessageObserveReveal) TestEqual(msg2 MessageObserveReveal) bool {
+	return msg.Epoch == msg2.Epoch &&
+		msg.Round == msg2.Round &&
+		msg.SignedObservation.Equal(msg2.SignedObservation) &&
+		msg.Nonce == msg2.Nonce
+}
+
 func (msg MessageReportReq) TestEqual(msg2 MessageReportReq) bool {
 	if !(msg.Epoch == msg2.Epoch &&
 		msg.Round == msg2.Round &&
@@ -31,15 +60,33 @@ func (msg MessageReportReq) TestEqual(msg2 MessageReportReq) bool {
 			return false
 		}
 	}
+	if len(msg.ObservationNonces) != len(msg2.ObservationNonces) {
+		return false
+	}
+	for i := range msg.ObservationNonces {
+		if msg.ObservationNonces[i] != msg2.ObservationNonces[i] {
+			return false
+		}
+	}
+	if len(msg.AttributedSignedCommitments) != len(msg2.AttributedSignedCommitments) {
+		return false
+	}
+	for i := range msg.AttributedSignedCommitments {
+		if !msg.AttributedSignedCommitments[i].Equal(msg2.AttributedSignedCommitments[i]) {
+			return false
+		}
+	}
 	return true
 }
 
 func (msg MessageReport) TestEqual(m2 MessageReport) bool {
-	return msg.Epoch == m2.Epoch && msg.Round == m2.Round && msg.AttestedReport.TestEqual(m2.AttestedReport)
+	return msg.Epoch == m2.Epoch && msg.Round == m2.Round && attestedReportsOneTestEqual(msg.AttestedReports, m2.AttestedReports) &&
+		msg.SignedOutcomeState.Equal(m2.SignedOutcomeState)
 }
 
 func (msg MessageFinal) TestEqual(m2 MessageFinal) bool {
-	return msg.Epoch == m2.Epoch && msg.Round == m2.Round && msg.AttestedReport.TestEqual(m2.AttestedReport)
+	return msg.Epoch == m2.Epoch && msg.Round == m2.Round && attestedReportsManyTestEqual(msg.AttestedReports, m2.AttestedReports) &&
+		msg.CertifiedOutcomeState.Equal(m2.CertifiedOutcomeState)
 }
 
 func (msg MessageFinalEcho) TestEqual(m2 MessageFinalEcho) bool {
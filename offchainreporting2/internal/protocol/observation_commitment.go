@@ -0,0 +1,145 @@
+package protocol
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// Used with PublicConfig.CommitRevealObservations. Followers first send a
+// signed commitment to their observation to the leader. Once the leader has
+// fixed a set of more than 2f commitments (MessageRevealReq), followers reveal
+// their observation along with the nonce that was used to compute the
+// commitment (MessageObserveReveal). The leader and all followers check that
+// each revealed observation matches its commitment. If some committed oracles
+// don't reveal, the leader extends the set by late commitments, so that
+// byzantine oracles cannot stall the round by withholding their reveals.
+// Reveals are only sent to the leader, so late committers don't learn the
+// observations of others, unless they collude with the leader.
+//
+// This ensures that an oracle cannot base its observation on the observations
+// of other oracles, unless it colludes with the leader. The signed commitments
+// serve as evidence that each oracle committed to its observation before any
+// observation was revealed.
+
+const observationNonceSize = 32
+
+type ObservationNonce [observationNonceSize]byte
+
+func MakeObservationNonce() (ObservationNonce, error) {
+	var nonce ObservationNonce
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return ObservationNonce{}, err
+	}
+	return nonce, nil
+}
+
+type ObservationCommitment [32]byte
+
+// MakeObservationCommitment computes a hiding and binding commitment to the
+// observation. The nonce must be chosen uniformly at random and only be
+// revealed together with the observation.
+func MakeObservationCommitment(
+	repts types.ReportTimestamp,
+	query types.Query,
+	observation types.Observation,
+	nonce ObservationNonce,
+) ObservationCommitment {
+	h := sha256.New()
+	_, _ = h.Write([]byte("ocr2 observation commitment"))
+	_, _ = h.Write(repts.ConfigDigest[:])
+	_ = binary.Write(h, binary.BigEndian, repts.Epoch)
+	_, _ = h.Write([]byte{repts.Round})
+
+	_ = binary.Write(h, binary.BigEndian, uint64(len(query)))
+	_, _ = h.Write(query)
+
+	_ = binary.Write(h, binary.BigEndian, uint64(len(observation)))
+	_, _ = h.Write(observation)
+
+	_, _ = h.Write(nonce[:])
+
+	var commitment ObservationCommitment
+	copy(commitment[:], h.Sum(nil))
+	return commitment
+}
+
+type SignedObservationCommitment struct {
+	Commitment ObservationCommitment
+	Signature  []byte
+}
+
+func MakeSignedObservationCommitment(
+	repts types.ReportTimestamp,
+	commitment ObservationCommitment,
+	signer func(msg []byte) (sig []byte, err error),
+) (
+	SignedObservationCommitment,
+	error,
+) {
+	sig, err := signer(signedObservationCommitmentWireMessage(repts, commitment))
+	if err != nil {
+		return SignedObservationCommitment{}, err
+	}
+	return SignedObservationCommitment{commitment, sig}, nil
+}
+
+func (soc SignedObservationCommitment) Equal(soc2 SignedObservationCommitment) bool {
+	return soc.Commitment == soc2.Commitment &&
+		bytes.Equal(soc.Signature, soc2.Signature)
+}
+
+func (soc SignedObservationCommitment) Verify(repts types.ReportTimestamp, publicKey types.OffchainPublicKey) error {
+	pk := ed25519.PublicKey(publicKey[:])
+	// should never trigger since types.OffchainPublicKey is an array with length ed25519.PublicKeySize
+	if len(pk) != ed25519.PublicKeySize {
+		return fmt.Errorf("ed25519 public key size mismatch, expected %v but got %v", ed25519.PublicKeySize, len(pk))
+	}
+
+	ok := ed25519.Verify(pk, signedObservationCommitmentWireMessage(repts, soc.Commitment), soc.Signature)
+	if !ok {
+		return fmt.Errorf("SignedObservationCommitment has invalid signature")
+	}
+
+	return nil
+}
+
+// VerifyReveal checks that so and nonce open the commitment. It doesn't check
+// the signature on so.
+func (soc SignedObservationCommitment) VerifyReveal(
+	repts types.ReportTimestamp,
+	query types.Query,
+	so SignedObservation,
+	nonce ObservationNonce,
+) error {
+	if MakeObservationCommitment(repts, query, so.Observation, nonce) != soc.Commitment {
+		return fmt.Errorf("revealed observation doesn't match commitment")
+	}
+	return nil
+}
+
+func signedObservationCommitmentWireMessage(repts types.ReportTimestamp, commitment ObservationCommitment) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte("ocr2 signed observation commitment"))
+	_, _ = h.Write(repts.ConfigDigest[:])
+	_ = binary.Write(h, binary.BigEndian, repts.Epoch)
+	_, _ = h.Write([]byte{repts.Round})
+	_, _ = h.Write(commitment[:])
+	return h.Sum(nil)
+}
+
+type AttributedSignedObservationCommitment struct {
+	SignedCommitment SignedObservationCommitment
+	Observer         commontypes.OracleID
+}
+
+func (asoc AttributedSignedObservationCommitment) Equal(asoc2 AttributedSignedObservationCommitment) bool {
+	return asoc.SignedCommitment.Equal(asoc2.SignedCommitment) &&
+		asoc.Observer == asoc2.Observer
+}
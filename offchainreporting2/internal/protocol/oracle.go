@@ -17,14 +17,32 @@ const futureMessageBufferSize = 10 // big enough for a couple of full rounds of
 //
 // RunOracle runs forever until ctx is cancelled. It will only shut down
 // after all its sub-goroutines have exited.
+//
+// chStarted is closed once the oracle has reached its first epoch, i.e. once
+// the pacemaker has moved past the epoch it started out with. This implies
+// that networking works and that enough oracles run this instance.
+//
+// chRetire is closed once the instance's successor has started. The instance
+// then stops accepting new reports for transmission and closes chDrained once
+// it has no pending transmissions left.
+//
+// contractTransmitter is used for reading from the contract.
+// transmissionTargets contains all targets that reports are transmitted to,
+// starting with the primary target, whose ContractTransmitter is usually
+// contractTransmitter.
 func RunOracle(
 	ctx context.Context,
 
+	chStarted chan<- struct{},
+	chRetire <-chan struct{},
+	chDrained chan<- struct{},
+	chTransmissionAdmin <-chan TransmissionAdminRequest,
 	config config.SharedConfig,
 	contractTransmitter types.ContractTransmitter,
 	database types.Database,
+	dryRunRecorder types.TransmissionRecorder,
 	id commontypes.OracleID,
-	localConfig types.LocalConfig,
+	localConfig *config.LocalConfigHolder,
 	logger loghelper.LoggerWithContext,
 	netEndpoint NetworkEndpoint,
 	offchainKeyring types.OffchainKeyring,
@@ -32,22 +50,31 @@ func RunOracle(
 	reportingPlugin types.ReportingPlugin,
 	reportQuorum int,
 	telemetrySender TelemetrySender,
+	transmissionScheduler types.TransmissionScheduler,
+	transmissionTargets []types.TransmissionTarget,
 ) {
 	o := oracleState{
 		ctx: ctx,
 
-		config:              config,
-		contractTransmitter: contractTransmitter,
-		database:            database,
-		id:                  id,
-		localConfig:         localConfig,
-		logger:              logger,
-		netEndpoint:         netEndpoint,
-		offchainKeyring:     offchainKeyring,
-		onchainKeyring:      onchainKeyring,
-		reportingPlugin:     reportingPlugin,
-		reportQuorum:        reportQuorum,
-		telemetrySender:     telemetrySender,
+		chStarted:             chStarted,
+		chRetire:              chRetire,
+		chDrained:             chDrained,
+		chTransmissionAdmin:   chTransmissionAdmin,
+		config:                config,
+		contractTransmitter:   contractTransmitter,
+		database:              database,
+		dryRunRecorder:        dryRunRecorder,
+		id:                    id,
+		localConfig:           localConfig,
+		logger:                logger,
+		netEndpoint:           netEndpoint,
+		offchainKeyring:       offchainKeyring,
+		onchainKeyring:        onchainKeyring,
+		reportingPlugin:       reportingPlugin,
+		reportQuorum:          reportQuorum,
+		telemetrySender:       telemetrySender,
+		transmissionScheduler: transmissionScheduler,
+		transmissionTargets:   transmissionTargets,
 	}
 	o.run()
 }
@@ -55,18 +82,25 @@ func RunOracle(
 type oracleState struct {
 	ctx context.Context
 
-	config              config.SharedConfig
-	contractTransmitter types.ContractTransmitter
-	database            types.Database
-	id                  commontypes.OracleID
-	localConfig         types.LocalConfig
-	logger              loghelper.LoggerWithContext
-	netEndpoint         NetworkEndpoint
-	offchainKeyring     types.OffchainKeyring
-	onchainKeyring      types.OnchainKeyring
-	reportingPlugin     types.ReportingPlugin
-	reportQuorum        int
-	telemetrySender     TelemetrySender
+	chStarted             chan<- struct{}
+	chRetire              <-chan struct{}
+	chDrained             chan<- struct{}
+	chTransmissionAdmin   <-chan TransmissionAdminRequest
+	config                config.SharedConfig
+	contractTransmitter   types.ContractTransmitter
+	database              types.Database
+	dryRunRecorder        types.TransmissionRecorder
+	id                    commontypes.OracleID
+	localConfig           *config.LocalConfigHolder
+	logger                loghelper.LoggerWithContext
+	netEndpoint           NetworkEndpoint
+	offchainKeyring       types.OffchainKeyring
+	onchainKeyring        types.OnchainKeyring
+	reportingPlugin       types.ReportingPlugin
+	reportQuorum          int
+	telemetrySender       TelemetrySender
+	transmissionScheduler types.TransmissionScheduler
+	transmissionTargets   []types.TransmissionTarget
 
 	bufferedMessages          []*MessageBuffer
 	chNetToPacemaker          chan<- MessageToPacemakerWithSender
@@ -75,6 +109,8 @@ type oracleState struct {
 	childCancel               context.CancelFunc
 	childCtx                  context.Context
 	epoch                     uint32
+	initialEpoch              uint32
+	started                   bool
 	subprocesses              subprocesses.Subprocesses
 }
 
@@ -144,6 +180,19 @@ func (o *oracleState) run() {
 	o.childCtx, o.childCancel = context.WithCancel(context.Background())
 	defer o.childCancel()
 
+	var outcomeStateStore *outcomeStateStore
+	if reportingPlugin, ok := o.reportingPlugin.(types.OutcomeStateReportingPlugin); ok {
+		o.logger.Info("Oracle: ReportingPlugin uses outcome states", nil)
+		outcomeStateStore = newOutcomeStateStore(
+			o.config.ConfigDigest,
+			o.database,
+			o.localConfig,
+			o.logger,
+			reportingPlugin,
+		)
+		outcomeStateStore.restore(o.childCtx)
+	}
+
 	o.subprocesses.Go(func() {
 		RunPacemaker(
 			o.childCtx,
@@ -162,6 +211,7 @@ func (o *oracleState) run() {
 			o.netEndpoint,
 			o.offchainKeyring,
 			o.onchainKeyring,
+			outcomeStateStore,
 			o.reportingPlugin,
 			o.reportQuorum,
 			o.telemetrySender,
@@ -178,6 +228,7 @@ func (o *oracleState) run() {
 			o.onchainKeyring,
 			o.logger,
 			o.netEndpoint,
+			outcomeStateStore,
 			o.reportQuorum,
 		)
 	})
@@ -188,12 +239,17 @@ func (o *oracleState) run() {
 
 			o.config,
 			chReportFinalizationToTransmission,
+			o.chRetire,
+			o.chDrained,
+			o.chTransmissionAdmin,
 			o.database,
+			o.dryRunRecorder,
 			o.id,
 			o.localConfig,
 			o.logger,
 			o.reportingPlugin,
-			o.contractTransmitter,
+			o.transmissionScheduler,
+			o.transmissionTargets,
 		)
 	})
 
@@ -233,6 +289,19 @@ func (o *oracleState) run() {
 }
 
 func (o *oracleState) epochChanged(e uint32) {
+	if !o.started {
+		// the pacemaker notifies us of the epoch it starts out with first
+		if o.initialEpoch == 0 {
+			o.initialEpoch = e
+		} else if o.initialEpoch < e {
+			o.logger.Info("Oracle: reached first epoch", commontypes.LogFields{
+				"epoch": e,
+			})
+			o.started = true
+			close(o.chStarted)
+		}
+	}
+
 	o.epoch = e
 	o.logger.Trace("epochChanged: getting messages for new epoch", commontypes.LogFields{
 		"epoch": e,
@@ -0,0 +1,279 @@
+package protocol
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/config"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// Used with types.OutcomeStateReportingPlugin. Followers sign the OutcomeState
+// they derived for a round and send it to the leader along with their report
+// (MessageReport). The leader waits for more than (n+f)/2 matching signatures
+// and includes them in MessageFinal as a CertifiedOutcomeState. Since every
+// honest oracle signs at most one OutcomeState per (epoch, round), any two
+// such quorums intersect in an honest oracle and at most one OutcomeState can
+// be certified per (epoch, round).
+//
+// The certificate is checked by followers (messageFinal) and by report
+// finalization (messageFinalEcho) before the OutcomeState is committed to the
+// outcomeStateStore that both of them share.
+
+// outcomeStateQuorum returns the number of signatures in a
+// CertifiedOutcomeState
+func outcomeStateQuorum(n int, f int) int {
+	return (n+f)/2 + 1
+}
+
+type SignedOutcomeState struct {
+	OutcomeState types.OutcomeState
+	Signature    []byte
+}
+
+func MakeSignedOutcomeState(
+	repts types.ReportTimestamp,
+	outcomeState types.OutcomeState,
+	signer func(msg []byte) (sig []byte, err error),
+) (
+	SignedOutcomeState,
+	error,
+) {
+	sig, err := signer(outcomeStateWireMessage(repts, outcomeState))
+	if err != nil {
+		return SignedOutcomeState{}, err
+	}
+	return SignedOutcomeState{outcomeState, sig}, nil
+}
+
+func (sos SignedOutcomeState) Equal(sos2 SignedOutcomeState) bool {
+	return bytes.Equal(sos.OutcomeState, sos2.OutcomeState) &&
+		bytes.Equal(sos.Signature, sos2.Signature)
+}
+
+// IsEmpty is true for the SignedOutcomeState sent by oracles that don't use
+// outcome states.
+func (sos SignedOutcomeState) IsEmpty() bool {
+	return len(sos.OutcomeState) == 0 && len(sos.Signature) == 0
+}
+
+func (sos SignedOutcomeState) Verify(repts types.ReportTimestamp, publicKey types.OffchainPublicKey) error {
+	if !verifyOutcomeStateSignature(repts, sos.OutcomeState, publicKey, sos.Signature) {
+		return fmt.Errorf("SignedOutcomeState has invalid signature")
+	}
+	return nil
+}
+
+type AttributedOutcomeSignature struct {
+	Signature []byte
+	Signer    commontypes.OracleID
+}
+
+func (aos AttributedOutcomeSignature) Equal(aos2 AttributedOutcomeSignature) bool {
+	return bytes.Equal(aos.Signature, aos2.Signature) &&
+		aos.Signer == aos2.Signer
+}
+
+type CertifiedOutcomeState struct {
+	OutcomeState         types.OutcomeState
+	AttributedSignatures []AttributedOutcomeSignature
+}
+
+func (cos CertifiedOutcomeState) Equal(cos2 CertifiedOutcomeState) bool {
+	if !bytes.Equal(cos.OutcomeState, cos2.OutcomeState) {
+		return false
+	}
+	if len(cos.AttributedSignatures) != len(cos2.AttributedSignatures) {
+		return false
+	}
+	for i := range cos.AttributedSignatures {
+		if !cos.AttributedSignatures[i].Equal(cos2.AttributedSignatures[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsEmpty is true for the CertifiedOutcomeState sent by oracles that don't
+// use outcome states.
+func (cos CertifiedOutcomeState) IsEmpty() bool {
+	return len(cos.OutcomeState) == 0 && len(cos.AttributedSignatures) == 0
+}
+
+func (cos CertifiedOutcomeState) Verify(
+	numSignatures int,
+	oracleIdentities []config.OracleIdentity,
+	repts types.ReportTimestamp,
+) error {
+	if numSignatures != len(cos.AttributedSignatures) {
+		return fmt.Errorf("wrong number of outcome state signatures, expected %v and got %v", numSignatures, len(cos.AttributedSignatures))
+	}
+	seen := make(map[commontypes.OracleID]bool)
+	for i, sig := range cos.AttributedSignatures {
+		if seen[sig.Signer] {
+			return fmt.Errorf("duplicate outcome state signature by %v", sig.Signer)
+		}
+		seen[sig.Signer] = true
+		if !(0 <= int(sig.Signer) && int(sig.Signer) < len(oracleIdentities)) {
+			return fmt.Errorf("signer out of bounds: %v", sig.Signer)
+		}
+		if !verifyOutcomeStateSignature(repts, cos.OutcomeState, oracleIdentities[sig.Signer].OffchainPublicKey, sig.Signature) {
+			return fmt.Errorf("%v-th outcome state signature by %v-th oracle does not verify", i, sig.Signer)
+		}
+	}
+	return nil
+}
+
+func verifyOutcomeStateSignature(
+	repts types.ReportTimestamp,
+	outcomeState types.OutcomeState,
+	publicKey types.OffchainPublicKey,
+	sig []byte,
+) bool {
+	pk := ed25519.PublicKey(publicKey[:])
+	// should never trigger since types.OffchainPublicKey is an array with length ed25519.PublicKeySize
+	if len(pk) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(pk, outcomeStateWireMessage(repts, outcomeState), sig)
+}
+
+func outcomeStateWireMessage(repts types.ReportTimestamp, outcomeState types.OutcomeState) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte("ocr2 outcome state"))
+	_, _ = h.Write(repts.ConfigDigest[:])
+	_ = binary.Write(h, binary.BigEndian, repts.Epoch)
+	_, _ = h.Write([]byte{repts.Round})
+	_ = binary.Write(h, binary.BigEndian, uint64(len(outcomeState)))
+	_, _ = h.Write(outcomeState)
+	return h.Sum(nil)
+}
+
+// outcomeStateStore holds the latest committed OutcomeState. It is shared
+// between report generation and report finalization, both of which commit
+// certified OutcomeStates to it. A nil *outcomeStateStore means that the
+// ReportingPlugin doesn't use outcome states.
+type outcomeStateStore struct {
+	configDigest    types.ConfigDigest
+	database        types.OutcomeStateDatabase // may be nil
+	localConfig     *config.LocalConfigHolder
+	logger          loghelper.LoggerWithContext
+	reportingPlugin types.OutcomeStateReportingPlugin
+
+	mutex        sync.Mutex
+	epochRound   EpochRound
+	outcomeState types.OutcomeState
+}
+
+func newOutcomeStateStore(
+	configDigest types.ConfigDigest,
+	database types.Database,
+	localConfig *config.LocalConfigHolder,
+	logger loghelper.LoggerWithContext,
+	reportingPlugin types.OutcomeStateReportingPlugin,
+) *outcomeStateStore {
+	outcomeStateDatabase, ok := database.(types.OutcomeStateDatabase)
+	if !ok {
+		logger.Warn("OutcomeStateStore: Database doesn't implement OutcomeStateDatabase, outcome state won't survive restarts", nil)
+	}
+	return &outcomeStateStore{
+		configDigest,
+		outcomeStateDatabase,
+		localConfig,
+		logger,
+		reportingPlugin,
+
+		sync.Mutex{},
+		EpochRound{},
+		nil,
+	}
+}
+
+// restore loads the latest committed OutcomeState from the database and
+// passes it to the ReportingPlugin.
+func (store *outcomeStateStore) restore(ctx context.Context) {
+	if store.database == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, store.localConfig.Get().DatabaseTimeout)
+	defer cancel()
+	state, err := store.database.ReadOutcomeState(ctx, store.configDigest)
+	if err != nil {
+		store.logger.ErrorIfNotCanceled("OutcomeStateStore: error while restoring outcome state from database", ctx, commontypes.LogFields{
+			"error": err,
+		})
+		return
+	}
+	if state == nil {
+		return
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.epochRound = EpochRound{state.Epoch, state.Round}
+	store.outcomeState = state.OutcomeState
+	store.logger.Info("OutcomeStateStore: restored outcome state from database", commontypes.LogFields{
+		"epoch": state.Epoch,
+		"round": state.Round,
+	})
+	store.reportingPlugin.OutcomeStateCommitted(
+		types.ReportTimestamp{store.configDigest, state.Epoch, state.Round},
+		state.OutcomeState,
+	)
+}
+
+// latest returns the latest committed OutcomeState
+func (store *outcomeStateStore) latest() types.OutcomeState {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return store.outcomeState
+}
+
+// commit makes outcomeState the latest committed OutcomeState, unless an
+// OutcomeState from the same or a later (epoch, round) has already been
+// committed. The caller must have verified that outcomeState is certified.
+func (store *outcomeStateStore) commit(ctx context.Context, epochRound EpochRound, outcomeState types.OutcomeState) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if !store.epochRound.Less(epochRound) {
+		return
+	}
+	store.epochRound = epochRound
+	store.outcomeState = outcomeState
+
+	store.logger.Debug("OutcomeStateStore: committed outcome state", commontypes.LogFields{
+		"epoch": epochRound.Epoch,
+		"round": epochRound.Round,
+	})
+
+	if store.database != nil {
+		ctx, cancel := context.WithTimeout(ctx, store.localConfig.Get().DatabaseTimeout)
+		defer cancel()
+		err := store.database.WriteOutcomeState(ctx, store.configDigest, types.PersistentOutcomeState{
+			epochRound.Epoch,
+			epochRound.Round,
+			outcomeState,
+		})
+		if err != nil {
+			store.logger.ErrorIfNotCanceled("OutcomeStateStore: error while persisting outcome state", ctx, commontypes.LogFields{
+				"epoch": epochRound.Epoch,
+				"round": epochRound.Round,
+				"error": err,
+			})
+		}
+	}
+
+	store.reportingPlugin.OutcomeStateCommitted(
+		types.ReportTimestamp{store.configDigest, epochRound.Epoch, epochRound.Round},
+		outcomeState,
+	)
+}
@@ -0,0 +1,92 @@
+package protocol
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+func TestOutcomeStateQuorum(t *testing.T) {
+	for _, tc := range []struct{ n, f, quorum int }{
+		{4, 1, 3},
+		{5, 1, 4},
+		{7, 2, 5},
+		{10, 3, 7},
+		{31, 10, 21},
+	} {
+		if quorum := outcomeStateQuorum(tc.n, tc.f); quorum != tc.quorum {
+			t.Errorf("outcomeStateQuorum(%v, %v) = %v, expected %v", tc.n, tc.f, quorum, tc.quorum)
+		}
+	}
+
+	for n := 1; n <= types.MaxOracles; n++ {
+		for f := 0; 3*f < n; f++ {
+			quorum := outcomeStateQuorum(n, f)
+			// any two quorums share more than f oracles, so at least one
+			// honest oracle that signs at most one OutcomeState per round
+			if !(2*quorum-n > f) {
+				t.Errorf("n=%v, f=%v: quorums of %v may not intersect in an honest oracle", n, f, quorum)
+			}
+			// the honest oracles alone can form a quorum
+			if !(quorum <= n-f) {
+				t.Errorf("n=%v, f=%v: quorum of %v needs faulty oracles", n, f, quorum)
+			}
+		}
+	}
+}
+
+func TestCertifiedOutcomeStateVerify(t *testing.T) {
+	const n, f = 4, 1
+	keys, identities := makeTestOracleIdentities(t, n)
+	repts := types.ReportTimestamp{types.ConfigDigest{1}, 2, 3}
+	outcomeState := types.OutcomeState("outcome state")
+	quorum := outcomeStateQuorum(n, f)
+
+	certify := func(signers ...int) CertifiedOutcomeState {
+		cos := CertifiedOutcomeState{outcomeState, nil}
+		for _, signer := range signers {
+			sos, err := MakeSignedOutcomeState(repts, outcomeState, func(msg []byte) ([]byte, error) {
+				return ed25519.Sign(keys[signer], msg), nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			cos.AttributedSignatures = append(cos.AttributedSignatures, AttributedOutcomeSignature{sos.Signature, commontypes.OracleID(signer)})
+		}
+		return cos
+	}
+
+	if err := certify(0, 2, 3).Verify(quorum, identities, repts); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		cos    CertifiedOutcomeState
+		repts  types.ReportTimestamp
+		modify func(cos *CertifiedOutcomeState)
+	}{
+		{"too few signatures", certify(0, 1), repts, nil},
+		{"too many signatures", certify(0, 1, 2, 3), repts, nil},
+		{"wrong round", certify(0, 1, 2), types.ReportTimestamp{repts.ConfigDigest, repts.Epoch, repts.Round + 1}, nil},
+		{"duplicate signer", certify(0, 1, 1), repts, nil},
+		{"signer out of bounds", certify(0, 1, 2), repts, func(cos *CertifiedOutcomeState) {
+			cos.AttributedSignatures[2].Signer = n
+		}},
+		{"wrong signer", certify(0, 1, 2), repts, func(cos *CertifiedOutcomeState) {
+			cos.AttributedSignatures[2].Signer = 3
+		}},
+		{"different outcome state", certify(0, 1, 2), repts, func(cos *CertifiedOutcomeState) {
+			cos.OutcomeState = types.OutcomeState("other outcome state")
+		}},
+	} {
+		if tc.modify != nil {
+			tc.modify(&tc.cos)
+		}
+		if err := tc.cos.Verify(quorum, identities, tc.repts); err == nil {
+			t.Errorf("%v: expected error", tc.name)
+		}
+	}
+}
@@ -35,11 +35,12 @@ func RunPacemaker(
 	contractTransmitter types.ContractTransmitter,
 	database types.Database,
 	id commontypes.OracleID,
-	localConfig types.LocalConfig,
+	localConfig *config.LocalConfigHolder,
 	logger loghelper.LoggerWithContext,
 	netSender NetworkSender,
 	offchainKeyring types.OffchainKeyring,
 	onchainKeyring types.OnchainKeyring,
+	outcomeStateStore *outcomeStateStore,
 	reportingPlugin types.ReportingPlugin,
 	reportQuorum int,
 	telemetrySender TelemetrySender,
@@ -47,8 +48,8 @@ func RunPacemaker(
 	pace := makePacemakerState(
 		ctx, subprocesses, chNetToPacemaker, chNetToReportGeneration, chPacemakerToOracle,
 		chReportGenerationToReportFinalization, config, contractTransmitter, database,
-		id, localConfig, logger, netSender, offchainKeyring, onchainKeyring, reportingPlugin,
-		reportQuorum, telemetrySender,
+		id, localConfig, logger, netSender, offchainKeyring, onchainKeyring, outcomeStateStore,
+		reportingPlugin, reportQuorum, telemetrySender,
 	)
 	pace.run()
 }
@@ -62,10 +63,11 @@ func makePacemakerState(
 	chReportGenerationToReportFinalization chan<- EventToReportFinalization,
 	config config.SharedConfig, contractTransmitter types.ContractTransmitter,
 	database types.Database, id commontypes.OracleID,
-	localConfig types.LocalConfig, logger loghelper.LoggerWithContext,
+	localConfig *config.LocalConfigHolder, logger loghelper.LoggerWithContext,
 	netSender NetworkSender,
 	offchainKeyring types.OffchainKeyring,
 	onchainKeyring types.OnchainKeyring,
+	outcomeStateStore *outcomeStateStore,
 	reportingPlugin types.ReportingPlugin,
 	reportQuorum int,
 	telemetrySender TelemetrySender,
@@ -87,6 +89,7 @@ func makePacemakerState(
 		netSender:                              netSender,
 		offchainKeyring:                        offchainKeyring,
 		onchainKeyring:                         onchainKeyring,
+		outcomeStateStore:                      outcomeStateStore,
 		reportingPlugin:                        reportingPlugin,
 		reportQuorum:                           reportQuorum,
 		telemetrySender:                        telemetrySender,
@@ -108,11 +111,12 @@ type pacemakerState struct {
 	contractTransmitter                    types.ContractTransmitter
 	database                               types.Database
 	id                                     commontypes.OracleID
-	localConfig                            types.LocalConfig
+	localConfig                            *config.LocalConfigHolder
 	logger                                 loghelper.LoggerWithContext
 	netSender                              NetworkSender
 	offchainKeyring                        types.OffchainKeyring
 	onchainKeyring                         types.OnchainKeyring
+	outcomeStateStore                      *outcomeStateStore
 	reportingPlugin                        types.ReportingPlugin
 	reportQuorum                           int
 	telemetrySender                        TelemetrySender
@@ -166,7 +170,7 @@ func (pace *pacemakerState) run() {
 				chPersist,
 				pace.config.ConfigDigest,
 				pace.database,
-				pace.localConfig.DatabaseTimeout,
+				pace.localConfig,
 				pace.logger,
 			)
 		})
@@ -240,7 +244,7 @@ func (pace *pacemakerState) restoreStateFromDatabase() {
 	var err error
 	ok := pace.subprocesses.BlockForAtMost(
 		pace.ctx,
-		pace.localConfig.DatabaseTimeout,
+		pace.localConfig.Get().DatabaseTimeout,
 		func(ctx context.Context) {
 			state, err = pace.database.ReadState(ctx, pace.config.ConfigDigest)
 		},
@@ -248,7 +252,7 @@ func (pace *pacemakerState) restoreStateFromDatabase() {
 
 	if !ok {
 		pace.logger.Error("Pacemaker: Timeout while restoring state from database", commontypes.LogFields{
-			"timeout": pace.localConfig.DatabaseTimeout,
+			"timeout": pace.localConfig.Get().DatabaseTimeout,
 		})
 		return
 	}
@@ -296,7 +300,7 @@ func (pace *pacemakerState) restoreNeFromTransmitter() {
 	var err error
 	ok := pace.subprocesses.BlockForAtMost(
 		pace.ctx,
-		pace.localConfig.BlockchainTimeout,
+		pace.localConfig.Get().BlockchainTimeout,
 		func(ctx context.Context) {
 			configDigest, epoch, err = pace.contractTransmitter.LatestConfigDigestAndEpoch(ctx)
 		},
@@ -304,7 +308,7 @@ func (pace *pacemakerState) restoreNeFromTransmitter() {
 
 	if !ok {
 		pace.logger.Error("Pacemaker: latestConfigDigestAndEpoch timed out while restoring ne", commontypes.LogFields{
-			"timeout": pace.localConfig.BlockchainTimeout,
+			"timeout": pace.localConfig.Get().BlockchainTimeout,
 		})
 		return
 	}
@@ -485,6 +489,7 @@ func (pace *pacemakerState) spawnReportGeneration() {
 			netSender,
 			offchainKeyring,
 			onchainKeyring,
+			outcomeStateStore,
 			reportingPlugin,
 			reportQuorum,
 			telemetrySender := pace.subprocesses,
@@ -500,6 +505,7 @@ func (pace *pacemakerState) spawnReportGeneration() {
 			pace.netSender,
 			pace.offchainKeyring,
 			pace.onchainKeyring,
+			pace.outcomeStateStore,
 			pace.reportingPlugin,
 			pace.reportQuorum,
 			pace.telemetrySender
@@ -523,6 +529,7 @@ func (pace *pacemakerState) spawnReportGeneration() {
 				netSender,
 				offchainKeyring,
 				onchainKeyring,
+				outcomeStateStore,
 				reportingPlugin,
 				reportQuorum,
 				telemetrySender,
@@ -2,21 +2,21 @@ package persist
 
 import (
 	"context"
-	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/config"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 )
 
 type persistPacemakerState struct {
 	ctx context.Context
 
-	chPersist       <-chan types.PersistentState
-	configDigest    types.ConfigDigest
-	database        types.Database
-	databaseTimeout time.Duration
-	logger          loghelper.LoggerWithContext
+	chPersist    <-chan types.PersistentState
+	configDigest types.ConfigDigest
+	database     types.Database
+	localConfig  *config.LocalConfigHolder
+	logger       loghelper.LoggerWithContext
 
 	writtenState *types.PersistentState
 }
@@ -28,7 +28,7 @@ func PersistPacemaker(
 	chPersist <-chan types.PersistentState,
 	configDigest types.ConfigDigest,
 	database types.Database,
-	databaseTimeout time.Duration,
+	localConfig *config.LocalConfigHolder,
 	logger loghelper.LoggerWithContext,
 ) {
 	ps := persistPacemakerState{
@@ -37,7 +37,7 @@ func PersistPacemaker(
 		chPersist,
 		configDigest,
 		database,
-		databaseTimeout,
+		localConfig,
 		logger,
 
 		nil,
@@ -89,7 +89,7 @@ func (ps *persistPacemakerState) writeIfNew(pendingState types.PersistentState)
 		return
 	}
 
-	writeCtx, writeCancel := context.WithTimeout(ps.ctx, ps.databaseTimeout)
+	writeCtx, writeCancel := context.WithTimeout(ps.ctx, ps.localConfig.Get().DatabaseTimeout)
 	defer writeCancel()
 	err := ps.database.WriteState(
 		writeCtx,
@@ -2,15 +2,15 @@ package persist
 
 import (
 	"context"
-	"time"
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/config"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 )
 
 type TransmissionDBUpdate struct {
-	Timestamp           types.ReportTimestamp
+	Key                 types.PendingTransmissionKey
 	PendingTransmission *types.PendingTransmission
 }
 
@@ -20,7 +20,7 @@ func PersistTransmission(
 	ctx context.Context,
 	chPersist <-chan TransmissionDBUpdate,
 	db types.Database,
-	dbTimeout time.Duration,
+	localConfig *config.LocalConfigHolder,
 	logger loghelper.LoggerWithContext,
 ) {
 	for {
@@ -32,15 +32,15 @@ func PersistTransmission(
 			}
 
 			func() {
-				dbCtx, dbCancel := context.WithTimeout(ctx, dbTimeout)
+				dbCtx, dbCancel := context.WithTimeout(ctx, localConfig.Get().DatabaseTimeout)
 				defer dbCancel()
 
 				store := update.PendingTransmission != nil
 				var err error
 				if store {
-					err = db.StorePendingTransmission(dbCtx, update.Timestamp, *update.PendingTransmission)
+					err = db.StorePendingTransmission(dbCtx, update.Key, *update.PendingTransmission)
 				} else {
-					err = db.DeletePendingTransmission(dbCtx, update.Timestamp)
+					err = db.DeletePendingTransmission(dbCtx, update.Key)
 				}
 				if err != nil {
 					logger.ErrorIfNotCanceled(
@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"time"
 
@@ -21,11 +22,12 @@ func RunReportFinalization(
 	contractSigner types.OnchainKeyring,
 	logger loghelper.LoggerWithContext,
 	netSender NetworkSender,
+	outcomeStateStore *outcomeStateStore,
 	reportQuorum int,
 ) {
 	newReportFinalizationState(ctx, chNetToReportFinalization,
 		chReportFinalizationToTransmission, chReportGenerationToReportFinalization,
-		config, contractSigner, logger, netSender, reportQuorum).run()
+		config, contractSigner, logger, netSender, outcomeStateStore, reportQuorum).run()
 }
 
 const minExpirationAgeRounds int = 10
@@ -42,6 +44,7 @@ type reportFinalizationState struct {
 	contractSigner                         types.OnchainKeyring
 	logger                                 loghelper.LoggerWithContext
 	netSender                              NetworkSender
+	outcomeStateStore                      *outcomeStateStore // nil unless the ReportingPlugin uses outcome states
 	reportQuorum                           int
 
 	// reap() is used to prevent unbounded state growth of finalized
@@ -93,14 +96,12 @@ func (repfin *reportFinalizationState) messageFinalEcho(
 		return
 	}
 
-	err := msg.AttestedReport.VerifySignatures(
-		repfin.reportQuorum,
+	err := verifyMessageFinal(
+		repfin.config,
 		repfin.contractSigner,
-		repfin.config.OracleIdentities,
-		types.ReportContext{
-			types.ReportTimestamp{repfin.config.ConfigDigest, msg.Epoch, msg.Round},
-			msg.H,
-		},
+		repfin.outcomeStateStore != nil,
+		repfin.reportQuorum,
+		msg.MessageFinal,
 	)
 	if err != nil {
 		repfin.logger.Warn("error while verifying signatures on attested report", commontypes.LogFields{
@@ -145,14 +146,79 @@ func (repfin *reportFinalizationState) finalize(msg MessageFinal) {
 
 	repfin.netSender.Broadcast(MessageFinalEcho{msg}) // send [ FINALECHO, e, r, O] to all p_j ∈ P
 
-	select {
-	case repfin.chReportFinalizationToTransmission <- EventTransmit(msg):
-	case <-repfin.ctx.Done():
+	if repfin.outcomeStateStore != nil {
+		repfin.outcomeStateStore.commit(repfin.ctx, epochRound, msg.CertifiedOutcomeState.OutcomeState)
+	}
+
+	for i, attestedReport := range msg.AttestedReports {
+		repctx := makeReportContext(
+			types.ReportTimestamp{repfin.config.ConfigDigest, msg.Epoch, msg.Round},
+			msg.H,
+			uint8(i),
+		)
+		select {
+		case repfin.chReportFinalizationToTransmission <- EventTransmit{
+			msg.Epoch,
+			msg.Round,
+			repctx.ExtraHash,
+			repctx.ReportIndex,
+			attestedReport,
+		}:
+		case <-repfin.ctx.Done():
+			return
+		}
 	}
 
 	repfin.reap()
 }
 
+// verifyMessageFinal checks the signatures on the attested reports in msg and,
+// if outcomeStates is set, the certificate on its outcome state. With outcome
+// states, a MessageFinal without reports indicates that no report was
+// generated in the round.
+func verifyMessageFinal(
+	config config.SharedConfig,
+	contractSigner types.OnchainKeyring,
+	outcomeStates bool,
+	reportQuorum int,
+	msg MessageFinal,
+) error {
+	repts := types.ReportTimestamp{config.ConfigDigest, msg.Epoch, msg.Round}
+	if outcomeStates {
+		if err := msg.CertifiedOutcomeState.Verify(
+			outcomeStateQuorum(config.N(), config.F),
+			config.OracleIdentities,
+			repts,
+		); err != nil {
+			return err
+		}
+	} else {
+		if !msg.CertifiedOutcomeState.IsEmpty() {
+			return fmt.Errorf("MessageFinal has outcome state even though outcome states are disabled")
+		}
+		if len(msg.AttestedReports) == 0 {
+			return fmt.Errorf("MessageFinal has no reports")
+		}
+	}
+	if len(msg.AttestedReports) > types.MaxReportsPerRound {
+		return fmt.Errorf("MessageFinal has too many reports, expected at most %v and got %v", types.MaxReportsPerRound, len(msg.AttestedReports))
+	}
+	// Since makeReportContext binds signatures to the report's index, honest
+	// oracles won't have signed any report beyond the first unless the
+	// ReportingPlugin implements MultiReportReportingPlugin.
+	for i := range msg.AttestedReports {
+		if err := msg.AttestedReports[i].VerifySignatures(
+			reportQuorum,
+			contractSigner,
+			config.OracleIdentities,
+			makeReportContext(repts, msg.H, uint8(i)),
+		); err != nil {
+			return fmt.Errorf("report %v: %w", i, err)
+		}
+	}
+	return nil
+}
+
 func (repfin *reportFinalizationState) isExpired(er EpochRound) bool {
 	latestIndex := repfin.epochRoundIndex(repfin.finalizedLatest)
 	expiredIndex := latestIndex - int64(repfin.expirationAgeRounds())
@@ -208,6 +274,7 @@ func newReportFinalizationState(
 	contractSigner types.OnchainKeyring,
 	logger loghelper.LoggerWithContext,
 	netSender NetworkSender,
+	outcomeStateStore *outcomeStateStore,
 	reportQuorum int,
 ) *reportFinalizationState {
 	return &reportFinalizationState{
@@ -220,6 +287,7 @@ func newReportFinalizationState(
 		contractSigner,
 		logger,
 		netSender,
+		outcomeStateStore,
 		reportQuorum,
 
 		map[EpochRound]struct{}{},
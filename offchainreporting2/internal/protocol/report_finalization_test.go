@@ -0,0 +1,197 @@
+package protocol
+
+import (
+	"context"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/config"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// testOnchainKeyring signs ExtraHash and the report with ed25519. Like many
+// real OnchainKeyrings, it ignores ReportContext.ReportIndex.
+type testOnchainKeyring struct {
+	key ed25519.PrivateKey
+}
+
+func (k testOnchainKeyring) PublicKey() types.OnchainPublicKey {
+	return types.OnchainPublicKey(k.key.Public().(ed25519.PublicKey))
+}
+
+func testOnchainSignedMessage(repctx types.ReportContext, report types.Report) []byte {
+	return append(append([]byte{}, repctx.ExtraHash[:]...), report...)
+}
+
+func (k testOnchainKeyring) Sign(repctx types.ReportContext, report types.Report) ([]byte, error) {
+	return ed25519.Sign(k.key, testOnchainSignedMessage(repctx, report)), nil
+}
+
+func (testOnchainKeyring) Verify(publicKey types.OnchainPublicKey, repctx types.ReportContext, report types.Report, signature []byte) bool {
+	return len(publicKey) == ed25519.PublicKeySize && ed25519.Verify(ed25519.PublicKey(publicKey), testOnchainSignedMessage(repctx, report), signature)
+}
+
+func (testOnchainKeyring) MaxSignatureLength() int {
+	return ed25519.SignatureSize
+}
+
+func TestMakeReportContext(t *testing.T) {
+	repts := types.ReportTimestamp{types.ConfigDigest{1}, 2, 3}
+	h := [32]byte{4}
+
+	repctx := makeReportContext(repts, h, 0)
+	if repctx != (types.ReportContext{repts, h, 0}) {
+		t.Fatalf("report context for index 0 should use h as ExtraHash, got %v", repctx)
+	}
+
+	extraHashes := map[[32]byte]uint8{h: 0}
+	for i := 1; i < types.MaxReportsPerRound; i++ {
+		repctx := makeReportContext(repts, h, uint8(i))
+		if repctx.ReportTimestamp != repts || repctx.ReportIndex != uint8(i) {
+			t.Fatalf("unexpected report context for index %v: %v", i, repctx)
+		}
+		if j, ok := extraHashes[repctx.ExtraHash]; ok {
+			t.Fatalf("indices %v and %v share ExtraHash %x", i, j, repctx.ExtraHash)
+		}
+		extraHashes[repctx.ExtraHash] = uint8(i)
+		if makeReportContext(repts, h, uint8(i)) != repctx {
+			t.Fatalf("report context for index %v isn't deterministic", i)
+		}
+		if makeReportContext(repts, [32]byte{5}, uint8(i)).ExtraHash == repctx.ExtraHash {
+			t.Fatalf("ExtraHash for index %v doesn't depend on h", i)
+		}
+	}
+}
+
+// multiReportFixture is a MessageFinal with two reports, signed by a quorum of
+// oracles
+type multiReportFixture struct {
+	config   config.SharedConfig
+	keyrings []testOnchainKeyring
+	quorum   int
+	msg      MessageFinal
+}
+
+func newMultiReportFixture(t *testing.T) multiReportFixture {
+	keys, identities := makeTestOracleIdentities(t, 4)
+	keyrings := make([]testOnchainKeyring, 0, len(keys))
+	for i, key := range keys {
+		keyrings = append(keyrings, testOnchainKeyring{key})
+		identities[i].OnchainPublicKey = keyrings[i].PublicKey()
+	}
+	fx := multiReportFixture{
+		config.SharedConfig{PublicConfig: config.PublicConfig{
+			RMax:             10,
+			OracleIdentities: identities,
+			F:                1,
+			ConfigDigest:     types.ConfigDigest{1},
+		}},
+		keyrings,
+		2,
+		MessageFinal{2, 3, [32]byte{4}, nil, CertifiedOutcomeState{}},
+	}
+	repts := types.ReportTimestamp{fx.config.ConfigDigest, fx.msg.Epoch, fx.msg.Round}
+	for i, report := range []types.Report{types.Report("first"), types.Report("second")} {
+		fx.msg.AttestedReports = append(fx.msg.AttestedReports, fx.sign(t, makeReportContext(repts, fx.msg.H, uint8(i)), report))
+	}
+	return fx
+}
+
+func (fx multiReportFixture) sign(t *testing.T, repctx types.ReportContext, report types.Report) AttestedReportMany {
+	arm := AttestedReportMany{report, nil}
+	for signer := 0; signer < fx.quorum; signer++ {
+		sig, err := fx.keyrings[signer].Sign(repctx, report)
+		if err != nil {
+			t.Fatal(err)
+		}
+		arm.AttributedSignatures = append(arm.AttributedSignatures, types.AttributedOnchainSignature{sig, commontypes.OracleID(signer)})
+	}
+	return arm
+}
+
+func TestVerifyMessageFinalReportIndex(t *testing.T) {
+	fx := newMultiReportFixture(t)
+
+	if err := verifyMessageFinal(fx.config, fx.keyrings[0], false, fx.quorum, fx.msg); err != nil {
+		t.Fatal(err)
+	}
+
+	swapped := fx.msg
+	swapped.AttestedReports = []AttestedReportMany{fx.msg.AttestedReports[1], fx.msg.AttestedReports[0]}
+	if err := verifyMessageFinal(fx.config, fx.keyrings[0], false, fx.quorum, swapped); err == nil {
+		t.Fatal("expected error for reports signed at a different index")
+	}
+
+	// honest oracles sign a single report per round with index 0, which
+	// mustn't be replayed at another index
+	repts := types.ReportTimestamp{fx.config.ConfigDigest, fx.msg.Epoch, fx.msg.Round}
+	replayed := fx.msg
+	replayed.AttestedReports = []AttestedReportMany{fx.msg.AttestedReports[0], fx.sign(t, makeReportContext(repts, fx.msg.H, 0), types.Report("second"))}
+	if err := verifyMessageFinal(fx.config, fx.keyrings[0], false, fx.quorum, replayed); err == nil {
+		t.Fatal("expected error for report signed at index 0 in position 1")
+	}
+
+	tooMany := fx.msg
+	tooMany.AttestedReports = nil
+	for i := 0; i <= types.MaxReportsPerRound; i++ {
+		tooMany.AttestedReports = append(tooMany.AttestedReports, fx.sign(t, makeReportContext(repts, fx.msg.H, uint8(i)), types.Report("report")))
+	}
+	if err := verifyMessageFinal(fx.config, fx.keyrings[0], false, fx.quorum, tooMany); err == nil {
+		t.Fatal("expected error for too many reports")
+	}
+
+	empty := fx.msg
+	empty.AttestedReports = nil
+	if err := verifyMessageFinal(fx.config, fx.keyrings[0], false, fx.quorum, empty); err == nil {
+		t.Fatal("expected error for MessageFinal without reports")
+	}
+}
+
+type nopNetworkSender struct{}
+
+func (nopNetworkSender) SendTo(Message, commontypes.OracleID) {}
+func (nopNetworkSender) Broadcast(Message)                    {}
+
+func TestFinalizeReportIndex(t *testing.T) {
+	fx := newMultiReportFixture(t)
+
+	chTransmit := make(chan EventToTransmission, len(fx.msg.AttestedReports))
+	repfin := newReportFinalizationState(
+		context.Background(),
+		nil,
+		chTransmit,
+		nil,
+		fx.config,
+		fx.keyrings[0],
+		loghelper.MakeRootLoggerWithContext(nopLogger{}),
+		nopNetworkSender{},
+		nil,
+		fx.quorum,
+	)
+	repfin.finalize(fx.msg)
+
+	repts := types.ReportTimestamp{fx.config.ConfigDigest, fx.msg.Epoch, fx.msg.Round}
+	for i := range fx.msg.AttestedReports {
+		var ev EventTransmit
+		select {
+		case e := <-chTransmit:
+			ev = e.(EventTransmit)
+		default:
+			t.Fatalf("expected EventTransmit for report %v", i)
+		}
+		repctx := makeReportContext(repts, fx.msg.H, uint8(i))
+		if ev.Epoch != fx.msg.Epoch || ev.Round != fx.msg.Round || ev.ReportIndex != repctx.ReportIndex || ev.H != repctx.ExtraHash {
+			t.Errorf("unexpected EventTransmit for report %v: %v", i, ev)
+		}
+		if err := ev.AttestedReport.VerifySignatures(fx.quorum, fx.keyrings[0], fx.config.OracleIdentities, types.ReportContext{repts, ev.H, ev.ReportIndex}); err != nil {
+			t.Errorf("report %v doesn't verify with the transmitted report context: %v", i, err)
+		}
+	}
+	select {
+	case ev := <-chTransmit:
+		t.Fatalf("unexpected event %v", ev)
+	default:
+	}
+}
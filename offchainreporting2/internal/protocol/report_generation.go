@@ -24,11 +24,12 @@ func RunReportGeneration(
 	e uint32,
 	id commontypes.OracleID,
 	l commontypes.OracleID,
-	localConfig types.LocalConfig,
+	localConfig *config.LocalConfigHolder,
 	logger loghelper.LoggerWithContext,
 	netSender NetworkSender,
 	offchainKeyring types.OffchainKeyring,
 	onchainKeyring types.OnchainKeyring,
+	outcomeStateStore *outcomeStateStore,
 	reportingPlugin types.ReportingPlugin,
 	reportQuorum int,
 	telemetrySender TelemetrySender,
@@ -50,6 +51,7 @@ func RunReportGeneration(
 		netSender:                              netSender,
 		offchainKeyring:                        offchainKeyring,
 		onchainKeyring:                         onchainKeyring,
+		outcomeStateStore:                      outcomeStateStore,
 		reportingPlugin:                        reportingPlugin,
 		reportQuorum:                           reportQuorum,
 		telemetrySender:                        telemetrySender,
@@ -69,11 +71,12 @@ type reportGenerationState struct {
 	e                                      uint32 // Current epoch number
 	id                                     commontypes.OracleID
 	l                                      commontypes.OracleID // Current leader number
-	localConfig                            types.LocalConfig
+	localConfig                            *config.LocalConfigHolder
 	logger                                 loghelper.LoggerWithContext
 	netSender                              NetworkSender
 	offchainKeyring                        types.OffchainKeyring
 	onchainKeyring                         types.OnchainKeyring
+	outcomeStateStore                      *outcomeStateStore // nil unless the ReportingPlugin uses outcome states
 	reportingPlugin                        types.ReportingPlugin
 	reportQuorum                           int
 	telemetrySender                        TelemetrySender
@@ -91,8 +94,27 @@ type leaderState struct {
 	// observe contains the observations received so far
 	observe []*SignedObservation
 
-	// report contains the signed reports received so far
-	report []*AttestedReportOne
+	// Only used if config.CommitRevealObservations is set.
+	// commit contains the observation commitments received so far, nonce
+	// contains the nonces opening them for all revealed observations in
+	// observe. fixedCommit contains the commitments sent in the latest
+	// MessageRevealReq.
+	commit      []*SignedObservationCommitment
+	nonce       []ObservationNonce
+	fixedCommit []*SignedObservationCommitment
+	// number of MessageRevealReqs sent this round, at most
+	// MaxRevealReqsPerRound
+	revealReqs int
+
+	// report contains the signed reports received so far, see
+	// MessageReport.AttestedReports. nil if none has been received from an
+	// oracle.
+	report [][]AttestedReportOne
+
+	// Only used if the ReportingPlugin uses outcome states.
+	// outcomeState contains the signed outcome states received so far, along
+	// with report.
+	outcomeState []*SignedOutcomeState
 
 	// tRound is a heartbeat indicating when the current leader should start a new
 	// round.
@@ -104,7 +126,11 @@ type leaderState struct {
 
 	// tGrace is a grace period the leader waits for after it has achieved
 	// quorum on "observe" messages, to allow slower oracles time to submit their
-	// observations.
+	// observations. In commit-reveal mode, the leader waits for it twice: once
+	// after it has achieved quorum on commitments and once after it has
+	// achieved quorum on reveals. In between, it fires every DeltaGrace to
+	// extend the MessageRevealReq by late commitments while the leader waits
+	// for reveals.
 	tGrace <-chan time.Time
 
 	phase phase
@@ -125,6 +151,17 @@ type followerState struct {
 	// completedRound tracks whether the current oracle has completed the current
 	// round
 	completedRound bool
+
+	// Only used if config.CommitRevealObservations is set.
+	// observation and nonce are the current oracle's observation for this
+	// round and the nonce used to commit to it. observation is nil if the
+	// oracle didn't make an observation.
+	observation *SignedObservation
+	nonce       ObservationNonce
+	// commitments are the commitments fixed by the leader's latest
+	// MessageRevealReq, indexed by oracle. nil if no valid MessageRevealReq
+	// has been received.
+	commitments []*SignedObservationCommitment
 }
 
 // Run starts the event loop for the report-generation protocol
@@ -133,7 +170,8 @@ func (repgen *reportGenerationState) run() {
 
 	// Initialization
 	repgen.leaderState.r = 0
-	repgen.leaderState.report = make([]*AttestedReportOne, repgen.config.N())
+	repgen.leaderState.report = make([][]AttestedReportOne, repgen.config.N())
+	repgen.leaderState.outcomeState = make([]*SignedOutcomeState, repgen.config.N())
 	repgen.leaderState.readyToStartRound = false
 	repgen.followerState.r = 0
 	repgen.followerState.completedRound = false
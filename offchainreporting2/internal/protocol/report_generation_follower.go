@@ -34,6 +34,27 @@ func reportContextHash(query types.Query, aos []types.AttributedObservation) [32
 	return output
 }
 
+// makeReportContext returns the ReportContext for the report at reportIndex
+// among the reports generated in a round with report context hash h. For
+// reportIndex 0, ExtraHash is h itself, so nothing changes for plugins that
+// generate a single report per round. For other indices, ExtraHash commits to
+// the index, so that signatures are bound to the report's position even for
+// OnchainKeyrings that ignore ReportContext.ReportIndex.
+func makeReportContext(repts types.ReportTimestamp, h [32]byte, reportIndex uint8) types.ReportContext {
+	if reportIndex == 0 {
+		return types.ReportContext{repts, h, 0}
+	}
+
+	hash := sha256.New()
+	_, _ = hash.Write([]byte("ocr2 report index"))
+	_, _ = hash.Write(h[:])
+	_, _ = hash.Write([]byte{reportIndex})
+
+	var extraHash [32]byte
+	copy(extraHash[:], hash.Sum(nil))
+	return types.ReportContext{repts, extraHash, reportIndex}
+}
+
 func (repgen *reportGenerationState) followerReportTimestamp() types.ReportTimestamp {
 	return types.ReportTimestamp{repgen.config.ConfigDigest, repgen.e, repgen.followerState.r}
 }
@@ -115,6 +136,9 @@ func (repgen *reportGenerationState) messageObserveReq(msg MessageObserveReq, se
 	// and has no impact on the transmission process.)
 	repgen.followerState.sentReport = false
 	repgen.followerState.completedRound = false
+	repgen.followerState.observation = nil
+	repgen.followerState.nonce = ObservationNonce{}
+	repgen.followerState.commitments = nil
 
 	repgen.telemetrySender.RoundStarted(
 		repgen.config.ConfigDigest,
@@ -169,6 +193,11 @@ func (repgen *reportGenerationState) messageObserveReq(msg MessageObserveReq, se
 		return
 	}
 
+	if repgen.config.CommitRevealObservations {
+		repgen.sendObserveCommit(msg.Query, so)
+		return
+	}
+
 	repgen.logger.Debug("sent observation to leader", commontypes.LogFields{
 		"round":       repgen.followerState.r,
 		"observation": o,
@@ -180,6 +209,133 @@ func (repgen *reportGenerationState) messageObserveReq(msg MessageObserveReq, se
 	}, repgen.l)
 }
 
+// sendObserveCommit commits to so and sends the commitment to the leader. so
+// is only revealed once the leader has fixed the set of commitments for the
+// round, see messageRevealReq.
+func (repgen *reportGenerationState) sendObserveCommit(query types.Query, so SignedObservation) {
+	nonce, err := MakeObservationNonce()
+	if err != nil {
+		repgen.logger.Error("sendObserveCommit: could not make ObservationNonce", commontypes.LogFields{
+			"round": repgen.followerState.r,
+			"error": err,
+		})
+		return
+	}
+
+	soc, err := MakeSignedObservationCommitment(
+		repgen.followerReportTimestamp(),
+		MakeObservationCommitment(repgen.followerReportTimestamp(), query, so.Observation, nonce),
+		repgen.offchainKeyring.OffchainSign,
+	)
+	if err != nil {
+		repgen.logger.Error("sendObserveCommit: could not make SignedObservationCommitment", commontypes.LogFields{
+			"round": repgen.followerState.r,
+			"error": err,
+		})
+		return
+	}
+
+	if err := soc.Verify(repgen.followerReportTimestamp(), repgen.offchainKeyring.OffchainPublicKey()); err != nil {
+		repgen.logger.Error("MakeSignedObservationCommitment produced invalid signature:", commontypes.LogFields{
+			"round": repgen.followerState.r,
+			"error": err,
+		})
+		return
+	}
+
+	repgen.followerState.observation = &so
+	repgen.followerState.nonce = nonce
+
+	repgen.logger.Debug("sent observation commitment to leader", commontypes.LogFields{
+		"round":       repgen.followerState.r,
+		"observation": so.Observation,
+	})
+	repgen.netSender.SendTo(MessageObserveCommit{
+		repgen.e,
+		repgen.followerState.r,
+		soc,
+	}, repgen.l)
+}
+
+// messageRevealReq is called when the oracle receives a reveal-req message from
+// the current leader. If the message contains more than 2f valid commitments
+// from distinct oracles, the oracle remembers them for checking the
+// subsequent report-req and reveals its own observation to the leader, provided
+// that its commitment is among them. The leader may send further reveal-req
+// messages in the same round, each of which must extend the previous one by
+// late commitments.
+func (repgen *reportGenerationState) messageRevealReq(msg MessageRevealReq, sender commontypes.OracleID) {
+	dropPrefix := "messageRevealReq: dropping MessageRevealReq from "
+	if msg.Epoch != repgen.e {
+		repgen.logger.Debug(dropPrefix+"wrong epoch",
+			commontypes.LogFields{"round": repgen.followerState.r, "msgEpoch": msg.Epoch})
+		return
+	}
+	if sender != repgen.l {
+		// warn because someone *from this epoch* is trying to usurp the lead
+		repgen.logger.Warn(dropPrefix+"non-leader",
+			commontypes.LogFields{"round": repgen.followerState.r, "sender": sender})
+		return
+	}
+	if msg.Round != repgen.followerState.r {
+		repgen.logger.Debug(dropPrefix+"wrong round",
+			commontypes.LogFields{"round": repgen.followerState.r, "msgRound": msg.Round})
+		return
+	}
+	if !repgen.config.CommitRevealObservations {
+		repgen.logger.Warn(dropPrefix+"leader even though CommitRevealObservations is disabled",
+			commontypes.LogFields{"round": repgen.followerState.r})
+		return
+	}
+
+	commitments, err := repgen.verifyCommitments(msg.AttributedSignedCommitments)
+	if err != nil {
+		repgen.logger.Error("messageRevealReq: could not validate commitments sent by leader", commontypes.LogFields{
+			"round": repgen.followerState.r,
+			"error": err,
+			"msg":   msg,
+		})
+		return
+	}
+	previous := repgen.followerState.commitments
+	if previous != nil && !extendsCommitments(commitments, previous) {
+		repgen.logger.Warn(dropPrefix+"leader not extending the previous MessageRevealReq",
+			commontypes.LogFields{"round": repgen.followerState.r})
+		return
+	}
+	repgen.followerState.commitments = commitments
+
+	if previous != nil && previous[repgen.id] != nil {
+		repgen.logger.Debug("messageRevealReq: already revealed observation", commontypes.LogFields{
+			"round": repgen.followerState.r,
+		})
+		return
+	}
+
+	if repgen.followerState.observation == nil {
+		repgen.logger.Debug("messageRevealReq: no observation to reveal", commontypes.LogFields{
+			"round": repgen.followerState.r,
+		})
+		return
+	}
+	if commitments[repgen.id] == nil {
+		repgen.logger.Debug("messageRevealReq: leader didn't include our commitment, not revealing", commontypes.LogFields{
+			"round": repgen.followerState.r,
+		})
+		return
+	}
+
+	repgen.logger.Debug("revealed observation to leader", commontypes.LogFields{
+		"round": repgen.followerState.r,
+	})
+	repgen.netSender.SendTo(MessageObserveReveal{
+		repgen.e,
+		repgen.followerState.r,
+		*repgen.followerState.observation,
+		repgen.followerState.nonce,
+	}, repgen.l)
+}
+
 // messageReportReq is called when an oracle receives a report-req message from
 // the current leader. If the contained report validates, the oracle signs it
 // and sends it back to the leader.
@@ -233,8 +389,7 @@ func (repgen *reportGenerationState) messageReportReq(msg MessageReportReq, send
 		})
 	}
 
-	var shouldReport bool
-	var report types.Report
+	var reports []types.Report
 	{
 		ctx, cancel := context.WithTimeout(repgen.ctx, repgen.config.MaxDurationReport)
 		defer cancel()
@@ -249,12 +404,7 @@ func (repgen *reportGenerationState) messageReportReq(msg MessageReportReq, send
 		)
 
 		var err error
-		shouldReport, report, err = repgen.reportingPlugin.Report(
-			ctx,
-			repgen.followerReportTimestamp(),
-			msg.Query,
-			aos,
-		)
+		reports, err = repgen.reports(ctx, msg.Query, aos)
 
 		ins.Stop()
 
@@ -269,52 +419,63 @@ func (repgen *reportGenerationState) messageReportReq(msg MessageReportReq, send
 		}
 	}
 
-	var attestedReport AttestedReportOne
-	if shouldReport {
-		attestedReport, err = MakeAttestedReportOneNoskip(
-			types.ReportContext{
-				repgen.followerReportTimestamp(),
-				reportContextHash(msg.Query, aos),
-			},
-			report,
-			repgen.onchainKeyring.Sign,
-		)
-
-		if err != nil {
-			// Can't really do much here except logging as much detail as possible to
-			// aid reproduction, and praying it won't happen again
-			repgen.logger.Error("messageReportReq: failed to sign report", commontypes.LogFields{
-				"round":          repgen.followerState.r,
-				"error":          err,
-				"id":             repgen.id,
-				"attestedReport": attestedReport,
-				"pubkey":         repgen.onchainKeyring.PublicKey(),
-			})
+	var signedOutcomeState SignedOutcomeState
+	if repgen.outcomeStateStore != nil {
+		var ok bool
+		signedOutcomeState, ok = repgen.makeSignedOutcomeState(msg.Query, aos)
+		if !ok {
 			return
 		}
-	} else {
-		attestedReport = MakeAttestedReportOneSkip()
-		repgen.completeRound()
 	}
 
-	{
-		err := attestedReport.Verify(
-			repgen.onchainKeyring,
-			repgen.onchainKeyring.PublicKey(),
-			types.ReportContext{
-				repgen.followerReportTimestamp(),
-				reportContextHash(msg.Query, aos),
-			},
-		)
+	var attestedReports []AttestedReportOne
+	if len(reports) != 0 {
+		h := reportContextHash(msg.Query, aos)
+		for i, report := range reports {
+			repctx := makeReportContext(repgen.followerReportTimestamp(), h, uint8(i))
+			attestedReport, err := MakeAttestedReportOneNoskip(
+				repctx,
+				report,
+				repgen.onchainKeyring.Sign,
+			)
+
+			if err != nil {
+				// Can't really do much here except logging as much detail as possible to
+				// aid reproduction, and praying it won't happen again
+				repgen.logger.Error("messageReportReq: failed to sign report", commontypes.LogFields{
+					"round":          repgen.followerState.r,
+					"reportIndex":    i,
+					"error":          err,
+					"id":             repgen.id,
+					"attestedReport": attestedReport,
+					"pubkey":         repgen.onchainKeyring.PublicKey(),
+				})
+				return
+			}
 
-		if err != nil {
-			repgen.logger.Error("could not verify my own signature", commontypes.LogFields{
-				"round":          repgen.followerState.r,
-				"error":          err,
-				"id":             repgen.id,
-				"attestedReport": attestedReport, // includes sig
-				"pubkey":         repgen.onchainKeyring.PublicKey()})
-			return
+			if err := attestedReport.Verify(
+				repgen.onchainKeyring,
+				repgen.onchainKeyring.PublicKey(),
+				repctx,
+			); err != nil {
+				repgen.logger.Error("could not verify my own signature", commontypes.LogFields{
+					"round":          repgen.followerState.r,
+					"reportIndex":    i,
+					"error":          err,
+					"id":             repgen.id,
+					"attestedReport": attestedReport, // includes sig
+					"pubkey":         repgen.onchainKeyring.PublicKey()})
+				return
+			}
+
+			attestedReports = append(attestedReports, attestedReport)
+		}
+	} else {
+		attestedReports = []AttestedReportOne{MakeAttestedReportOneSkip()}
+		// With outcome states, the leader sends MessageFinal even if no report
+		// is generated. The round is completed once we receive it.
+		if repgen.outcomeStateStore == nil {
+			repgen.completeRound()
 		}
 	}
 
@@ -323,12 +484,108 @@ func (repgen *reportGenerationState) messageReportReq(msg MessageReportReq, send
 		MessageReport{
 			repgen.e,
 			repgen.followerState.r,
-			attestedReport,
+			attestedReports,
+			signedOutcomeState,
 		},
 		repgen.l,
 	)
 }
 
+// reports returns the reports to generate in the current round, or nil if no
+// report should be generated. It calls MultiReportReportingPlugin.Reports if
+// the ReportingPlugin implements it, and ReportingPlugin.Report otherwise.
+func (repgen *reportGenerationState) reports(ctx context.Context, query types.Query, aos []types.AttributedObservation) ([]types.Report, error) {
+	if reportingPlugin, ok := repgen.reportingPlugin.(types.MultiReportReportingPlugin); ok {
+		reports, err := reportingPlugin.Reports(ctx, repgen.followerReportTimestamp(), query, aos)
+		if err != nil {
+			return nil, err
+		}
+		if len(reports) > types.MaxReportsPerRound {
+			return nil, errors.Errorf("ReportingPlugin.Reports returned %v reports, but at most %v are allowed", len(reports), types.MaxReportsPerRound)
+		}
+		return reports, nil
+	}
+
+	shouldReport, report, err := repgen.reportingPlugin.Report(ctx, repgen.followerReportTimestamp(), query, aos)
+	if err != nil || !shouldReport {
+		return nil, err
+	}
+	return []types.Report{report}, nil
+}
+
+// makeSignedOutcomeState derives the round's OutcomeState from the latest
+// committed one and signs it. It logs and returns false on failure.
+func (repgen *reportGenerationState) makeSignedOutcomeState(query types.Query, aos []types.AttributedObservation) (SignedOutcomeState, bool) {
+	reportingPlugin, ok := repgen.reportingPlugin.(types.OutcomeStateReportingPlugin)
+	if !ok {
+		// assertion
+		repgen.logger.Critical("makeSignedOutcomeState: ReportingPlugin doesn't implement OutcomeStateReportingPlugin", nil)
+		return SignedOutcomeState{}, false
+	}
+
+	var outcomeState types.OutcomeState
+	{
+		ctx, cancel := context.WithTimeout(repgen.ctx, repgen.config.MaxDurationReport)
+		defer cancel()
+
+		ins := loghelper.NewIfNotStopped(
+			repgen.config.MaxDurationReport+ReportingPluginTimeoutWarningGracePeriod,
+			func() {
+				repgen.logger.Error("ReportGeneration: ReportingPlugin.Outcome is taking too long", commontypes.LogFields{
+					"round": repgen.followerState.r, "maxDuration": repgen.config.MaxDurationReport,
+				})
+			},
+		)
+
+		var err error
+		outcomeState, err = reportingPlugin.Outcome(
+			ctx,
+			repgen.followerReportTimestamp(),
+			repgen.outcomeStateStore.latest(),
+			query,
+			aos,
+		)
+
+		ins.Stop()
+
+		if err != nil {
+			repgen.logger.Error("makeSignedOutcomeState: error in ReportingPlugin.Outcome", commontypes.LogFields{
+				"round": repgen.followerState.r,
+				"error": err,
+			})
+			return SignedOutcomeState{}, false
+		}
+	}
+
+	if len(outcomeState) > types.MaxOutcomeStateLength {
+		repgen.logger.Error("makeSignedOutcomeState: ReportingPlugin.Outcome returned OutcomeState that is too long", commontypes.LogFields{
+			"round":                 repgen.followerState.r,
+			"outcomeStateLength":    len(outcomeState),
+			"maxOutcomeStateLength": types.MaxOutcomeStateLength,
+		})
+		return SignedOutcomeState{}, false
+	}
+
+	sos, err := MakeSignedOutcomeState(repgen.followerReportTimestamp(), outcomeState, repgen.offchainKeyring.OffchainSign)
+	if err != nil {
+		repgen.logger.Error("makeSignedOutcomeState: could not sign OutcomeState", commontypes.LogFields{
+			"round": repgen.followerState.r,
+			"error": err,
+		})
+		return SignedOutcomeState{}, false
+	}
+
+	if err := sos.Verify(repgen.followerReportTimestamp(), repgen.offchainKeyring.OffchainPublicKey()); err != nil {
+		repgen.logger.Error("MakeSignedOutcomeState produced invalid signature:", commontypes.LogFields{
+			"round": repgen.followerState.r,
+			"error": err,
+		})
+		return SignedOutcomeState{}, false
+	}
+
+	return sos, true
+}
+
 // messageFinal is called when a "final" message is received for the local
 // oracle process. If the report in the msg is valid, the oracle broadcasts it
 // in a "final-echo" message.
@@ -351,11 +608,12 @@ func (repgen *reportGenerationState) messageFinal(
 			"round": repgen.followerState.r, "msgRound": msg.Round})
 		return
 	}
-	if err := msg.AttestedReport.VerifySignatures(
-		repgen.reportQuorum,
+	if err := verifyMessageFinal(
+		repgen.config,
 		repgen.onchainKeyring,
-		repgen.config.OracleIdentities,
-		types.ReportContext{repgen.followerReportTimestamp(), msg.H},
+		repgen.outcomeStateStore != nil,
+		repgen.reportQuorum,
+		msg,
 	); err != nil {
 		repgen.logger.Error("could not validate signatures on attested report in MessageFinal",
 			commontypes.LogFields{
@@ -366,6 +624,16 @@ func (repgen *reportGenerationState) messageFinal(
 		return
 	}
 
+	// Commit right away rather than leaving it to report finalization, so that
+	// the next round is guaranteed to start from this round's OutcomeState.
+	if repgen.outcomeStateStore != nil {
+		repgen.outcomeStateStore.commit(
+			repgen.ctx,
+			EpochRound{msg.Epoch, msg.Round},
+			msg.CertifiedOutcomeState.OutcomeState,
+		)
+	}
+
 	select {
 	case repgen.chReportGenerationToReportFinalization <- EventFinal{msg}:
 	case <-repgen.ctx.Done():
@@ -391,7 +659,10 @@ func (repgen *reportGenerationState) completeRound() {
 
 // verifyReportReq errors unless its signatures are all correct given the
 // current round/epoch/config, and from distinct oracles, and there are more
-// than 2f observations.
+// than 2f observations. If config.CommitRevealObservations is set, it also
+// errors unless the commitments in msg are valid, extend those of the
+// leader's latest MessageRevealReq (if any), and each observation opens one
+// of them.
 func (repgen *reportGenerationState) verifyReportReq(msg MessageReportReq) error {
 	// check signatures and signature distinctness
 	{
@@ -418,5 +689,74 @@ func (repgen *reportGenerationState) verifyReportReq(msg MessageReportReq) error
 				"need more than %d", len(counted), bound)
 		}
 	}
+
+	// check that observations open the commitments fixed by the leader
+	if repgen.config.CommitRevealObservations {
+		commitments, err := repgen.verifyCommitments(msg.AttributedSignedCommitments)
+		if err != nil {
+			return err
+		}
+		previous := repgen.followerState.commitments
+		if previous != nil && !extendsCommitments(commitments, previous) {
+			return errors.Errorf("commitments don't extend those of the leader's MessageRevealReq")
+		}
+		if len(msg.ObservationNonces) != len(msg.AttributedSignedObservations) {
+			return errors.Errorf("got %v nonces for %v observations",
+				len(msg.ObservationNonces), len(msg.AttributedSignedObservations))
+		}
+		for i, obs := range msg.AttributedSignedObservations {
+			commitment := commitments[obs.Observer]
+			if commitment == nil {
+				return errors.Errorf("observation by oracle id %v wasn't committed to", obs.Observer)
+			}
+			if err := commitment.VerifyReveal(repgen.followerReportTimestamp(), msg.Query, obs.SignedObservation, msg.ObservationNonces[i]); err != nil {
+				return errors.Errorf("invalid reveal of observation by oracle id %v: %s", obs.Observer, err)
+			}
+		}
+	} else if len(msg.ObservationNonces) != 0 || len(msg.AttributedSignedCommitments) != 0 {
+		return errors.Errorf("got nonces or commitments even though CommitRevealObservations is disabled")
+	}
 	return nil
 }
+
+// extendsCommitments returns true if commitments contains every commitment in
+// previous
+func extendsCommitments(commitments []*SignedObservationCommitment, previous []*SignedObservationCommitment) bool {
+	for i, soc := range previous {
+		if soc != nil && (commitments[i] == nil || !commitments[i].Equal(*soc)) {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyCommitments errors unless the commitments are from distinct oracles,
+// all signatures are correct given the current round/epoch/config, and there
+// are more than 2f commitments. It returns the commitments indexed by oracle.
+func (repgen *reportGenerationState) verifyCommitments(asocs []AttributedSignedObservationCommitment) ([]*SignedObservationCommitment, error) {
+	commitments := make([]*SignedObservationCommitment, repgen.config.N())
+	count := 0
+	for _, asoc := range asocs {
+		asoc := asoc
+		// NOTE: OracleID is untrusted, therefore we _must_ bounds check it first
+		if int(asoc.Observer) < 0 || repgen.config.N() <= int(asoc.Observer) {
+			return nil, errors.Errorf("given oracle ID of %v is out of bounds (only "+
+				"have %v public keys)", asoc.Observer, repgen.config.N())
+		}
+		if commitments[asoc.Observer] != nil {
+			return nil, errors.Errorf("duplicate commitment by oracle id %v", asoc.Observer)
+		}
+		observerOffchainPublicKey := repgen.config.OracleIdentities[asoc.Observer].OffchainPublicKey
+		if err := asoc.SignedCommitment.Verify(repgen.followerReportTimestamp(), observerOffchainPublicKey); err != nil {
+			return nil, errors.Errorf("invalid signed commitment: %s", err)
+		}
+		commitments[asoc.Observer] = &asoc.SignedCommitment
+		count++
+	}
+	bound := 2 * repgen.config.F
+	if count <= bound {
+		return nil, errors.Errorf("not enough commitments; got %d, "+
+			"need more than %d", count, bound)
+	}
+	return commitments, nil
+}
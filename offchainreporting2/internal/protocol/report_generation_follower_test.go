@@ -0,0 +1,239 @@
+package protocol
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/config"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Trace(string, commontypes.LogFields)    {}
+func (nopLogger) Debug(string, commontypes.LogFields)    {}
+func (nopLogger) Info(string, commontypes.LogFields)     {}
+func (nopLogger) Warn(string, commontypes.LogFields)     {}
+func (nopLogger) Error(string, commontypes.LogFields)    {}
+func (nopLogger) Critical(string, commontypes.LogFields) {}
+
+// commitRevealFixture is a follower in round 1 of epoch 2 with n=4, f=1, along
+// with the offchain keys of all oracles
+type commitRevealFixture struct {
+	repgen *reportGenerationState
+	keys   []ed25519.PrivateKey
+	query  types.Query
+}
+
+// makeTestOracleIdentities returns n oracle identities with fresh offchain
+// keys, along with the corresponding private keys
+func makeTestOracleIdentities(t *testing.T, n int) ([]ed25519.PrivateKey, []config.OracleIdentity) {
+	keys := make([]ed25519.PrivateKey, 0, n)
+	identities := make([]config.OracleIdentity, 0, n)
+	for i := 0; i < n; i++ {
+		pk, sk, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var offchainPublicKey types.OffchainPublicKey
+		copy(offchainPublicKey[:], pk)
+		keys = append(keys, sk)
+		identities = append(identities, config.OracleIdentity{OffchainPublicKey: offchainPublicKey})
+	}
+	return keys, identities
+}
+
+func newCommitRevealFixture(t *testing.T) commitRevealFixture {
+	keys, identities := makeTestOracleIdentities(t, 4)
+	repgen := &reportGenerationState{
+		config: config.SharedConfig{PublicConfig: config.PublicConfig{
+			OracleIdentities:         identities,
+			CommitRevealObservations: true,
+			F:                        1,
+			ConfigDigest:             types.ConfigDigest{1},
+		}},
+		e:      2,
+		logger: loghelper.MakeRootLoggerWithContext(nopLogger{}),
+	}
+	repgen.followerState.r = 1
+	return commitRevealFixture{repgen, keys, types.Query("query")}
+}
+
+func (fx commitRevealFixture) signer(oid int) func([]byte) ([]byte, error) {
+	return func(msg []byte) ([]byte, error) {
+		return ed25519.Sign(fx.keys[oid], msg), nil
+	}
+}
+
+// reportReq returns a valid MessageReportReq with observations, nonces and
+// commitments by oracles oids
+func (fx commitRevealFixture) reportReq(t *testing.T, oids ...int) MessageReportReq {
+	repts := fx.repgen.followerReportTimestamp()
+	msg := MessageReportReq{repts.Epoch, repts.Round, fx.query, nil, nil, nil}
+	for _, oid := range oids {
+		observation := types.Observation{byte(oid)}
+		so, err := MakeSignedObservation(repts, fx.query, observation, fx.signer(oid))
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonce, err := MakeObservationNonce()
+		if err != nil {
+			t.Fatal(err)
+		}
+		soc, err := MakeSignedObservationCommitment(repts, MakeObservationCommitment(repts, fx.query, observation, nonce), fx.signer(oid))
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg.AttributedSignedObservations = append(msg.AttributedSignedObservations, AttributedSignedObservation{so, commontypes.OracleID(oid)})
+		msg.ObservationNonces = append(msg.ObservationNonces, nonce)
+		msg.AttributedSignedCommitments = append(msg.AttributedSignedCommitments, AttributedSignedObservationCommitment{soc, commontypes.OracleID(oid)})
+	}
+	return msg
+}
+
+// reportReqWithLateCommitment returns a valid MessageReportReq with
+// observations by oracles 0, 1, 2 and commitments by all oracles, as if oracle
+// 3 committed but never revealed
+func (fx commitRevealFixture) reportReqWithLateCommitment(t *testing.T) MessageReportReq {
+	msg := fx.reportReq(t, 0, 1, 2, 3)
+	msg.AttributedSignedObservations = msg.AttributedSignedObservations[:3]
+	msg.ObservationNonces = msg.ObservationNonces[:3]
+	return msg
+}
+
+func TestVerifyReportReqCommitReveal(t *testing.T) {
+	fx := newCommitRevealFixture(t)
+
+	t.Run("valid", func(t *testing.T) {
+		if err := fx.repgen.verifyReportReq(fx.reportReq(t, 0, 1, 2)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("valid with more commitments than observations", func(t *testing.T) {
+		if err := fx.repgen.verifyReportReq(fx.reportReqWithLateCommitment(t)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("valid extension of the follower's commitments", func(t *testing.T) {
+		msg := fx.reportReq(t, 0, 1, 2, 3)
+		commitments, err := fx.repgen.verifyCommitments(msg.AttributedSignedCommitments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitments[3] = nil
+		fx.repgen.followerState.commitments = commitments
+		defer func() { fx.repgen.followerState.commitments = nil }()
+		if err := fx.repgen.verifyReportReq(msg); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	invalid := []struct {
+		name   string
+		modify func(msg *MessageReportReq)
+	}{
+		{"wrong nonce", func(msg *MessageReportReq) {
+			msg.ObservationNonces[1][0] ^= 1
+		}},
+		{"missing nonce", func(msg *MessageReportReq) {
+			msg.ObservationNonces = msg.ObservationNonces[:2]
+		}},
+		{"observation without commitment", func(msg *MessageReportReq) {
+			msg.AttributedSignedCommitments = append(msg.AttributedSignedCommitments[:2], msg.AttributedSignedCommitments[3])
+		}},
+		{"invalid commitment signature", func(msg *MessageReportReq) {
+			msg.AttributedSignedCommitments[0].SignedCommitment.Signature[0] ^= 1
+		}},
+		{"duplicate commitment", func(msg *MessageReportReq) {
+			msg.AttributedSignedCommitments = append(msg.AttributedSignedCommitments, msg.AttributedSignedCommitments[0])
+		}},
+		{"commitment by oracle out of bounds", func(msg *MessageReportReq) {
+			msg.AttributedSignedCommitments[0].Observer = 4
+		}},
+		{"too few commitments", func(msg *MessageReportReq) {
+			msg.AttributedSignedCommitments = msg.AttributedSignedCommitments[:2]
+		}},
+		{"no commitments", func(msg *MessageReportReq) {
+			msg.AttributedSignedCommitments = nil
+		}},
+	}
+	for _, tc := range invalid {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			msg := fx.reportReqWithLateCommitment(t)
+			tc.modify(&msg)
+			if err := fx.repgen.verifyReportReq(msg); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+
+	t.Run("commitments don't extend the follower's commitments", func(t *testing.T) {
+		previous, err := fx.repgen.verifyCommitments(fx.reportReq(t, 0, 1, 2).AttributedSignedCommitments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fx.repgen.followerState.commitments = previous
+		defer func() { fx.repgen.followerState.commitments = nil }()
+		// fresh nonces, so the commitments differ from previous
+		if err := fx.repgen.verifyReportReq(fx.reportReq(t, 0, 1, 2)); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("nonces or commitments without commit-reveal", func(t *testing.T) {
+		fx := newCommitRevealFixture(t)
+		fx.repgen.config.CommitRevealObservations = false
+		msg := fx.reportReq(t, 0, 1, 2)
+		if err := fx.repgen.verifyReportReq(msg); err == nil {
+			t.Fatal("expected error")
+		}
+		msg.ObservationNonces = nil
+		if err := fx.repgen.verifyReportReq(msg); err == nil {
+			t.Fatal("expected error")
+		}
+		msg.AttributedSignedCommitments = nil
+		if err := fx.repgen.verifyReportReq(msg); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestExtendsCommitments(t *testing.T) {
+	a := &SignedObservationCommitment{ObservationCommitment{1}, []byte{1}}
+	b := &SignedObservationCommitment{ObservationCommitment{2}, []byte{2}}
+	c := &SignedObservationCommitment{ObservationCommitment{3}, []byte{3}}
+
+	for _, tc := range []struct {
+		name        string
+		commitments []*SignedObservationCommitment
+		previous    []*SignedObservationCommitment
+		extends     bool
+	}{
+		{"equal", []*SignedObservationCommitment{a, b, nil}, []*SignedObservationCommitment{a, b, nil}, true},
+		{"extension", []*SignedObservationCommitment{a, b, c}, []*SignedObservationCommitment{a, b, nil}, true},
+		{"dropped commitment", []*SignedObservationCommitment{a, nil, c}, []*SignedObservationCommitment{a, b, nil}, false},
+		{"replaced commitment", []*SignedObservationCommitment{a, c, nil}, []*SignedObservationCommitment{a, b, nil}, false},
+	} {
+		if extendsCommitments(tc.commitments, tc.previous) != tc.extends {
+			t.Errorf("%v: expected extendsCommitments to return %v", tc.name, tc.extends)
+		}
+	}
+}
+
+func TestMaxRevealReqsPerRound(t *testing.T) {
+	for _, tc := range []struct{ n, f, max int }{
+		{4, 1, 2},
+		{7, 2, 3},
+		{31, 10, 11},
+	} {
+		if max := MaxRevealReqsPerRound(tc.n, tc.f); max != tc.max {
+			t.Errorf("MaxRevealReqsPerRound(%v, %v) = %v, expected %v", tc.n, tc.f, max, tc.max)
+		}
+	}
+}
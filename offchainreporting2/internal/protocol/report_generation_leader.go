@@ -1,9 +1,11 @@
 package protocol
 
 import (
+	"bytes"
 	"context"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
@@ -18,15 +20,22 @@ type phase int
 const (
 	phaseObserve phase = iota
 	phaseGrace
+	// phaseReveal and phaseRevealGrace are only used if
+	// config.CommitRevealObservations is set. In that case, phaseObserve and
+	// phaseGrace collect commitments rather than observations.
+	phaseReveal
+	phaseRevealGrace
 	phaseReport
 	phaseFinal
 )
 
 var englishPhase = map[phase]string{
-	phaseObserve: "observe",
-	phaseGrace:   "grace",
-	phaseReport:  "report",
-	phaseFinal:   "final",
+	phaseObserve:     "observe",
+	phaseGrace:       "grace",
+	phaseReveal:      "reveal",
+	phaseRevealGrace: "reveal grace",
+	phaseReport:      "report",
+	phaseFinal:       "final",
 }
 
 func (repgen *reportGenerationState) leaderReportTimestamp() types.ReportTimestamp {
@@ -70,7 +79,12 @@ func (repgen *reportGenerationState) startRound() {
 	}
 	repgen.leaderState.r = rPlusOne
 	repgen.leaderState.observe = make([]*SignedObservation, repgen.config.N())
-	repgen.leaderState.report = make([]*AttestedReportOne, repgen.config.N())
+	repgen.leaderState.commit = make([]*SignedObservationCommitment, repgen.config.N())
+	repgen.leaderState.nonce = make([]ObservationNonce, repgen.config.N())
+	repgen.leaderState.fixedCommit = make([]*SignedObservationCommitment, repgen.config.N())
+	repgen.leaderState.revealReqs = 0
+	repgen.leaderState.report = make([][]AttestedReportOne, repgen.config.N())
+	repgen.leaderState.outcomeState = make([]*SignedOutcomeState, repgen.config.N())
 	repgen.leaderState.tRound = time.After(repgen.config.DeltaRound)
 	repgen.leaderState.readyToStartRound = false
 	var query types.Query
@@ -146,6 +160,14 @@ func (repgen *reportGenerationState) messageObserve(msg MessageObserve, sender c
 		return
 	}
 
+	if repgen.config.CommitRevealObservations {
+		repgen.logger.Warn("Got MessageObserve even though CommitRevealObservations is enabled", commontypes.LogFields{
+			"round":  repgen.leaderState.r,
+			"sender": sender,
+		})
+		return
+	}
+
 	if repgen.leaderState.phase != phaseObserve && repgen.leaderState.phase != phaseGrace {
 		repgen.logger.Debug("received MessageObserve after grace phase", commontypes.LogFields{
 			"round": repgen.leaderState.r,
@@ -211,19 +233,267 @@ func (repgen *reportGenerationState) messageObserve(msg MessageObserve, sender c
 	}
 }
 
+// messageObserveCommit is called when the current leader has received an
+// "observe-commit" message. It is the commit-reveal counterpart of
+// messageObserve: once the leader has more than 2f commitments, it kicks off
+// the grace period, after which it fixes the set of commitments and asks the
+// committed oracles to reveal their observations. Commitments that arrive
+// while the leader waits for reveals are added to the set later, see
+// eventTGraceTimeout.
+func (repgen *reportGenerationState) messageObserveCommit(msg MessageObserveCommit, sender commontypes.OracleID) {
+	dropPrefix := "messageObserveCommit: dropping MessageObserveCommit due to "
+	if msg.Epoch != repgen.e {
+		repgen.logger.Debug(dropPrefix+"wrong epoch",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender, "msgEpoch": msg.Epoch})
+		return
+	}
+	if repgen.l != repgen.id {
+		repgen.logger.Warn(dropPrefix+"not being leader of the current epoch",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender})
+		return
+	}
+	if msg.Round != repgen.leaderState.r {
+		repgen.logger.Debug(dropPrefix+"wrong round",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender, "msgRound": msg.Round})
+		return
+	}
+	if !repgen.config.CommitRevealObservations {
+		repgen.logger.Warn(dropPrefix+"CommitRevealObservations being disabled",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender})
+		return
+	}
+	if repgen.leaderState.phase != phaseObserve && repgen.leaderState.phase != phaseGrace && repgen.leaderState.phase != phaseReveal {
+		repgen.logger.Debug(dropPrefix+"being past reveal phase",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender})
+		return
+	}
+	if repgen.leaderState.commit[sender] != nil {
+		repgen.logger.Debug(dropPrefix+"having already received sender's commitment",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender})
+		return
+	}
+	if err := msg.SignedCommitment.Verify(repgen.leaderReportTimestamp(), repgen.config.OracleIdentities[sender].OffchainPublicKey); err != nil {
+		repgen.logger.Warn(dropPrefix+"invalid SignedObservationCommitment", commontypes.LogFields{
+			"round":  repgen.leaderState.r,
+			"sender": sender,
+			"msg":    msg,
+			"error":  err,
+		})
+		return
+	}
+
+	repgen.leaderState.commit[sender] = &msg.SignedCommitment
+
+	switch repgen.leaderState.phase {
+	case phaseObserve:
+		commitCount := 0
+		for _, soc := range repgen.leaderState.commit {
+			if soc != nil {
+				commitCount++
+			}
+		}
+		repgen.logger.Debug("One more observation commitment", commontypes.LogFields{
+			"round":               repgen.leaderState.r,
+			"commitCount":         commitCount,
+			"requiredCommitCount": (2 * repgen.config.F) + 1,
+		})
+		if commitCount > 2*repgen.config.F {
+			repgen.logger.Debug("starting commitment grace period", commontypes.LogFields{
+				"round": repgen.leaderState.r,
+			})
+			repgen.leaderState.tGrace = time.After(repgen.config.DeltaGrace)
+			repgen.leaderState.phase = phaseGrace
+		}
+	case phaseGrace:
+		repgen.logger.Debug("accepted extra observation commitment during grace period", nil)
+	case phaseReveal:
+		repgen.logger.Debug("accepted late observation commitment during reveal phase", nil)
+	}
+}
+
+// messageObserveReveal is called when the current leader has received an
+// "observe-reveal" message. The revealed observation must open a commitment
+// that was fixed by the leader's MessageRevealReq. Once the leader has more
+// than 2f revealed observations, it kicks off another grace period, after which
+// it sends out the report request.
+func (repgen *reportGenerationState) messageObserveReveal(msg MessageObserveReveal, sender commontypes.OracleID) {
+	dropPrefix := "messageObserveReveal: dropping MessageObserveReveal due to "
+	if msg.Epoch != repgen.e {
+		repgen.logger.Debug(dropPrefix+"wrong epoch",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender, "msgEpoch": msg.Epoch})
+		return
+	}
+	if repgen.l != repgen.id {
+		repgen.logger.Warn(dropPrefix+"not being leader of the current epoch",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender})
+		return
+	}
+	if msg.Round != repgen.leaderState.r {
+		repgen.logger.Debug(dropPrefix+"wrong round",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender, "msgRound": msg.Round})
+		return
+	}
+	if !repgen.config.CommitRevealObservations {
+		repgen.logger.Warn(dropPrefix+"CommitRevealObservations being disabled",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender})
+		return
+	}
+	if repgen.leaderState.phase != phaseReveal && repgen.leaderState.phase != phaseRevealGrace {
+		repgen.logger.Debug(dropPrefix+"not being in reveal or reveal grace phase",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender, "currentPhase": englishPhase[repgen.leaderState.phase]})
+		return
+	}
+	commitment := repgen.leaderState.fixedCommit[sender]
+	if commitment == nil {
+		repgen.logger.Warn(dropPrefix+"sender's commitment not being in MessageRevealReq",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender})
+		return
+	}
+	if repgen.leaderState.observe[sender] != nil {
+		repgen.logger.Debug(dropPrefix+"having already received sender's observation",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender})
+		return
+	}
+	if err := msg.SignedObservation.Verify(repgen.leaderReportTimestamp(), repgen.leaderState.q, repgen.config.OracleIdentities[sender].OffchainPublicKey); err != nil {
+		repgen.logger.Warn(dropPrefix+"invalid SignedObservation", commontypes.LogFields{
+			"round":  repgen.leaderState.r,
+			"sender": sender,
+			"msg":    msg,
+			"error":  err,
+		})
+		return
+	}
+	if err := commitment.VerifyReveal(repgen.leaderReportTimestamp(), repgen.leaderState.q, msg.SignedObservation, msg.Nonce); err != nil {
+		repgen.logger.Warn(dropPrefix+"observation not matching commitment", commontypes.LogFields{
+			"round":  repgen.leaderState.r,
+			"sender": sender,
+			"msg":    msg,
+			"error":  err,
+		})
+		return
+	}
+
+	repgen.leaderState.observe[sender] = &msg.SignedObservation
+	repgen.leaderState.nonce[sender] = msg.Nonce
+
+	switch repgen.leaderState.phase {
+	case phaseReveal:
+		revealCount := 0
+		for _, so := range repgen.leaderState.observe {
+			if so != nil {
+				revealCount++
+			}
+		}
+		repgen.logger.Debug("One more revealed observation", commontypes.LogFields{
+			"round":               repgen.leaderState.r,
+			"revealCount":         revealCount,
+			"requiredRevealCount": (2 * repgen.config.F) + 1,
+		})
+		if revealCount > 2*repgen.config.F {
+			repgen.logger.Debug("starting reveal grace period", commontypes.LogFields{
+				"round": repgen.leaderState.r,
+			})
+			repgen.leaderState.tGrace = time.After(repgen.config.DeltaGrace)
+			repgen.leaderState.phase = phaseRevealGrace
+		}
+	case phaseRevealGrace:
+		repgen.logger.Debug("accepted extra revealed observation during reveal grace period", nil)
+	}
+}
+
 // eventTGraceTimeout is called by the leader when the grace period
 // is over. It collates the signed observations it has received so far, and
 // sends out a request for participants' signatures on the final report.
+//
+// If config.CommitRevealObservations is set, the first grace period instead
+// ends the collection of commitments, and the leader asks the oracles to
+// reveal their observations. The report request is only sent after the second
+// grace period. While the leader waits for more than 2f reveals, the timer
+// fires every DeltaGrace, and the leader extends its MessageRevealReq by any
+// commitments that arrived in the meantime. Otherwise, oracles that commit but
+// don't reveal could stall the round.
 func (repgen *reportGenerationState) eventTGraceTimeout() {
-	if repgen.leaderState.phase != phaseGrace {
+	switch repgen.leaderState.phase {
+	case phaseGrace:
+		if repgen.config.CommitRevealObservations {
+			repgen.sendRevealReq()
+		} else {
+			repgen.sendReportReq()
+		}
+	case phaseReveal:
+		if repgen.leaderState.revealReqs >= MaxRevealReqsPerRound(repgen.config.N(), repgen.config.F) {
+			// every commitment has been included, there's nothing left to extend by
+			return
+		}
+		if countNonNil(repgen.leaderState.commit) > countNonNil(repgen.leaderState.fixedCommit) {
+			repgen.logger.Debug("not enough reveals, extending MessageRevealReq by late commitments", commontypes.LogFields{
+				"round": repgen.leaderState.r,
+			})
+			repgen.sendRevealReq()
+		} else {
+			repgen.leaderState.tGrace = time.After(repgen.config.DeltaGrace)
+		}
+	case phaseRevealGrace:
+		repgen.sendReportReq()
+	default:
 		repgen.logger.Error("leader's phase conflicts tGrace timeout", commontypes.LogFields{
 			"round": repgen.leaderState.r,
 			"phase": englishPhase[repgen.leaderState.phase],
 		})
-		return
 	}
+}
+
+func (repgen *reportGenerationState) sendRevealReq() {
+	repgen.netSender.Broadcast(MessageRevealReq{
+		repgen.e,
+		repgen.leaderState.r,
+		attributedSignedCommitments(repgen.leaderState.commit),
+	})
+
+	copy(repgen.leaderState.fixedCommit, repgen.leaderState.commit)
+	repgen.leaderState.revealReqs++
+	repgen.leaderState.tGrace = time.After(repgen.config.DeltaGrace)
+	repgen.leaderState.phase = phaseReveal
+}
+
+// MaxRevealReqsPerRound bounds the number of MessageRevealReqs a leader sends
+// per round: The first one contains more than 2f commitments, and each
+// extension adds at least one more, up to n.
+func MaxRevealReqsPerRound(n int, f int) int {
+	return n - 2*f
+}
+
+func attributedSignedCommitments(commitments []*SignedObservationCommitment) []AttributedSignedObservationCommitment {
+	asocs := []AttributedSignedObservationCommitment{}
+	for oid, soc := range commitments {
+		if soc != nil {
+			asocs = append(asocs, AttributedSignedObservationCommitment{
+				*soc,
+				commontypes.OracleID(oid),
+			})
+		}
+	}
+	return asocs
+}
+
+func countNonNil[T any](ptrs []*T) int {
+	count := 0
+	for _, ptr := range ptrs {
+		if ptr != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func (repgen *reportGenerationState) sendReportReq() {
 	asos := []AttributedSignedObservation{}
 	aos := []types.AttributedObservation{}
+	var nonces []ObservationNonce
+	var asocs []AttributedSignedObservationCommitment
+	if repgen.config.CommitRevealObservations {
+		asocs = attributedSignedCommitments(repgen.leaderState.fixedCommit)
+	}
 	for oid, so := range repgen.leaderState.observe {
 		if so != nil {
 			asos = append(asos, AttributedSignedObservation{
@@ -234,6 +504,9 @@ func (repgen *reportGenerationState) eventTGraceTimeout() {
 				so.Observation,
 				commontypes.OracleID(oid),
 			})
+			if repgen.config.CommitRevealObservations {
+				nonces = append(nonces, repgen.leaderState.nonce[oid])
+			}
 		}
 	}
 
@@ -242,6 +515,8 @@ func (repgen *reportGenerationState) eventTGraceTimeout() {
 		repgen.leaderState.r,
 		repgen.leaderState.q,
 		asos,
+		nonces,
+		asocs,
 	})
 
 	repgen.leaderState.h = reportContextHash(repgen.leaderState.q, aos)
@@ -277,15 +552,7 @@ func (repgen *reportGenerationState) messageReport(msg MessageReport, sender com
 		return
 	}
 
-	err := msg.AttestedReport.Verify(
-		repgen.onchainKeyring,
-		repgen.config.OracleIdentities[sender].OnchainPublicKey,
-		types.ReportContext{
-			repgen.leaderReportTimestamp(),
-			repgen.leaderState.h,
-		},
-	)
-	if err != nil {
+	if err := repgen.verifyAttestedReports(msg.AttestedReports, sender); err != nil {
 		repgen.logger.Error("could not validate signature", commontypes.LogFields{
 			"round": repgen.leaderState.r,
 			"error": err,
@@ -294,43 +561,145 @@ func (repgen *reportGenerationState) messageReport(msg MessageReport, sender com
 		return
 	}
 
-	repgen.leaderState.report[sender] = &msg.AttestedReport
+	if repgen.outcomeStateStore != nil {
+		err := msg.SignedOutcomeState.Verify(
+			repgen.leaderReportTimestamp(),
+			repgen.config.OracleIdentities[sender].OffchainPublicKey,
+		)
+		if err != nil {
+			repgen.logger.Error("could not validate outcome state signature", commontypes.LogFields{
+				"round": repgen.leaderState.r,
+				"error": err,
+				"msg":   msg,
+			})
+			return
+		}
+	} else if !msg.SignedOutcomeState.IsEmpty() {
+		repgen.logger.Warn(dropPrefix+"outcome state even though outcome states are disabled",
+			commontypes.LogFields{"round": repgen.leaderState.r, "sender": sender})
+		return
+	}
+
+	repgen.leaderState.report[sender] = msg.AttestedReports
+	repgen.leaderState.outcomeState[sender] = &msg.SignedOutcomeState
 
 	// upon exists R s.t. |{p_j ∈ P | report[j]=(R,·)}| > f ∧ phase = REPORT
+	//
+	// With outcome states, R also includes the outcome state and we need more
+	// than (n+f)/2 matching messages, see outcome_state.go. With multiple
+	// reports per round, R is the list of all reports.
 	{ // FUTUREWORK: make it non-quadratic time
-		ass := []types.AttributedOnchainSignature{}
-		for id, report := range repgen.leaderState.report {
-			if report == nil {
+		asss := make([][]types.AttributedOnchainSignature, len(msg.AttestedReports))
+		aoss := []AttributedOutcomeSignature{}
+		for id, reports := range repgen.leaderState.report {
+			if reports == nil {
+				continue
+			}
+			outcomeState := repgen.leaderState.outcomeState[id]
+			if !bytes.Equal(outcomeState.OutcomeState, msg.SignedOutcomeState.OutcomeState) {
+				repgen.logger.Warn("received disparate outcome states", commontypes.LogFields{
+					"round":  repgen.leaderState.r,
+					"oracle": id,
+					"sender": sender,
+				})
 				continue
 			}
-			if report.EqualExceptSignature(msg.AttestedReport) {
-				ass = append(ass, types.AttributedOnchainSignature{
-					report.Signature,
+			if attestedReportsEqualExceptSignature(reports, msg.AttestedReports) {
+				for i, report := range reports {
+					asss[i] = append(asss[i], types.AttributedOnchainSignature{
+						report.Signature,
+						commontypes.OracleID(id),
+					})
+				}
+				aoss = append(aoss, AttributedOutcomeSignature{
+					outcomeState.Signature,
 					commontypes.OracleID(id),
 				})
-			} else if !report.Skip && !msg.AttestedReport.Skip { // oracles may commonly disagree on whether to skip, no need to warn about that
+			} else if !isSkip(reports) && !isSkip(msg.AttestedReports) { // oracles may commonly disagree on whether to skip, no need to warn about that
 				repgen.logger.Warn("received disparate reports messages", commontypes.LogFields{
-					"round":          repgen.leaderState.r,
-					"previousReport": report,
-					"msgReport":      msg,
+					"round":           repgen.leaderState.r,
+					"previousReports": reports,
+					"msgReports":      msg,
 				})
 			}
 		}
 
-		if repgen.reportQuorum <= len(ass) {
-			if !msg.AttestedReport.Skip {
-				repgen.netSender.Broadcast(MessageFinal{
-					repgen.e,
-					repgen.leaderState.r,
-					repgen.leaderState.h,
-					AttestedReportMany{
-						msg.AttestedReport.Report,
-						ass,
-					},
-				})
+		// All entries of asss have the same length, as do those of aoss.
+		matching := len(asss[0])
+
+		if repgen.outcomeStateStore == nil {
+			if repgen.reportQuorum <= matching {
+				if !isSkip(msg.AttestedReports) {
+					repgen.netSender.Broadcast(MessageFinal{
+						repgen.e,
+						repgen.leaderState.r,
+						repgen.leaderState.h,
+						repgen.attestedReportsMany(msg.AttestedReports, asss),
+						CertifiedOutcomeState{},
+					})
+				}
+				repgen.leaderState.phase = phaseFinal
+				repgen.startRound()
+			}
+		} else if outcomeQuorum := outcomeStateQuorum(repgen.config.N(), repgen.config.F); outcomeQuorum <= matching {
+			// outcomeQuorum >= reportQuorum, so we also have enough report
+			// signatures
+			var attestedReports []AttestedReportMany
+			if !isSkip(msg.AttestedReports) {
+				attestedReports = repgen.attestedReportsMany(msg.AttestedReports, asss)
 			}
+			repgen.netSender.Broadcast(MessageFinal{
+				repgen.e,
+				repgen.leaderState.r,
+				repgen.leaderState.h,
+				attestedReports,
+				CertifiedOutcomeState{
+					msg.SignedOutcomeState.OutcomeState,
+					aoss[:outcomeQuorum],
+				},
+			})
 			repgen.leaderState.phase = phaseFinal
 			repgen.startRound()
 		}
 	}
 }
+
+// attestedReportsMany combines reports with the signatures in asss. It
+// includes exactly reportQuorum signatures per report, as expected by
+// AttestedReportMany.VerifySignatures.
+func (repgen *reportGenerationState) attestedReportsMany(reports []AttestedReportOne, asss [][]types.AttributedOnchainSignature) []AttestedReportMany {
+	attestedReports := make([]AttestedReportMany, 0, len(reports))
+	for i, report := range reports {
+		attestedReports = append(attestedReports, AttestedReportMany{
+			report.Report,
+			asss[i][:repgen.reportQuorum],
+		})
+	}
+	return attestedReports
+}
+
+// verifyAttestedReports errors unless reports is a single skip or a list of
+// validly signed reports. Lists of more than one report are only accepted if
+// the ReportingPlugin implements MultiReportReportingPlugin.
+func (repgen *reportGenerationState) verifyAttestedReports(reports []AttestedReportOne, sender commontypes.OracleID) error {
+	maxReports := 1
+	if _, ok := repgen.reportingPlugin.(types.MultiReportReportingPlugin); ok {
+		maxReports = types.MaxReportsPerRound
+	}
+	if !(1 <= len(reports) && len(reports) <= maxReports) {
+		return errors.Errorf("expected between 1 and %v reports, got %v", maxReports, len(reports))
+	}
+	for i, report := range reports {
+		if report.Skip && len(reports) != 1 {
+			return errors.Errorf("skip must be the only report, but got %v reports", len(reports))
+		}
+		if err := report.Verify(
+			repgen.onchainKeyring,
+			repgen.config.OracleIdentities[sender].OnchainPublicKey,
+			makeReportContext(repgen.leaderReportTimestamp(), repgen.leaderState.h, uint8(i)),
+		); err != nil {
+			return errors.Errorf("report %v: %s", i, err)
+		}
+	}
+	return nil
+}
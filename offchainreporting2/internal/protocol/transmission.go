@@ -22,6 +22,20 @@ const chPersistCapacityTransmission = 16
 // TransmissionProtocol tracks the local oracle process's role in the transmission of a
 // report to the on-chain oracle contract.
 //
+// Each accepted report is scheduled for transmission to every target in
+// transmissionTargets, the first of which is the primary target. Targets are
+// scheduled and filtered independently.
+//
+// If a target's ContractTransmitter implements types.TransmissionResultReporter,
+// reports whose transmission to the target failed are re-queued for the start
+// of the next stage of the transmission schedule, up to
+// LocalConfig.MaxRetransmissions times. They are dropped once the schedule has
+// ended or a newer report has been accepted.
+//
+// Once chRetire is closed, no new reports are accepted for transmission and
+// chDrained is closed as soon as all pending transmissions have been worked
+// off, including those whose result hasn't been reported yet.
+//
 // Note: The transmission protocol doesn't clean up pending transmissions
 // when it is terminated. This is by design, but means that old pending
 // transmissions may accumulate in the database. They should be garbage
@@ -32,12 +46,17 @@ func RunTransmission(
 
 	config config.SharedConfig,
 	chReportFinalizationToTransmission <-chan EventToTransmission,
+	chRetire <-chan struct{},
+	chDrained chan<- struct{},
+	chTransmissionAdmin <-chan TransmissionAdminRequest,
 	database types.Database,
+	dryRunRecorder types.TransmissionRecorder,
 	id commontypes.OracleID,
-	localConfig types.LocalConfig,
+	localConfig *config.LocalConfigHolder,
 	logger loghelper.LoggerWithContext,
 	reportingPlugin types.ReportingPlugin,
-	transmitter types.ContractTransmitter,
+	transmissionScheduler types.TransmissionScheduler,
+	transmissionTargets []types.TransmissionTarget,
 ) {
 	t := transmissionState{
 		ctx:          ctx,
@@ -45,12 +64,17 @@ func RunTransmission(
 
 		config:                             config,
 		chReportFinalizationToTransmission: chReportFinalizationToTransmission,
+		chRetire:                           chRetire,
+		chDrained:                          chDrained,
+		chTransmissionAdmin:                chTransmissionAdmin,
 		database:                           database,
+		dryRunRecorder:                     dryRunRecorder,
 		id:                                 id,
 		localConfig:                        localConfig,
 		logger:                             logger,
 		reportingPlugin:                    reportingPlugin,
-		transmitter:                        transmitter,
+		transmissionScheduler:              transmissionScheduler,
+		transmissionTargets:                transmissionTargets,
 	}
 	t.run()
 }
@@ -61,21 +85,45 @@ type transmissionState struct {
 
 	config                             config.SharedConfig
 	chReportFinalizationToTransmission <-chan EventToTransmission
+	chRetire                           <-chan struct{}
+	chDrained                          chan<- struct{}
+	chTransmissionAdmin                <-chan TransmissionAdminRequest
 	database                           types.Database
+	dryRunRecorder                     types.TransmissionRecorder
 	id                                 commontypes.OracleID
-	localConfig                        types.LocalConfig
+	localConfig                        *config.LocalConfigHolder
 	logger                             loghelper.LoggerWithContext
 	reportingPlugin                    types.ReportingPlugin
-	transmitter                        types.ContractTransmitter
+	transmissionScheduler              types.TransmissionScheduler
+	transmissionTargets                []types.TransmissionTarget
 
 	chPersist chan<- persist.TransmissionDBUpdate
 	times     MinHeapTimeToPendingTransmission
 	tTransmit <-chan time.Time
+
+	// latestAccepted contains the (epoch, round) of the newest report
+	// accepted for each target
+	latestAccepted map[string]EpochRound
+	// unconfirmed contains transmissions whose result hasn't been reported
+	// yet, for targets whose ContractTransmitter is a
+	// types.TransmissionResultReporter. They are persisted with
+	// AwaitingResult set, so that they survive restarts.
+	unconfirmed map[types.PendingTransmissionKey]types.PendingTransmission
+	// set once chRetire has been closed
+	retiring bool
+	// set once chDrained has been closed
+	drained bool
+}
+
+type transmissionResultWithTarget struct {
+	target string
+	result types.TransmissionResult
 }
 
 // run runs the event loop for the local transmission protocol
 func (t *transmissionState) run() {
-	t.restoreFromDatabase()
+	t.latestAccepted = map[string]EpochRound{}
+	t.unconfirmed = map[types.PendingTransmissionKey]types.PendingTransmission{}
 
 	chPersist := make(chan persist.TransmissionDBUpdate, chPersistCapacityTransmission)
 	t.chPersist = chPersist
@@ -84,11 +132,26 @@ func (t *transmissionState) run() {
 			t.ctx,
 			chPersist,
 			t.database,
-			t.localConfig.DatabaseTimeout,
+			t.localConfig,
 			t.logger,
 		)
 	})
 
+	t.restoreFromDatabase()
+
+	chResults := make(chan transmissionResultWithTarget)
+	for _, target := range t.transmissionTargets {
+		reporter, ok := target.ContractTransmitter.(types.TransmissionResultReporter)
+		if !ok {
+			continue
+		}
+		name := target.Name
+		chTargetResults := reporter.TransmissionResults()
+		t.subprocesses.Go(func() {
+			forwardTransmissionResults(t.ctx, chTargetResults, name, chResults)
+		})
+	}
+
 	chDone := t.ctx.Done()
 	for {
 		select {
@@ -96,9 +159,17 @@ func (t *transmissionState) run() {
 			ev.processTransmission(t)
 		case <-t.tTransmit:
 			t.eventTTransmitTimeout()
+		case result := <-chResults:
+			t.eventTransmissionResult(result.target, result.result)
+		case req := <-t.chTransmissionAdmin:
+			req.processTransmissionAdmin(t)
+		case <-t.chRetire:
+			t.eventRetire()
 		case <-chDone:
 		}
 
+		t.checkDrained()
+
 		// ensure prompt exit
 		select {
 		case <-chDone:
@@ -110,7 +181,7 @@ func (t *transmissionState) run() {
 }
 
 func (t *transmissionState) restoreFromDatabase() {
-	childCtx, childCancel := context.WithTimeout(t.ctx, t.localConfig.DatabaseTimeout)
+	childCtx, childCancel := context.WithTimeout(t.ctx, t.localConfig.Get().DatabaseTimeout)
 	defer childCancel()
 	pending, err := t.database.PendingTransmissionsWithConfigDigest(childCtx, t.config.ConfigDigest)
 	if err != nil {
@@ -120,9 +191,20 @@ func (t *transmissionState) restoreFromDatabase() {
 
 	now := time.Now()
 
-	// insert non-expired transmissions into queue
+	for key := range pending {
+		t.updateLatestAccepted(key.Target, EpochRound{key.Epoch, key.Round})
+	}
+
+	// insert non-expired transmissions into queue, and keep waiting for the
+	// results of transmissions of the newest reports
 	for key, trans := range pending {
-		if now.Before(trans.Time) {
+		if trans.AwaitingResult {
+			if (EpochRound{key.Epoch, key.Round}) == t.latestAccepted[key.Target] {
+				t.unconfirmed[key] = trans
+			} else {
+				t.forgetUnconfirmed(key)
+			}
+		} else if now.Before(trans.Time) {
 			t.times.Push(MinHeapTimeToPendingTransmissionItem{
 				key,
 				trans,
@@ -137,12 +219,46 @@ func (t *transmissionState) restoreFromDatabase() {
 	}
 }
 
+// eventRetire is called once the instance's successor has started
+func (t *transmissionState) eventRetire() {
+	t.logger.Info("Transmission: successor has started, draining pending transmissions", commontypes.LogFields{
+		"queued":      t.times.Len(),
+		"unconfirmed": len(t.unconfirmed),
+	})
+	t.retiring = true
+	// a closed channel is always ready, stop selecting on it
+	t.chRetire = nil
+}
+
+// checkDrained closes chDrained once a retiring instance has no pending
+// transmissions left
+func (t *transmissionState) checkDrained() {
+	if !t.retiring || t.drained {
+		return
+	}
+	if t.times.Len() != 0 || len(t.unconfirmed) != 0 {
+		return
+	}
+	t.logger.Info("Transmission: drained pending transmissions", nil)
+	t.drained = true
+	close(t.chDrained)
+}
+
 // eventTransmit is called when the local process sends a transmit event
 func (t *transmissionState) eventTransmit(ev EventTransmit) {
 	t.logger.Debug("Received transmit event", commontypes.LogFields{
 		"event": ev,
 	})
 
+	if t.retiring {
+		t.logger.Info("eventTransmit(ev): successor has started, not accepting report", commontypes.LogFields{
+			"epoch":       ev.Epoch,
+			"round":       ev.Round,
+			"reportIndex": ev.ReportIndex,
+		})
+		return
+	}
+
 	ts := types.ReportTimestamp{t.config.ConfigDigest, ev.Epoch, ev.Round}
 
 	{
@@ -183,30 +299,45 @@ func (t *transmissionState) eventTransmit(ev EventTransmit) {
 	}
 
 	now := time.Now()
-	delayMaybe := t.transmitDelay(ev.Epoch, ev.Round)
-	if delayMaybe == nil {
-		return
-	}
-	delay := *delayMaybe
+	repctx := types.ReportContext{ts, ev.H, ev.ReportIndex}
 
-	transmission := types.PendingTransmission{
-		now.Add(delay),
-		ev.H,
-		ev.AttestedReport.Report,
-		ev.AttestedReport.AttributedSignatures,
-	}
+	for _, target := range t.transmissionTargets {
+		t.updateLatestAccepted(target.Name, EpochRound{ev.Epoch, ev.Round})
 
-	select {
-	case t.chPersist <- persist.TransmissionDBUpdate{ts, &transmission}:
-	default:
-		t.logger.Warn("eventTransmit: chPersist is overflowing", nil)
-	}
+		delay, ok := t.transmitDelay(repctx, target.Name)
+		if !ok {
+			continue
+		}
 
-	t.times.Push(MinHeapTimeToPendingTransmissionItem{ts, transmission})
+		key := types.PendingTransmissionKey{ts, ev.ReportIndex, target.Name}
+		transmission := types.PendingTransmission{
+			now.Add(delay),
+			ev.H,
+			ev.AttestedReport.Report,
+			ev.AttestedReport.AttributedSignatures,
+			now,
+			0,
+			false,
+		}
 
-	next := t.times.Peek()
-	if (EpochRound{ev.Epoch, ev.Round}) == (EpochRound{next.Epoch, next.Round}) {
-		t.tTransmit = time.After(delay)
+		select {
+		case t.chPersist <- persist.TransmissionDBUpdate{key, &transmission}:
+		default:
+			t.logger.Warn("eventTransmit: chPersist is overflowing", commontypes.LogFields{
+				"target": target.Name,
+			})
+		}
+
+		t.times.Push(MinHeapTimeToPendingTransmissionItem{key, transmission})
+
+		if t.dryRunRecorder != nil {
+			t.record(types.TransmissionStageAccepted, target.Name, repctx, transmission)
+		}
+	}
+
+	if t.times.Len() != 0 {
+		next := t.times.Peek()
+		t.tTransmit = time.After(time.Until(next.Time))
 	}
 }
 
@@ -224,17 +355,35 @@ func (t *transmissionState) eventTTransmitTimeout() {
 	}
 	item := t.times.Pop()
 
-	select {
-	case t.chPersist <- persist.TransmissionDBUpdate{
-		types.ReportTimestamp{
-			t.config.ConfigDigest,
-			item.Epoch,
-			item.Round,
-		},
-		nil,
-	}:
-	default:
-		t.logger.Warn("eventTTransmitTimeout: chPersist is overflowing", nil)
+	target, ok := t.transmissionTarget(item.Target)
+	if !ok {
+		// can happen if the pending transmission was restored from the
+		// database after the target was removed
+		t.logger.Warn("eventTTransmitTimeout: dropping pending transmission for unknown target", commontypes.LogFields{
+			"target": item.Target,
+			"epoch":  item.Epoch,
+			"round":  item.Round,
+		})
+	}
+
+	// the transmission is no longer pending, unless we await its result
+	awaitingResult := false
+	defer func() {
+		if awaitingResult {
+			return
+		}
+		select {
+		case t.chPersist <- persist.TransmissionDBUpdate{
+			item.PendingTransmissionKey,
+			nil,
+		}:
+		default:
+			t.logger.Warn("eventTTransmitTimeout: chPersist is overflowing", nil)
+		}
+	}()
+
+	if !ok {
+		return
 	}
 
 	{
@@ -253,7 +402,13 @@ func (t *transmissionState) eventTTransmitTimeout() {
 			},
 		)
 
-		shouldTransmit, err := t.reportingPlugin.ShouldTransmitAcceptedReport(
+		// Only the primary target lacks a TransmissionFilter, since the
+		// plugin's filter checks the primary contract.
+		var filter types.TransmissionFilter = t.reportingPlugin
+		if target.TransmissionFilter != nil {
+			filter = target.TransmissionFilter
+		}
+		shouldTransmit, err := filter.ShouldTransmitAcceptedReport(
 			ctx,
 			item.ReportTimestamp,
 			item.Report,
@@ -262,42 +417,65 @@ func (t *transmissionState) eventTTransmitTimeout() {
 		ins.Stop()
 
 		if err != nil {
-			t.logger.Error("eventTTransmitTimeout: ReportingPlugin.ShouldTransmitAcceptedReport error", commontypes.LogFields{"error": err})
+			t.logger.Error("eventTTransmitTimeout: ShouldTransmitAcceptedReport error", commontypes.LogFields{
+				"error":  err,
+				"target": target.Name,
+			})
 			return
 		}
 
 		if !shouldTransmit {
-			t.logger.Info("eventTTransmitTimeout: ReportingPlugin.ShouldTransmitAcceptedReport returned false", nil)
+			t.logger.Info("eventTTransmitTimeout: ShouldTransmitAcceptedReport returned false", commontypes.LogFields{
+				"target": target.Name,
+			})
+			if t.dryRunRecorder != nil {
+				t.record(types.TransmissionStageSkipped, target.Name, types.ReportContext{item.ReportTimestamp, item.ExtraHash, item.ReportIndex}, item.PendingTransmission)
+			}
 			return
 		}
 	}
 
+	if t.dryRunRecorder != nil {
+		t.logger.Info("eventTTransmitTimeout: dry run, recording report instead of transmitting", commontypes.LogFields{
+			"epoch":       item.Epoch,
+			"round":       item.Round,
+			"reportIndex": item.ReportIndex,
+			"target":      target.Name,
+		})
+		t.record(types.TransmissionStageTransmit, target.Name, types.ReportContext{item.ReportTimestamp, item.ExtraHash, item.ReportIndex}, item.PendingTransmission)
+		return
+	}
+
 	t.logger.Info("eventTTransmitTimeout: Transmitting", commontypes.LogFields{
-		"epoch": item.Epoch,
-		"round": item.Round,
+		"epoch":       item.Epoch,
+		"round":       item.Round,
+		"reportIndex": item.ReportIndex,
+		"target":      target.Name,
 	})
 
 	{
+		transmitTimeout := t.localConfig.Get().ContractTransmitterTransmitTimeout
 		ctx, cancel := context.WithTimeout(
 			t.ctx,
-			t.localConfig.ContractTransmitterTransmitTimeout,
+			transmitTimeout,
 		)
 		defer cancel()
 
 		ins := loghelper.NewIfNotStopped(
-			t.localConfig.ContractTransmitterTransmitTimeout+ContractTransmitterTimeoutWarningGracePeriod,
+			transmitTimeout+ContractTransmitterTimeoutWarningGracePeriod,
 			func() {
 				t.logger.Error("Transmission: ContractTransmitter.Transmit is taking too long", commontypes.LogFields{
-					"item": item, "maxDuration": t.localConfig.ContractTransmitterTransmitTimeout,
+					"item": item, "maxDuration": transmitTimeout,
 				})
 			},
 		)
 
-		err := t.transmitter.Transmit(
+		err := target.ContractTransmitter.Transmit(
 			ctx,
 			types.ReportContext{
 				item.ReportTimestamp,
 				item.ExtraHash,
+				item.ReportIndex,
 			},
 			item.Report,
 			item.AttributedSignatures,
@@ -306,19 +484,184 @@ func (t *transmissionState) eventTTransmitTimeout() {
 		ins.Stop()
 
 		if err != nil {
-			t.logger.Error("eventTTransmitTimeout: ContractTransmitter.Transmit error", commontypes.LogFields{"error": err})
+			t.logger.Error("eventTTransmitTimeout: ContractTransmitter.Transmit error", commontypes.LogFields{
+				"error":  err,
+				"target": target.Name,
+			})
 			return
 		}
 
 	}
 
+	if _, ok := target.ContractTransmitter.(types.TransmissionResultReporter); ok {
+		transmission := item.PendingTransmission
+		transmission.AwaitingResult = true
+		t.unconfirmed[item.PendingTransmissionKey] = transmission
+		awaitingResult = true
+
+		select {
+		case t.chPersist <- persist.TransmissionDBUpdate{item.PendingTransmissionKey, &transmission}:
+		default:
+			t.logger.Warn("eventTTransmitTimeout: chPersist is overflowing", nil)
+		}
+	}
+
 	t.logger.Info("eventTTransmitTimeout:❗️successfully transmitted report on-chain", commontypes.LogFields{
-		"epoch": item.Epoch,
-		"round": item.Round,
+		"epoch":       item.Epoch,
+		"round":       item.Round,
+		"reportIndex": item.ReportIndex,
+		"target":      target.Name,
 	})
 }
 
-func (t *transmissionState) transmitDelay(epoch uint32, round uint8) *time.Duration {
+// updateLatestAccepted records that a report from epochRound has been accepted
+// for target and forgets about unconfirmed transmissions of older reports,
+// which won't be retransmitted anymore.
+func (t *transmissionState) updateLatestAccepted(target string, epochRound EpochRound) {
+	if !t.latestAccepted[target].Less(epochRound) {
+		return
+	}
+	t.latestAccepted[target] = epochRound
+	for key := range t.unconfirmed {
+		if key.Target == target && (EpochRound{key.Epoch, key.Round}).Less(epochRound) {
+			t.forgetUnconfirmed(key)
+		}
+	}
+}
+
+// forgetUnconfirmed stops waiting for the result of a transmission and deletes
+// it from the database
+func (t *transmissionState) forgetUnconfirmed(key types.PendingTransmissionKey) {
+	delete(t.unconfirmed, key)
+	select {
+	case t.chPersist <- persist.TransmissionDBUpdate{key, nil}:
+	default:
+		t.logger.Warn("forgetUnconfirmed: chPersist is overflowing", nil)
+	}
+}
+
+// eventTransmissionResult is called when a target's ContractTransmitter
+// reports the result of a transmission
+func (t *transmissionState) eventTransmissionResult(target string, result types.TransmissionResult) {
+	key := types.PendingTransmissionKey{
+		result.ReportContext.ReportTimestamp,
+		result.ReportContext.ReportIndex,
+		target,
+	}
+	logFields := commontypes.LogFields{
+		"target":      target,
+		"epoch":       key.Epoch,
+		"round":       key.Round,
+		"reportIndex": key.ReportIndex,
+	}
+
+	unconfirmed, ok := t.unconfirmed[key]
+	if !ok {
+		t.logger.Debug("eventTransmissionResult: ignoring result for unknown transmission", logFields)
+		return
+	}
+
+	if result.Confirmed {
+		t.forgetUnconfirmed(key)
+		t.logger.Info("eventTransmissionResult: transmission confirmed", logFields)
+		return
+	}
+
+	if (EpochRound{key.Epoch, key.Round}) != t.latestAccepted[target] {
+		t.forgetUnconfirmed(key)
+		t.logger.Info("eventTransmissionResult: transmission failed, but a newer report has been accepted. Not retransmitting", logFields)
+		return
+	}
+
+	if unconfirmed.Retransmissions >= t.localConfig.Get().MaxRetransmissions {
+		t.forgetUnconfirmed(key)
+		t.logger.Warn("eventTransmissionResult: transmission failed, giving up after too many retransmissions", logFields)
+		return
+	}
+
+	retransmitTime, ok := t.retransmitTime(unconfirmed.AcceptedTime, time.Now())
+	if !ok {
+		t.forgetUnconfirmed(key)
+		t.logger.Warn("eventTransmissionResult: transmission failed, but the transmission schedule has ended. Not retransmitting", logFields)
+		return
+	}
+
+	t.logger.Warn("eventTransmissionResult: transmission failed, re-queueing report", commontypes.LogFields{
+		"target":         target,
+		"epoch":          key.Epoch,
+		"round":          key.Round,
+		"reportIndex":    key.ReportIndex,
+		"retransmitTime": retransmitTime,
+	})
+
+	delete(t.unconfirmed, key)
+	transmission := unconfirmed
+	transmission.Time = retransmitTime
+	transmission.Retransmissions++
+	transmission.AwaitingResult = false
+
+	select {
+	case t.chPersist <- persist.TransmissionDBUpdate{key, &transmission}:
+	default:
+		t.logger.Warn("eventTransmissionResult: chPersist is overflowing", nil)
+	}
+
+	t.times.Push(MinHeapTimeToPendingTransmissionItem{key, transmission})
+
+	next := t.times.Peek()
+	t.tTransmit = time.After(time.Until(next.Time))
+}
+
+// retransmitTime returns the start of the first stage of the transmission
+// schedule for a report accepted at accepted that begins after now, which gives
+// oracles in the next stage a chance to transmit before we retry. It returns
+// false if the schedule has ended by then.
+func (t *transmissionState) retransmitTime(accepted time.Time, now time.Time) (time.Time, bool) {
+	if accepted.IsZero() || t.config.DeltaStage <= 0 {
+		return time.Time{}, false
+	}
+	stage := 1
+	if now.After(accepted) {
+		stage = int(now.Sub(accepted)/t.config.DeltaStage) + 1
+	}
+	if stage >= len(t.config.S) {
+		return time.Time{}, false
+	}
+	return accepted.Add(time.Duration(stage) * t.config.DeltaStage), true
+}
+
+func forwardTransmissionResults(
+	ctx context.Context,
+	chIn <-chan types.TransmissionResult,
+	target string,
+	chOut chan<- transmissionResultWithTarget,
+) {
+	for {
+		select {
+		case result := <-chIn:
+			select {
+			case chOut <- transmissionResultWithTarget{target, result}:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (t *transmissionState) transmissionTarget(name string) (types.TransmissionTarget, bool) {
+	for _, target := range t.transmissionTargets {
+		if target.Name == name {
+			return target, true
+		}
+	}
+	return types.TransmissionTarget{}, false
+}
+
+// transmitDelay consults the TransmissionScheduler. It returns false if the
+// report shouldn't be transmitted to target.
+func (t *transmissionState) transmitDelay(repctx types.ReportContext, target string) (time.Duration, bool) {
 	// No need for HMAC. Since we use Keccak256, prepending
 	// with key gives us a PRF already.
 	hash := sha3.NewLegacyKeccak256()
@@ -326,22 +669,85 @@ func (t *transmissionState) transmitDelay(epoch uint32, round uint8) *time.Durat
 	hash.Write(transmissionOrderKey[:])
 	hash.Write(t.config.ConfigDigest[:])
 	temp := make([]byte, 8)
-	binary.LittleEndian.PutUint64(temp, uint64(epoch))
+	binary.LittleEndian.PutUint64(temp, uint64(repctx.Epoch))
 	hash.Write(temp)
-	binary.LittleEndian.PutUint64(temp, uint64(round))
+	binary.LittleEndian.PutUint64(temp, uint64(repctx.Round))
 	hash.Write(temp)
 
 	var key [16]byte
 	copy(key[:], hash.Sum(nil))
 	pi := permutation.Permutation(t.config.N(), key)
 
-	sum := 0
-	for i, s := range t.config.S {
-		sum += s
-		if pi[t.id] < sum {
-			result := time.Duration(i) * t.config.DeltaStage
-			return &result
-		}
+	transmitAccounts := make([]types.Account, 0, t.config.N())
+	for _, identity := range t.config.OracleIdentities {
+		transmitAccounts = append(transmitAccounts, identity.TransmitAccount)
+	}
+
+	ctx, cancel := context.WithTimeout(t.ctx, t.localConfig.Get().BlockchainTimeout)
+	defer cancel()
+	delay, transmit, err := t.transmissionScheduler.Schedule(ctx, types.TransmissionScheduleContext{
+		repctx,
+		target,
+		t.id,
+		transmitAccounts,
+		t.config.S,
+		t.config.DeltaStage,
+		t.config.RMax,
+		pi,
+	})
+	if err != nil {
+		t.logger.ErrorIfNotCanceled("Transmission: error in TransmissionScheduler.Schedule", ctx, commontypes.LogFields{
+			"error":       err,
+			"target":      target,
+			"epoch":       repctx.Epoch,
+			"round":       repctx.Round,
+			"reportIndex": repctx.ReportIndex,
+		})
+		return 0, false
+	}
+	if !transmit {
+		t.logger.Debug("Transmission: TransmissionScheduler.Schedule says not to transmit", commontypes.LogFields{
+			"target":      target,
+			"epoch":       repctx.Epoch,
+			"round":       repctx.Round,
+			"reportIndex": repctx.ReportIndex,
+		})
+		return 0, false
+	}
+	return delay, true
+}
+
+// record passes a report to the dryRunRecorder. Must only be called in dry-run
+// mode.
+func (t *transmissionState) record(stage types.TransmissionStage, target string, reportContext types.ReportContext, transmission types.PendingTransmission) {
+	ctx, cancel := context.WithTimeout(
+		t.ctx,
+		t.localConfig.Get().ContractTransmitterTransmitTimeout,
+	)
+	defer cancel()
+
+	var scheduledTime time.Time
+	if stage == types.TransmissionStageAccepted {
+		scheduledTime = transmission.Time
+	}
+
+	err := t.dryRunRecorder.Record(ctx, types.RecordedTransmission{
+		time.Now(),
+		stage,
+		target,
+		reportContext,
+		transmission.Report,
+		transmission.AttributedSignatures,
+		scheduledTime,
+	})
+	if err != nil {
+		t.logger.ErrorIfNotCanceled("Transmission: error in TransmissionRecorder.Record", ctx, commontypes.LogFields{
+			"error":       err,
+			"stage":       stage,
+			"target":      target,
+			"epoch":       reportContext.Epoch,
+			"round":       reportContext.Round,
+			"reportIndex": reportContext.ReportIndex,
+		})
 	}
-	return nil
 }
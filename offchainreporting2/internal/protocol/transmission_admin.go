@@ -0,0 +1,81 @@
+package protocol
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/protocol/persist"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// TransmissionAdminRequest lets operators inspect and modify the queue of
+// pending transmissions while the transmission protocol is running. Requests
+// are processed by the transmission protocol's event loop, which sends exactly
+// one response on the request's response channel. Response channels should be
+// buffered, so that the event loop never blocks on them.
+type TransmissionAdminRequest interface {
+	processTransmissionAdmin(t *transmissionState)
+}
+
+// ListTransmissionQueueRequest asks for the contents of the transmission queue
+type ListTransmissionQueueRequest struct {
+	ChResponse chan<- ListTransmissionQueueResponse
+}
+
+type ListTransmissionQueueResponse struct {
+	ConfigDigest types.ConfigDigest
+	// Items ordered by scheduled transmission time
+	Items []MinHeapTimeToPendingTransmissionItem
+}
+
+var _ TransmissionAdminRequest = ListTransmissionQueueRequest{}
+
+func (req ListTransmissionQueueRequest) processTransmissionAdmin(t *transmissionState) {
+	req.ChResponse <- ListTransmissionQueueResponse{
+		t.config.ConfigDigest,
+		t.times.Items(),
+	}
+}
+
+// CancelQueuedTransmissionRequest asks for the removal of a pending
+// transmission from the transmission queue and the database. The response is
+// false if the transmission wasn't queued.
+type CancelQueuedTransmissionRequest struct {
+	Key        types.PendingTransmissionKey
+	ChResponse chan<- bool
+}
+
+var _ TransmissionAdminRequest = CancelQueuedTransmissionRequest{}
+
+func (req CancelQueuedTransmissionRequest) processTransmissionAdmin(t *transmissionState) {
+	// also prevents retransmission of a report whose transmission has already
+	// been attempted
+	delete(t.unconfirmed, req.Key)
+
+	if !t.times.Remove(req.Key) {
+		req.ChResponse <- false
+		return
+	}
+
+	t.logger.Warn("Transmission: cancelled pending transmission on operator request", commontypes.LogFields{
+		"target":      req.Key.Target,
+		"epoch":       req.Key.Epoch,
+		"round":       req.Key.Round,
+		"reportIndex": req.Key.ReportIndex,
+	})
+
+	select {
+	case t.chPersist <- persist.TransmissionDBUpdate{req.Key, nil}:
+	default:
+		t.logger.Warn("CancelQueuedTransmissionRequest: chPersist is overflowing", nil)
+	}
+
+	if t.times.Len() != 0 {
+		next := t.times.Peek()
+		t.tTransmit = time.After(time.Until(next.Time))
+	} else {
+		t.tTransmit = nil
+	}
+
+	req.ChResponse <- true
+}
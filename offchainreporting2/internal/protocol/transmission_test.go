@@ -0,0 +1,337 @@
+package protocol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/config"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/protocol/persist"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+const testDeltaStage = 10 * time.Second
+
+// newTestTransmissionState returns a transmissionState with a schedule of
+// three stages and a buffered chPersist that isn't consumed
+func newTestTransmissionState(maxRetransmissions int) (*transmissionState, chan persist.TransmissionDBUpdate) {
+	chPersist := make(chan persist.TransmissionDBUpdate, 100)
+	t := &transmissionState{
+		ctx: context.Background(),
+		config: config.SharedConfig{PublicConfig: config.PublicConfig{
+			DeltaStage:   testDeltaStage,
+			S:            []int{1, 1, 2},
+			ConfigDigest: types.ConfigDigest{1},
+		}},
+		localConfig: config.NewLocalConfigHolder(types.LocalConfig{
+			ContractTransmitterTransmitTimeout: time.Second,
+			DatabaseTimeout:                    time.Second,
+			MaxRetransmissions:                 maxRetransmissions,
+		}),
+		logger:         loghelper.MakeRootLoggerWithContext(nopLogger{}),
+		chPersist:      chPersist,
+		latestAccepted: map[string]EpochRound{},
+		unconfirmed:    map[types.PendingTransmissionKey]types.PendingTransmission{},
+	}
+	return t, chPersist
+}
+
+func testPendingTransmissionKey(epoch uint32, round uint8, target string) types.PendingTransmissionKey {
+	return types.PendingTransmissionKey{types.ReportTimestamp{types.ConfigDigest{1}, epoch, round}, 0, target}
+}
+
+func drainPersistUpdates(chPersist chan persist.TransmissionDBUpdate) []persist.TransmissionDBUpdate {
+	var updates []persist.TransmissionDBUpdate
+	for {
+		select {
+		case update := <-chPersist:
+			updates = append(updates, update)
+		default:
+			return updates
+		}
+	}
+}
+
+func TestRetransmitTime(t *testing.T) {
+	ts, _ := newTestTransmissionState(1)
+	accepted := time.Unix(1000, 0)
+
+	for _, tc := range []struct {
+		name     string
+		accepted time.Time
+		now      time.Time
+		expected time.Time
+		ok       bool
+	}{
+		{"right after acceptance", accepted, accepted, accepted.Add(testDeltaStage), true},
+		{"before acceptance", accepted, accepted.Add(-time.Second), accepted.Add(testDeltaStage), true},
+		{"during first stage", accepted, accepted.Add(testDeltaStage / 2), accepted.Add(testDeltaStage), true},
+		{"during second stage", accepted, accepted.Add(testDeltaStage * 3 / 2), accepted.Add(2 * testDeltaStage), true},
+		{"during last stage", accepted, accepted.Add(testDeltaStage * 5 / 2), time.Time{}, false},
+		{"after schedule", accepted, accepted.Add(time.Hour), time.Time{}, false},
+		{"zero accepted", time.Time{}, accepted, time.Time{}, false},
+	} {
+		retransmitTime, ok := ts.retransmitTime(tc.accepted, tc.now)
+		if ok != tc.ok || !retransmitTime.Equal(tc.expected) {
+			t.Errorf("%v: expected (%v, %v), got (%v, %v)", tc.name, tc.expected, tc.ok, retransmitTime, ok)
+		}
+	}
+
+	ts.config.DeltaStage = 0
+	if _, ok := ts.retransmitTime(accepted, accepted); ok {
+		t.Error("expected no retransmission without DeltaStage")
+	}
+}
+
+func TestEventTransmissionResult(t *testing.T) {
+	key := testPendingTransmissionKey(1, 2, "")
+	result := func(confirmed bool) types.TransmissionResult {
+		return types.TransmissionResult{types.ReportContext{key.ReportTimestamp, [32]byte{}, key.ReportIndex}, confirmed}
+	}
+
+	for _, tc := range []struct {
+		name               string
+		maxRetransmissions int
+		retransmissions    int
+		accepted           time.Duration // before now
+		latestAccepted     EpochRound
+		confirmed          bool
+		requeue            bool
+	}{
+		{"confirmed", 1, 0, time.Second, EpochRound{1, 2}, true, false},
+		{"failed", 1, 0, time.Second, EpochRound{1, 2}, false, true},
+		{"failed after retransmission", 2, 1, time.Second, EpochRound{1, 2}, false, true},
+		{"failed, superseded", 1, 0, time.Second, EpochRound{1, 3}, false, false},
+		{"failed, retransmissions exhausted", 2, 2, time.Second, EpochRound{1, 2}, false, false},
+		{"failed, retransmission disabled", 0, 0, time.Second, EpochRound{1, 2}, false, false},
+		{"failed, schedule ended", 1, 0, 3 * testDeltaStage, EpochRound{1, 2}, false, false},
+	} {
+		ts, chPersist := newTestTransmissionState(tc.maxRetransmissions)
+		ts.latestAccepted[key.Target] = tc.latestAccepted
+		accepted := time.Now().Add(-tc.accepted)
+		ts.unconfirmed[key] = types.PendingTransmission{accepted, [32]byte{}, types.Report("report"), nil, accepted, tc.retransmissions, true}
+
+		ts.eventTransmissionResult(key.Target, result(tc.confirmed))
+
+		if _, ok := ts.unconfirmed[key]; ok {
+			t.Errorf("%v: transmission is still unconfirmed", tc.name)
+		}
+		updates := drainPersistUpdates(chPersist)
+		if len(updates) != 1 || updates[0].Key != key {
+			t.Errorf("%v: expected a single database update for %v, got %v", tc.name, key, updates)
+			continue
+		}
+
+		if !tc.requeue {
+			if updates[0].PendingTransmission != nil {
+				t.Errorf("%v: expected transmission to be deleted from the database", tc.name)
+			}
+			if ts.times.Len() != 0 {
+				t.Errorf("%v: expected no retransmission", tc.name)
+			}
+			continue
+		}
+
+		if ts.times.Len() != 1 {
+			t.Errorf("%v: expected a retransmission", tc.name)
+			continue
+		}
+		item := ts.times.Peek()
+		transmission := item.PendingTransmission
+		if item.PendingTransmissionKey != key ||
+			!transmission.Time.Equal(accepted.Add(testDeltaStage)) ||
+			!transmission.AcceptedTime.Equal(accepted) ||
+			transmission.Retransmissions != tc.retransmissions+1 ||
+			transmission.AwaitingResult {
+			t.Errorf("%v: unexpected retransmission %+v", tc.name, item)
+		}
+		if persisted := updates[0].PendingTransmission; persisted == nil ||
+			!persisted.Time.Equal(transmission.Time) ||
+			persisted.Retransmissions != transmission.Retransmissions ||
+			persisted.AwaitingResult {
+			t.Errorf("%v: retransmission wasn't persisted, got %+v", tc.name, persisted)
+		}
+	}
+
+	t.Run("unknown transmission", func(t *testing.T) {
+		ts, chPersist := newTestTransmissionState(1)
+		ts.latestAccepted[key.Target] = EpochRound{1, 2}
+		ts.eventTransmissionResult(key.Target, result(false))
+		if ts.times.Len() != 0 || len(drainPersistUpdates(chPersist)) != 0 {
+			t.Fatal("expected result for unknown transmission to be ignored")
+		}
+	})
+}
+
+func TestUpdateLatestAcceptedForgetsUnconfirmed(t *testing.T) {
+	ts, chPersist := newTestTransmissionState(1)
+	older := testPendingTransmissionKey(1, 2, "")
+	otherTarget := testPendingTransmissionKey(1, 2, "other")
+	for _, key := range []types.PendingTransmissionKey{older, otherTarget} {
+		ts.updateLatestAccepted(key.Target, EpochRound{key.Epoch, key.Round})
+		ts.unconfirmed[key] = types.PendingTransmission{AwaitingResult: true}
+	}
+
+	ts.updateLatestAccepted("", EpochRound{2, 1})
+
+	if _, ok := ts.unconfirmed[older]; ok {
+		t.Error("expected unconfirmed transmission of older report to be forgotten")
+	}
+	if _, ok := ts.unconfirmed[otherTarget]; !ok {
+		t.Error("expected unconfirmed transmission for other target to be kept")
+	}
+	updates := drainPersistUpdates(chPersist)
+	if len(updates) != 1 || updates[0].Key != older || updates[0].PendingTransmission != nil {
+		t.Errorf("expected deletion of %v from the database, got %v", older, updates)
+	}
+}
+
+// pendingTransmissionsDatabase serves pending transmissions from memory. All
+// other functions panic.
+type pendingTransmissionsDatabase struct {
+	types.Database
+	pending map[types.PendingTransmissionKey]types.PendingTransmission
+}
+
+func (db pendingTransmissionsDatabase) PendingTransmissionsWithConfigDigest(context.Context, types.ConfigDigest) (map[types.PendingTransmissionKey]types.PendingTransmission, error) {
+	return db.pending, nil
+}
+
+func TestRestoreFromDatabase(t *testing.T) {
+	now := time.Now()
+	staleUnconfirmed := testPendingTransmissionKey(1, 1, "")
+	unconfirmed := testPendingTransmissionKey(1, 2, "")
+	queued := testPendingTransmissionKey(1, 2, "other")
+	expired := testPendingTransmissionKey(1, 1, "other")
+
+	ts, chPersist := newTestTransmissionState(1)
+	ts.database = pendingTransmissionsDatabase{nil, map[types.PendingTransmissionKey]types.PendingTransmission{
+		staleUnconfirmed: {now.Add(-time.Minute), [32]byte{}, nil, nil, now.Add(-time.Minute), 0, true},
+		unconfirmed:      {now.Add(-time.Second), [32]byte{}, nil, nil, now.Add(-time.Second), 0, true},
+		queued:           {now.Add(time.Minute), [32]byte{}, nil, nil, now, 0, false},
+		expired:          {now.Add(-time.Minute), [32]byte{}, nil, nil, now.Add(-time.Minute), 0, false},
+	}}
+
+	ts.restoreFromDatabase()
+
+	for _, target := range []string{"", "other"} {
+		if ts.latestAccepted[target] != (EpochRound{1, 2}) {
+			t.Errorf("unexpected latest accepted report for target %q: %v", target, ts.latestAccepted[target])
+		}
+	}
+	if _, ok := ts.unconfirmed[unconfirmed]; !ok || len(ts.unconfirmed) != 1 {
+		t.Errorf("expected only %v to be awaiting its result, got %v", unconfirmed, ts.unconfirmed)
+	}
+	if ts.times.Len() != 1 || ts.times.Peek().PendingTransmissionKey != queued {
+		t.Errorf("expected only %v to be queued, got %v", queued, ts.times.Items())
+	}
+	updates := drainPersistUpdates(chPersist)
+	if len(updates) != 1 || updates[0].Key != staleUnconfirmed || updates[0].PendingTransmission != nil {
+		t.Errorf("expected deletion of %v from the database, got %v", staleUnconfirmed, updates)
+	}
+}
+
+// reportingContractTransmitter succeeds at every transmission and never
+// reports results. All other functions panic.
+type reportingContractTransmitter struct {
+	types.ContractTransmitter
+	transmissions *int
+}
+
+func (c reportingContractTransmitter) Transmit(context.Context, types.ReportContext, types.Report, []types.AttributedOnchainSignature) error {
+	*c.transmissions++
+	return nil
+}
+
+func (reportingContractTransmitter) TransmissionResults() <-chan types.TransmissionResult {
+	return nil
+}
+
+type acceptAllFilter struct{}
+
+func (acceptAllFilter) ShouldTransmitAcceptedReport(context.Context, types.ReportTimestamp, types.Report) (bool, error) {
+	return true, nil
+}
+
+func TestEventTTransmitTimeoutAwaitsResult(t *testing.T) {
+	ts, chPersist := newTestTransmissionState(1)
+	transmissions := 0
+	ts.transmissionTargets = []types.TransmissionTarget{
+		{"", reportingContractTransmitter{nil, &transmissions}, acceptAllFilter{}},
+	}
+	key := testPendingTransmissionKey(1, 2, "")
+	now := time.Now()
+	ts.times.Push(MinHeapTimeToPendingTransmissionItem{key, types.PendingTransmission{now, [32]byte{}, types.Report("report"), nil, now, 0, false}})
+
+	ts.eventTTransmitTimeout()
+
+	if transmissions != 1 {
+		t.Fatalf("expected one transmission, got %v", transmissions)
+	}
+	if transmission, ok := ts.unconfirmed[key]; !ok || !transmission.AwaitingResult {
+		t.Errorf("expected transmission to await its result, got %+v", ts.unconfirmed)
+	}
+	updates := drainPersistUpdates(chPersist)
+	if len(updates) != 1 || updates[0].Key != key || updates[0].PendingTransmission == nil || !updates[0].PendingTransmission.AwaitingResult {
+		t.Errorf("expected transmission awaiting its result to be persisted, got %v", updates)
+	}
+}
+
+func TestTransmissionRetiring(t *testing.T) {
+	ts, _ := newTestTransmissionState(1)
+	chRetire := make(chan struct{})
+	chDrained := make(chan struct{})
+	ts.chRetire = chRetire
+	ts.chDrained = chDrained
+	queued := testPendingTransmissionKey(1, 1, "")
+	unconfirmed := testPendingTransmissionKey(1, 2, "")
+	ts.times.Push(MinHeapTimeToPendingTransmissionItem{queued, types.PendingTransmission{}})
+	ts.unconfirmed[unconfirmed] = types.PendingTransmission{AwaitingResult: true}
+
+	ts.checkDrained()
+	if isClosedChannel(chDrained) {
+		t.Fatal("chDrained closed before retiring")
+	}
+
+	close(chRetire)
+	ts.eventRetire()
+	if !ts.retiring || ts.chRetire != nil {
+		t.Fatal("expected instance to be retiring and to stop selecting on chRetire")
+	}
+
+	// reports are no longer accepted, ShouldAcceptFinalizedReport isn't even
+	// consulted
+	ts.eventTransmit(EventTransmit{2, 1, [32]byte{}, 0, AttestedReportMany{types.Report("report"), nil}})
+	if ts.times.Len() != 1 {
+		t.Fatal("expected report to be dropped while retiring")
+	}
+
+	ts.checkDrained()
+	if isClosedChannel(chDrained) {
+		t.Fatal("chDrained closed with pending transmissions")
+	}
+
+	ts.times.Pop()
+	ts.checkDrained()
+	if isClosedChannel(chDrained) {
+		t.Fatal("chDrained closed with unconfirmed transmission")
+	}
+
+	ts.forgetUnconfirmed(unconfirmed)
+	ts.checkDrained()
+	if !isClosedChannel(chDrained) {
+		t.Fatal("expected chDrained to be closed once all transmissions are worked off")
+	}
+	// closing chDrained again would panic
+	ts.checkDrained()
+}
+
+func isClosedChannel(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
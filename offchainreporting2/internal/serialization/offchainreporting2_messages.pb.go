@@ -358,34 +358,489 @@ func (x *MessageObserve) GetSignedObservation() *SignedObservation {
 	return nil
 }
 
+type SignedObservationCommitment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Commitment []byte `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	Signature  []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *SignedObservationCommitment) Reset() {
+	*x = SignedObservationCommitment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting2_messages_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignedObservationCommitment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedObservationCommitment) ProtoMessage() {}
+
+func (x *SignedObservationCommitment) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_messages_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedObservationCommitment.ProtoReflect.Descriptor instead.
+func (*SignedObservationCommitment) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SignedObservationCommitment) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+func (x *SignedObservationCommitment) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type AttributedSignedObservationCommitment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SignedObservationCommitment *SignedObservationCommitment `protobuf:"bytes,1,opt,name=signed_observation_commitment,json=signedObservationCommitment,proto3" json:"signed_observation_commitment,omitempty"`
+	Observer                    uint32                       `protobuf:"varint,2,opt,name=observer,proto3" json:"observer,omitempty"`
+}
+
+func (x *AttributedSignedObservationCommitment) Reset() {
+	*x = AttributedSignedObservationCommitment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting2_messages_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AttributedSignedObservationCommitment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributedSignedObservationCommitment) ProtoMessage() {}
+
+func (x *AttributedSignedObservationCommitment) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_messages_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributedSignedObservationCommitment.ProtoReflect.Descriptor instead.
+func (*AttributedSignedObservationCommitment) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AttributedSignedObservationCommitment) GetSignedObservationCommitment() *SignedObservationCommitment {
+	if x != nil {
+		return x.SignedObservationCommitment
+	}
+	return nil
+}
+
+func (x *AttributedSignedObservationCommitment) GetObserver() uint32 {
+	if x != nil {
+		return x.Observer
+	}
+	return 0
+}
+
+type MessageObserveCommit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch                       uint64                       `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round                       uint32                       `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	SignedObservationCommitment *SignedObservationCommitment `protobuf:"bytes,3,opt,name=signed_observation_commitment,json=signedObservationCommitment,proto3" json:"signed_observation_commitment,omitempty"`
+}
+
+func (x *MessageObserveCommit) Reset() {
+	*x = MessageObserveCommit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting2_messages_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageObserveCommit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageObserveCommit) ProtoMessage() {}
+
+func (x *MessageObserveCommit) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_messages_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageObserveCommit.ProtoReflect.Descriptor instead.
+func (*MessageObserveCommit) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MessageObserveCommit) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *MessageObserveCommit) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *MessageObserveCommit) GetSignedObservationCommitment() *SignedObservationCommitment {
+	if x != nil {
+		return x.SignedObservationCommitment
+	}
+	return nil
+}
+
+type MessageRevealReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch                                  uint64                                   `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round                                  uint32                                   `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	AttributedSignedObservationCommitments []*AttributedSignedObservationCommitment `protobuf:"bytes,3,rep,name=attributed_signed_observation_commitments,json=attributedSignedObservationCommitments,proto3" json:"attributed_signed_observation_commitments,omitempty"`
+}
+
+func (x *MessageRevealReq) Reset() {
+	*x = MessageRevealReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting2_messages_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageRevealReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageRevealReq) ProtoMessage() {}
+
+func (x *MessageRevealReq) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_messages_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageRevealReq.ProtoReflect.Descriptor instead.
+func (*MessageRevealReq) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MessageRevealReq) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *MessageRevealReq) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *MessageRevealReq) GetAttributedSignedObservationCommitments() []*AttributedSignedObservationCommitment {
+	if x != nil {
+		return x.AttributedSignedObservationCommitments
+	}
+	return nil
+}
+
+type MessageObserveReveal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Epoch             uint64             `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round             uint32             `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	SignedObservation *SignedObservation `protobuf:"bytes,3,opt,name=signed_observation,json=signedObservation,proto3" json:"signed_observation,omitempty"`
+	Nonce             []byte             `protobuf:"bytes,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (x *MessageObserveReveal) Reset() {
+	*x = MessageObserveReveal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting2_messages_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageObserveReveal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageObserveReveal) ProtoMessage() {}
+
+func (x *MessageObserveReveal) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_messages_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageObserveReveal.ProtoReflect.Descriptor instead.
+func (*MessageObserveReveal) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MessageObserveReveal) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *MessageObserveReveal) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *MessageObserveReveal) GetSignedObservation() *SignedObservation {
+	if x != nil {
+		return x.SignedObservation
+	}
+	return nil
+}
+
+func (x *MessageObserveReveal) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
 type MessageReportReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Epoch                        uint64                         `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
-	Round                        uint32                         `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
-	Query                        []byte                         `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
-	AttributedSignedObservations []*AttributedSignedObservation `protobuf:"bytes,4,rep,name=attributed_signed_observations,json=attributedSignedObservations,proto3" json:"attributed_signed_observations,omitempty"`
+	Epoch                                  uint64                                   `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round                                  uint32                                   `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Query                                  []byte                                   `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	AttributedSignedObservations           []*AttributedSignedObservation           `protobuf:"bytes,4,rep,name=attributed_signed_observations,json=attributedSignedObservations,proto3" json:"attributed_signed_observations,omitempty"`
+	ObservationNonces                      [][]byte                                 `protobuf:"bytes,5,rep,name=observation_nonces,json=observationNonces,proto3" json:"observation_nonces,omitempty"`
+	AttributedSignedObservationCommitments []*AttributedSignedObservationCommitment `protobuf:"bytes,6,rep,name=attributed_signed_observation_commitments,json=attributedSignedObservationCommitments,proto3" json:"attributed_signed_observation_commitments,omitempty"`
+}
+
+func (x *MessageReportReq) Reset() {
+	*x = MessageReportReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting2_messages_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageReportReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageReportReq) ProtoMessage() {}
+
+func (x *MessageReportReq) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_messages_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageReportReq.ProtoReflect.Descriptor instead.
+func (*MessageReportReq) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MessageReportReq) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *MessageReportReq) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *MessageReportReq) GetQuery() []byte {
+	if x != nil {
+		return x.Query
+	}
+	return nil
+}
+
+func (x *MessageReportReq) GetAttributedSignedObservations() []*AttributedSignedObservation {
+	if x != nil {
+		return x.AttributedSignedObservations
+	}
+	return nil
+}
+
+func (x *MessageReportReq) GetObservationNonces() [][]byte {
+	if x != nil {
+		return x.ObservationNonces
+	}
+	return nil
+}
+
+func (x *MessageReportReq) GetAttributedSignedObservationCommitments() []*AttributedSignedObservationCommitment {
+	if x != nil {
+		return x.AttributedSignedObservationCommitments
+	}
+	return nil
+}
+
+type AttestedReportOne struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Skip      bool   `protobuf:"varint,3,opt,name=skip,proto3" json:"skip,omitempty"`
+	Report    []byte `protobuf:"bytes,4,opt,name=report,proto3" json:"report,omitempty"`
+	Signature []byte `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *AttestedReportOne) Reset() {
+	*x = AttestedReportOne{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting2_messages_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AttestedReportOne) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttestedReportOne) ProtoMessage() {}
+
+func (x *AttestedReportOne) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_messages_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttestedReportOne.ProtoReflect.Descriptor instead.
+func (*AttestedReportOne) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AttestedReportOne) GetSkip() bool {
+	if x != nil {
+		return x.Skip
+	}
+	return false
+}
+
+func (x *AttestedReportOne) GetReport() []byte {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+func (x *AttestedReportOne) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type SignedOutcomeState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OutcomeState []byte `protobuf:"bytes,1,opt,name=outcome_state,json=outcomeState,proto3" json:"outcome_state,omitempty"`
+	Signature    []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (x *MessageReportReq) Reset() {
-	*x = MessageReportReq{}
+func (x *SignedOutcomeState) Reset() {
+	*x = SignedOutcomeState{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_offchainreporting2_messages_proto_msgTypes[6]
+		mi := &file_offchainreporting2_messages_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MessageReportReq) String() string {
+func (x *SignedOutcomeState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MessageReportReq) ProtoMessage() {}
+func (*SignedOutcomeState) ProtoMessage() {}
 
-func (x *MessageReportReq) ProtoReflect() protoreflect.Message {
-	mi := &file_offchainreporting2_messages_proto_msgTypes[6]
+func (x *SignedOutcomeState) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_messages_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -396,66 +851,106 @@ func (x *MessageReportReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MessageReportReq.ProtoReflect.Descriptor instead.
-func (*MessageReportReq) Descriptor() ([]byte, []int) {
-	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SignedOutcomeState.ProtoReflect.Descriptor instead.
+func (*SignedOutcomeState) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *MessageReportReq) GetEpoch() uint64 {
+func (x *SignedOutcomeState) GetOutcomeState() []byte {
 	if x != nil {
-		return x.Epoch
+		return x.OutcomeState
 	}
-	return 0
+	return nil
 }
 
-func (x *MessageReportReq) GetRound() uint32 {
+func (x *SignedOutcomeState) GetSignature() []byte {
 	if x != nil {
-		return x.Round
+		return x.Signature
 	}
-	return 0
+	return nil
 }
 
-func (x *MessageReportReq) GetQuery() []byte {
+type AttributedOutcomeSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	Signer    uint32 `protobuf:"varint,2,opt,name=signer,proto3" json:"signer,omitempty"`
+}
+
+func (x *AttributedOutcomeSignature) Reset() {
+	*x = AttributedOutcomeSignature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting2_messages_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AttributedOutcomeSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributedOutcomeSignature) ProtoMessage() {}
+
+func (x *AttributedOutcomeSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_messages_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributedOutcomeSignature.ProtoReflect.Descriptor instead.
+func (*AttributedOutcomeSignature) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AttributedOutcomeSignature) GetSignature() []byte {
 	if x != nil {
-		return x.Query
+		return x.Signature
 	}
 	return nil
 }
 
-func (x *MessageReportReq) GetAttributedSignedObservations() []*AttributedSignedObservation {
+func (x *AttributedOutcomeSignature) GetSigner() uint32 {
 	if x != nil {
-		return x.AttributedSignedObservations
+		return x.Signer
 	}
-	return nil
+	return 0
 }
 
-type AttestedReportOne struct {
+type CertifiedOutcomeState struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Skip      bool   `protobuf:"varint,3,opt,name=skip,proto3" json:"skip,omitempty"`
-	Report    []byte `protobuf:"bytes,4,opt,name=report,proto3" json:"report,omitempty"`
-	Signature []byte `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	OutcomeState         []byte                        `protobuf:"bytes,1,opt,name=outcome_state,json=outcomeState,proto3" json:"outcome_state,omitempty"`
+	AttributedSignatures []*AttributedOutcomeSignature `protobuf:"bytes,2,rep,name=attributed_signatures,json=attributedSignatures,proto3" json:"attributed_signatures,omitempty"`
 }
 
-func (x *AttestedReportOne) Reset() {
-	*x = AttestedReportOne{}
+func (x *CertifiedOutcomeState) Reset() {
+	*x = CertifiedOutcomeState{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_offchainreporting2_messages_proto_msgTypes[7]
+		mi := &file_offchainreporting2_messages_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AttestedReportOne) String() string {
+func (x *CertifiedOutcomeState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AttestedReportOne) ProtoMessage() {}
+func (*CertifiedOutcomeState) ProtoMessage() {}
 
-func (x *AttestedReportOne) ProtoReflect() protoreflect.Message {
-	mi := &file_offchainreporting2_messages_proto_msgTypes[7]
+func (x *CertifiedOutcomeState) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_messages_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -466,28 +961,21 @@ func (x *AttestedReportOne) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AttestedReportOne.ProtoReflect.Descriptor instead.
-func (*AttestedReportOne) Descriptor() ([]byte, []int) {
-	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *AttestedReportOne) GetSkip() bool {
-	if x != nil {
-		return x.Skip
-	}
-	return false
+// Deprecated: Use CertifiedOutcomeState.ProtoReflect.Descriptor instead.
+func (*CertifiedOutcomeState) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *AttestedReportOne) GetReport() []byte {
+func (x *CertifiedOutcomeState) GetOutcomeState() []byte {
 	if x != nil {
-		return x.Report
+		return x.OutcomeState
 	}
 	return nil
 }
 
-func (x *AttestedReportOne) GetSignature() []byte {
+func (x *CertifiedOutcomeState) GetAttributedSignatures() []*AttributedOutcomeSignature {
 	if x != nil {
-		return x.Signature
+		return x.AttributedSignatures
 	}
 	return nil
 }
@@ -497,15 +985,16 @@ type MessageReport struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Epoch          uint64             `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
-	Round          uint32             `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
-	AttestedReport *AttestedReportOne `protobuf:"bytes,3,opt,name=attested_report,json=attestedReport,proto3" json:"attested_report,omitempty"`
+	Epoch              uint64               `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round              uint32               `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	AttestedReports    []*AttestedReportOne `protobuf:"bytes,3,rep,name=attested_reports,json=attestedReports,proto3" json:"attested_reports,omitempty"`
+	SignedOutcomeState *SignedOutcomeState  `protobuf:"bytes,4,opt,name=signed_outcome_state,json=signedOutcomeState,proto3" json:"signed_outcome_state,omitempty"`
 }
 
 func (x *MessageReport) Reset() {
 	*x = MessageReport{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_offchainreporting2_messages_proto_msgTypes[8]
+		mi := &file_offchainreporting2_messages_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -518,7 +1007,7 @@ func (x *MessageReport) String() string {
 func (*MessageReport) ProtoMessage() {}
 
 func (x *MessageReport) ProtoReflect() protoreflect.Message {
-	mi := &file_offchainreporting2_messages_proto_msgTypes[8]
+	mi := &file_offchainreporting2_messages_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -531,7 +1020,7 @@ func (x *MessageReport) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageReport.ProtoReflect.Descriptor instead.
 func (*MessageReport) Descriptor() ([]byte, []int) {
-	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{8}
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *MessageReport) GetEpoch() uint64 {
@@ -548,9 +1037,16 @@ func (x *MessageReport) GetRound() uint32 {
 	return 0
 }
 
-func (x *MessageReport) GetAttestedReport() *AttestedReportOne {
+func (x *MessageReport) GetAttestedReports() []*AttestedReportOne {
+	if x != nil {
+		return x.AttestedReports
+	}
+	return nil
+}
+
+func (x *MessageReport) GetSignedOutcomeState() *SignedOutcomeState {
 	if x != nil {
-		return x.AttestedReport
+		return x.SignedOutcomeState
 	}
 	return nil
 }
@@ -567,7 +1063,7 @@ type AttestedReportMany struct {
 func (x *AttestedReportMany) Reset() {
 	*x = AttestedReportMany{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_offchainreporting2_messages_proto_msgTypes[9]
+		mi := &file_offchainreporting2_messages_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -580,7 +1076,7 @@ func (x *AttestedReportMany) String() string {
 func (*AttestedReportMany) ProtoMessage() {}
 
 func (x *AttestedReportMany) ProtoReflect() protoreflect.Message {
-	mi := &file_offchainreporting2_messages_proto_msgTypes[9]
+	mi := &file_offchainreporting2_messages_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -593,7 +1089,7 @@ func (x *AttestedReportMany) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AttestedReportMany.ProtoReflect.Descriptor instead.
 func (*AttestedReportMany) Descriptor() ([]byte, []int) {
-	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{9}
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *AttestedReportMany) GetReport() []byte {
@@ -615,16 +1111,17 @@ type MessageFinal struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Epoch          uint64              `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
-	Round          uint32              `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
-	H              []byte              `protobuf:"bytes,4,opt,name=h,proto3" json:"h,omitempty"`
-	AttestedReport *AttestedReportMany `protobuf:"bytes,5,opt,name=attested_report,json=attestedReport,proto3" json:"attested_report,omitempty"`
+	Epoch                 uint64                 `protobuf:"varint,1,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Round                 uint32                 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	H                     []byte                 `protobuf:"bytes,4,opt,name=h,proto3" json:"h,omitempty"`
+	AttestedReports       []*AttestedReportMany  `protobuf:"bytes,5,rep,name=attested_reports,json=attestedReports,proto3" json:"attested_reports,omitempty"`
+	CertifiedOutcomeState *CertifiedOutcomeState `protobuf:"bytes,6,opt,name=certified_outcome_state,json=certifiedOutcomeState,proto3" json:"certified_outcome_state,omitempty"`
 }
 
 func (x *MessageFinal) Reset() {
 	*x = MessageFinal{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_offchainreporting2_messages_proto_msgTypes[10]
+		mi := &file_offchainreporting2_messages_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -637,7 +1134,7 @@ func (x *MessageFinal) String() string {
 func (*MessageFinal) ProtoMessage() {}
 
 func (x *MessageFinal) ProtoReflect() protoreflect.Message {
-	mi := &file_offchainreporting2_messages_proto_msgTypes[10]
+	mi := &file_offchainreporting2_messages_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -650,7 +1147,7 @@ func (x *MessageFinal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageFinal.ProtoReflect.Descriptor instead.
 func (*MessageFinal) Descriptor() ([]byte, []int) {
-	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{10}
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *MessageFinal) GetEpoch() uint64 {
@@ -674,14 +1171,20 @@ func (x *MessageFinal) GetH() []byte {
 	return nil
 }
 
-func (x *MessageFinal) GetAttestedReport() *AttestedReportMany {
+func (x *MessageFinal) GetAttestedReports() []*AttestedReportMany {
 	if x != nil {
-		return x.AttestedReport
+		return x.AttestedReports
+	}
+	return nil
+}
+
+func (x *MessageFinal) GetCertifiedOutcomeState() *CertifiedOutcomeState {
+	if x != nil {
+		return x.CertifiedOutcomeState
 	}
 	return nil
 }
 
-// MessageFinalEcho has exactly the same fields as MessageFinal
 type MessageFinalEcho struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -693,7 +1196,7 @@ type MessageFinalEcho struct {
 func (x *MessageFinalEcho) Reset() {
 	*x = MessageFinalEcho{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_offchainreporting2_messages_proto_msgTypes[11]
+		mi := &file_offchainreporting2_messages_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -706,7 +1209,7 @@ func (x *MessageFinalEcho) String() string {
 func (*MessageFinalEcho) ProtoMessage() {}
 
 func (x *MessageFinalEcho) ProtoReflect() protoreflect.Message {
-	mi := &file_offchainreporting2_messages_proto_msgTypes[11]
+	mi := &file_offchainreporting2_messages_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -719,7 +1222,7 @@ func (x *MessageFinalEcho) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageFinalEcho.ProtoReflect.Descriptor instead.
 func (*MessageFinalEcho) Descriptor() ([]byte, []int) {
-	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{11}
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *MessageFinalEcho) GetFinal() *MessageFinal {
@@ -742,13 +1245,16 @@ type MessageWrapper struct {
 	//	*MessageWrapper_MessageReport
 	//	*MessageWrapper_MessageFinal
 	//	*MessageWrapper_MessageFinalEcho
+	//	*MessageWrapper_MessageObserveCommit
+	//	*MessageWrapper_MessageRevealReq
+	//	*MessageWrapper_MessageObserveReveal
 	Msg isMessageWrapper_Msg `protobuf_oneof:"msg"`
 }
 
 func (x *MessageWrapper) Reset() {
 	*x = MessageWrapper{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_offchainreporting2_messages_proto_msgTypes[12]
+		mi := &file_offchainreporting2_messages_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -761,7 +1267,7 @@ func (x *MessageWrapper) String() string {
 func (*MessageWrapper) ProtoMessage() {}
 
 func (x *MessageWrapper) ProtoReflect() protoreflect.Message {
-	mi := &file_offchainreporting2_messages_proto_msgTypes[12]
+	mi := &file_offchainreporting2_messages_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -774,7 +1280,7 @@ func (x *MessageWrapper) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageWrapper.ProtoReflect.Descriptor instead.
 func (*MessageWrapper) Descriptor() ([]byte, []int) {
-	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{12}
+	return file_offchainreporting2_messages_proto_rawDescGZIP(), []int{20}
 }
 
 func (m *MessageWrapper) GetMsg() isMessageWrapper_Msg {
@@ -833,6 +1339,27 @@ func (x *MessageWrapper) GetMessageFinalEcho() *MessageFinalEcho {
 	return nil
 }
 
+func (x *MessageWrapper) GetMessageObserveCommit() *MessageObserveCommit {
+	if x, ok := x.GetMsg().(*MessageWrapper_MessageObserveCommit); ok {
+		return x.MessageObserveCommit
+	}
+	return nil
+}
+
+func (x *MessageWrapper) GetMessageRevealReq() *MessageRevealReq {
+	if x, ok := x.GetMsg().(*MessageWrapper_MessageRevealReq); ok {
+		return x.MessageRevealReq
+	}
+	return nil
+}
+
+func (x *MessageWrapper) GetMessageObserveReveal() *MessageObserveReveal {
+	if x, ok := x.GetMsg().(*MessageWrapper_MessageObserveReveal); ok {
+		return x.MessageObserveReveal
+	}
+	return nil
+}
+
 type isMessageWrapper_Msg interface {
 	isMessageWrapper_Msg()
 }
@@ -865,6 +1392,18 @@ type MessageWrapper_MessageFinalEcho struct {
 	MessageFinalEcho *MessageFinalEcho `protobuf:"bytes,8,opt,name=message_final_echo,json=messageFinalEcho,proto3,oneof"`
 }
 
+type MessageWrapper_MessageObserveCommit struct {
+	MessageObserveCommit *MessageObserveCommit `protobuf:"bytes,9,opt,name=message_observe_commit,json=messageObserveCommit,proto3,oneof"`
+}
+
+type MessageWrapper_MessageRevealReq struct {
+	MessageRevealReq *MessageRevealReq `protobuf:"bytes,10,opt,name=message_reveal_req,json=messageRevealReq,proto3,oneof"`
+}
+
+type MessageWrapper_MessageObserveReveal struct {
+	MessageObserveReveal *MessageObserveReveal `protobuf:"bytes,11,opt,name=message_observe_reveal,json=messageObserveReveal,proto3,oneof"`
+}
+
 func (*MessageWrapper_MessageNewEpoch) isMessageWrapper_Msg() {}
 
 func (*MessageWrapper_MessageObserveReq) isMessageWrapper_Msg() {}
@@ -879,6 +1418,12 @@ func (*MessageWrapper_MessageFinal) isMessageWrapper_Msg() {}
 
 func (*MessageWrapper_MessageFinalEcho) isMessageWrapper_Msg() {}
 
+func (*MessageWrapper_MessageObserveCommit) isMessageWrapper_Msg() {}
+
+func (*MessageWrapper_MessageRevealReq) isMessageWrapper_Msg() {}
+
+func (*MessageWrapper_MessageObserveReveal) isMessageWrapper_Msg() {}
+
 var File_offchainreporting2_messages_proto protoreflect.FileDescriptor
 
 var file_offchainreporting2_messages_proto_rawDesc = []byte{
@@ -922,99 +1467,216 @@ var file_offchainreporting2_messages_proto_rawDesc = []byte{
 	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x53, 0x69, 0x67, 0x6e,
 	0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x73,
 	0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x22, 0xcb, 0x01, 0x0a, 0x10, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x52, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72,
-	0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e,
-	0x64, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x75, 0x0a, 0x1e, 0x61, 0x74, 0x74, 0x72, 0x69,
-	0x62, 0x75, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x6f, 0x62, 0x73,
-	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x2f, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x69, 0x6e, 0x67, 0x32, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x64, 0x53,
-	0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x1c, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e,
-	0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x69,
-	0x0a, 0x11, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
-	0x4f, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72,
-	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12,
-	0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x4a, 0x04, 0x08,
-	0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x8b, 0x01, 0x0a, 0x0d, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65,
-	0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63,
-	0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x4e, 0x0a, 0x0f, 0x61, 0x74, 0x74, 0x65, 0x73,
-	0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x25, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72,
-	0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65,
-	0x70, 0x6f, 0x72, 0x74, 0x4f, 0x6e, 0x65, 0x52, 0x0e, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65,
-	0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x96, 0x01, 0x0a, 0x12, 0x41, 0x74, 0x74, 0x65,
-	0x73, 0x74, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x12, 0x16,
-	0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
-	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x5c, 0x0a, 0x15, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62,
-	0x75, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18,
-	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69,
-	0x62, 0x75, 0x74, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x14,
-	0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
-	0x75, 0x72, 0x65, 0x73, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03,
-	0x22, 0x9f, 0x01, 0x0a, 0x0c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e, 0x61,
-	0x6c, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x22, 0x5b, 0x0a, 0x1b, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xb8, 0x01,
+	0x0a, 0x25, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x73, 0x0a, 0x1d, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f,
+	0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69,
+	0x6e, 0x67, 0x32, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x1b, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0xb7, 0x01, 0x0a, 0x14, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
 	0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x0c, 0x0a,
-	0x01, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x68, 0x12, 0x4f, 0x0a, 0x0f, 0x61,
-	0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72,
-	0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74,
-	0x65, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x52, 0x0e, 0x61, 0x74,
-	0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4a, 0x04, 0x08, 0x03,
-	0x10, 0x04, 0x22, 0x4a, 0x0a, 0x10, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e,
-	0x61, 0x6c, 0x45, 0x63, 0x68, 0x6f, 0x12, 0x36, 0x0a, 0x05, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x52, 0x05, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x22, 0xd9,
-	0x04, 0x0a, 0x0e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65,
-	0x72, 0x12, 0x51, 0x0a, 0x11, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x65, 0x77,
-	0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x73, 0x0a,
+	0x1d, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x1b, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65,
+	0x6e, 0x74, 0x22, 0xd5, 0x01, 0x0a, 0x10, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65,
+	0x76, 0x65, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x12, 0x94, 0x01, 0x0a, 0x29, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x64, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x41, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x26, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x64, 0x53, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xae, 0x01, 0x0a, 0x14, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x76,
+	0x65, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12,
+	0x54, 0x0a, 0x12, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6f, 0x66,
+	0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32,
+	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x11, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0x91, 0x03, 0x0a, 0x10,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x12, 0x75, 0x0a, 0x1e, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x64,
+	0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6f, 0x66, 0x66,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e,
+	0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x1c, 0x61, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x6f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x11, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x94, 0x01, 0x0a, 0x29, 0x61, 0x74, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x6f,
+	0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x6f,
 	0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67,
-	0x32, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4e, 0x65, 0x77, 0x45, 0x70, 0x6f, 0x63,
-	0x68, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4e, 0x65, 0x77, 0x45,
-	0x70, 0x6f, 0x63, 0x68, 0x12, 0x57, 0x0a, 0x13, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f,
-	0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x25, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f,
-	0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x11, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x71, 0x12, 0x4d, 0x0a,
-	0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
+	0x32, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x26, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22,
+	0x69, 0x0a, 0x11, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x4f, 0x6e, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x73, 0x6b, 0x69, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x4a, 0x04,
+	0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x57, 0x0a, 0x12, 0x53, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x22, 0x52, 0x0a, 0x1a, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x64, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x22, 0xa1, 0x01, 0x0a, 0x15, 0x43, 0x65, 0x72, 0x74,
+	0x69, 0x66, 0x69, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x63, 0x0a, 0x15, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x14, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0xe7, 0x01, 0x0a, 0x0d,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x50, 0x0a, 0x10, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4f, 0x6e, 0x65, 0x52, 0x0f, 0x61, 0x74, 0x74, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x58, 0x0a, 0x14, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6f, 0x66, 0x66, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x53,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x12, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0x96, 0x01, 0x0a, 0x12, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x12, 0x5c, 0x0a, 0x15, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x65, 0x64, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x14, 0x61, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x84,
+	0x02, 0x0a, 0x0c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x68,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x68, 0x12, 0x51, 0x0a, 0x10, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x4d, 0x61, 0x6e, 0x79, 0x52, 0x0f, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x61, 0x0a, 0x17,
+	0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d,
+	0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e,
+	0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e,
+	0x67, 0x32, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x15, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x4a,
+	0x04, 0x08, 0x03, 0x10, 0x04, 0x22, 0x4a, 0x0a, 0x10, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x46, 0x69, 0x6e, 0x61, 0x6c, 0x45, 0x63, 0x68, 0x6f, 0x12, 0x36, 0x0a, 0x05, 0x66, 0x69, 0x6e,
+	0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x52, 0x05, 0x66, 0x69, 0x6e, 0x61,
+	0x6c, 0x22, 0xf3, 0x06, 0x0a, 0x0e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x57, 0x72, 0x61,
+	0x70, 0x70, 0x65, 0x72, 0x12, 0x51, 0x0a, 0x11, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f,
+	0x6e, 0x65, 0x77, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x69, 0x6e, 0x67, 0x32, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4e, 0x65, 0x77, 0x45,
+	0x70, 0x6f, 0x63, 0x68, 0x48, 0x00, 0x52, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4e,
+	0x65, 0x77, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x57, 0x0a, 0x13, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x11, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x71,
+	0x12, 0x4d, 0x0a, 0x0f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x66, 0x66, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x48, 0x00, 0x52,
+	0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x12,
+	0x54, 0x0a, 0x12, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x5f, 0x72, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x66,
+	0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32,
+	0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x71, 0x48, 0x00, 0x52, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x12, 0x4a, 0x0a, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e,
+	0x67, 0x32, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x48, 0x00, 0x52, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x47, 0x0a, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x69, 0x6e,
+	0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x0c, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x12, 0x54, 0x0a, 0x12, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x65, 0x63, 0x68, 0x6f,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
 	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x4d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x48, 0x00, 0x52, 0x0e, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x12, 0x54, 0x0a, 0x12,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x72,
-	0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68,
+	0x61, 0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x45, 0x63, 0x68, 0x6f, 0x48, 0x00, 0x52, 0x10,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x45, 0x63, 0x68, 0x6f,
+	0x12, 0x60, 0x0a, 0x16, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6f, 0x62, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x00, 0x52, 0x14, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x12, 0x54, 0x0a, 0x12, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65,
+	0x76, 0x65, 0x61, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69,
+	0x6e, 0x67, 0x32, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x76, 0x65, 0x61,
+	0x6c, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x76, 0x65, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x12, 0x60, 0x0a, 0x16, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x65,
+	0x61, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68,
 	0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x48, 0x00,
-	0x52, 0x10, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52,
-	0x65, 0x71, 0x12, 0x4a, 0x0a, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65,
-	0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x66, 0x66,
-	0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x48, 0x00, 0x52,
-	0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x47,
-	0x0a, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x48, 0x00, 0x52, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x12, 0x54, 0x0a, 0x12, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x5f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x65, 0x63, 0x68, 0x6f, 0x18, 0x08, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
-	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x46, 0x69, 0x6e, 0x61, 0x6c, 0x45, 0x63, 0x68, 0x6f, 0x48, 0x00, 0x52, 0x10, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x45, 0x63, 0x68, 0x6f, 0x42, 0x05, 0x0a,
-	0x03, 0x6d, 0x73, 0x67, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x42, 0x11, 0x5a, 0x0f, 0x2e, 0x3b,
-	0x73, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x76, 0x65,
+	0x61, 0x6c, 0x48, 0x00, 0x52, 0x14, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4f, 0x62, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x42, 0x05, 0x0a, 0x03, 0x6d, 0x73,
+	0x67, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x42, 0x11, 0x5a, 0x0f, 0x2e, 0x3b, 0x73, 0x65, 0x72,
+	0x69, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -1029,42 +1691,61 @@ func file_offchainreporting2_messages_proto_rawDescGZIP() []byte {
 	return file_offchainreporting2_messages_proto_rawDescData
 }
 
-var file_offchainreporting2_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_offchainreporting2_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_offchainreporting2_messages_proto_goTypes = []interface{}{
-	(*MessageNewEpoch)(nil),             // 0: offchainreporting2.MessageNewEpoch
-	(*MessageObserveReq)(nil),           // 1: offchainreporting2.MessageObserveReq
-	(*SignedObservation)(nil),           // 2: offchainreporting2.SignedObservation
-	(*AttributedSignedObservation)(nil), // 3: offchainreporting2.AttributedSignedObservation
-	(*AttributedSignature)(nil),         // 4: offchainreporting2.AttributedSignature
-	(*MessageObserve)(nil),              // 5: offchainreporting2.MessageObserve
-	(*MessageReportReq)(nil),            // 6: offchainreporting2.MessageReportReq
-	(*AttestedReportOne)(nil),           // 7: offchainreporting2.AttestedReportOne
-	(*MessageReport)(nil),               // 8: offchainreporting2.MessageReport
-	(*AttestedReportMany)(nil),          // 9: offchainreporting2.AttestedReportMany
-	(*MessageFinal)(nil),                // 10: offchainreporting2.MessageFinal
-	(*MessageFinalEcho)(nil),            // 11: offchainreporting2.MessageFinalEcho
-	(*MessageWrapper)(nil),              // 12: offchainreporting2.MessageWrapper
+	(*MessageNewEpoch)(nil),                       // 0: offchainreporting2.MessageNewEpoch
+	(*MessageObserveReq)(nil),                     // 1: offchainreporting2.MessageObserveReq
+	(*SignedObservation)(nil),                     // 2: offchainreporting2.SignedObservation
+	(*AttributedSignedObservation)(nil),           // 3: offchainreporting2.AttributedSignedObservation
+	(*AttributedSignature)(nil),                   // 4: offchainreporting2.AttributedSignature
+	(*MessageObserve)(nil),                        // 5: offchainreporting2.MessageObserve
+	(*SignedObservationCommitment)(nil),           // 6: offchainreporting2.SignedObservationCommitment
+	(*AttributedSignedObservationCommitment)(nil), // 7: offchainreporting2.AttributedSignedObservationCommitment
+	(*MessageObserveCommit)(nil),                  // 8: offchainreporting2.MessageObserveCommit
+	(*MessageRevealReq)(nil),                      // 9: offchainreporting2.MessageRevealReq
+	(*MessageObserveReveal)(nil),                  // 10: offchainreporting2.MessageObserveReveal
+	(*MessageReportReq)(nil),                      // 11: offchainreporting2.MessageReportReq
+	(*AttestedReportOne)(nil),                     // 12: offchainreporting2.AttestedReportOne
+	(*SignedOutcomeState)(nil),                    // 13: offchainreporting2.SignedOutcomeState
+	(*AttributedOutcomeSignature)(nil),            // 14: offchainreporting2.AttributedOutcomeSignature
+	(*CertifiedOutcomeState)(nil),                 // 15: offchainreporting2.CertifiedOutcomeState
+	(*MessageReport)(nil),                         // 16: offchainreporting2.MessageReport
+	(*AttestedReportMany)(nil),                    // 17: offchainreporting2.AttestedReportMany
+	(*MessageFinal)(nil),                          // 18: offchainreporting2.MessageFinal
+	(*MessageFinalEcho)(nil),                      // 19: offchainreporting2.MessageFinalEcho
+	(*MessageWrapper)(nil),                        // 20: offchainreporting2.MessageWrapper
 }
 var file_offchainreporting2_messages_proto_depIdxs = []int32{
 	2,  // 0: offchainreporting2.AttributedSignedObservation.signed_observation:type_name -> offchainreporting2.SignedObservation
 	2,  // 1: offchainreporting2.MessageObserve.signed_observation:type_name -> offchainreporting2.SignedObservation
-	3,  // 2: offchainreporting2.MessageReportReq.attributed_signed_observations:type_name -> offchainreporting2.AttributedSignedObservation
-	7,  // 3: offchainreporting2.MessageReport.attested_report:type_name -> offchainreporting2.AttestedReportOne
-	4,  // 4: offchainreporting2.AttestedReportMany.attributed_signatures:type_name -> offchainreporting2.AttributedSignature
-	9,  // 5: offchainreporting2.MessageFinal.attested_report:type_name -> offchainreporting2.AttestedReportMany
-	10, // 6: offchainreporting2.MessageFinalEcho.final:type_name -> offchainreporting2.MessageFinal
-	0,  // 7: offchainreporting2.MessageWrapper.message_new_epoch:type_name -> offchainreporting2.MessageNewEpoch
-	1,  // 8: offchainreporting2.MessageWrapper.message_observe_req:type_name -> offchainreporting2.MessageObserveReq
-	5,  // 9: offchainreporting2.MessageWrapper.message_observe:type_name -> offchainreporting2.MessageObserve
-	6,  // 10: offchainreporting2.MessageWrapper.message_report_req:type_name -> offchainreporting2.MessageReportReq
-	8,  // 11: offchainreporting2.MessageWrapper.message_report:type_name -> offchainreporting2.MessageReport
-	10, // 12: offchainreporting2.MessageWrapper.message_final:type_name -> offchainreporting2.MessageFinal
-	11, // 13: offchainreporting2.MessageWrapper.message_final_echo:type_name -> offchainreporting2.MessageFinalEcho
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	6,  // 2: offchainreporting2.AttributedSignedObservationCommitment.signed_observation_commitment:type_name -> offchainreporting2.SignedObservationCommitment
+	6,  // 3: offchainreporting2.MessageObserveCommit.signed_observation_commitment:type_name -> offchainreporting2.SignedObservationCommitment
+	7,  // 4: offchainreporting2.MessageRevealReq.attributed_signed_observation_commitments:type_name -> offchainreporting2.AttributedSignedObservationCommitment
+	2,  // 5: offchainreporting2.MessageObserveReveal.signed_observation:type_name -> offchainreporting2.SignedObservation
+	3,  // 6: offchainreporting2.MessageReportReq.attributed_signed_observations:type_name -> offchainreporting2.AttributedSignedObservation
+	7,  // 7: offchainreporting2.MessageReportReq.attributed_signed_observation_commitments:type_name -> offchainreporting2.AttributedSignedObservationCommitment
+	14, // 8: offchainreporting2.CertifiedOutcomeState.attributed_signatures:type_name -> offchainreporting2.AttributedOutcomeSignature
+	12, // 9: offchainreporting2.MessageReport.attested_reports:type_name -> offchainreporting2.AttestedReportOne
+	13, // 10: offchainreporting2.MessageReport.signed_outcome_state:type_name -> offchainreporting2.SignedOutcomeState
+	4,  // 11: offchainreporting2.AttestedReportMany.attributed_signatures:type_name -> offchainreporting2.AttributedSignature
+	17, // 12: offchainreporting2.MessageFinal.attested_reports:type_name -> offchainreporting2.AttestedReportMany
+	15, // 13: offchainreporting2.MessageFinal.certified_outcome_state:type_name -> offchainreporting2.CertifiedOutcomeState
+	18, // 14: offchainreporting2.MessageFinalEcho.final:type_name -> offchainreporting2.MessageFinal
+	0,  // 15: offchainreporting2.MessageWrapper.message_new_epoch:type_name -> offchainreporting2.MessageNewEpoch
+	1,  // 16: offchainreporting2.MessageWrapper.message_observe_req:type_name -> offchainreporting2.MessageObserveReq
+	5,  // 17: offchainreporting2.MessageWrapper.message_observe:type_name -> offchainreporting2.MessageObserve
+	11, // 18: offchainreporting2.MessageWrapper.message_report_req:type_name -> offchainreporting2.MessageReportReq
+	16, // 19: offchainreporting2.MessageWrapper.message_report:type_name -> offchainreporting2.MessageReport
+	18, // 20: offchainreporting2.MessageWrapper.message_final:type_name -> offchainreporting2.MessageFinal
+	19, // 21: offchainreporting2.MessageWrapper.message_final_echo:type_name -> offchainreporting2.MessageFinalEcho
+	8,  // 22: offchainreporting2.MessageWrapper.message_observe_commit:type_name -> offchainreporting2.MessageObserveCommit
+	9,  // 23: offchainreporting2.MessageWrapper.message_reveal_req:type_name -> offchainreporting2.MessageRevealReq
+	10, // 24: offchainreporting2.MessageWrapper.message_observe_reveal:type_name -> offchainreporting2.MessageObserveReveal
+	25, // [25:25] is the sub-list for method output_type
+	25, // [25:25] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_offchainreporting2_messages_proto_init() }
@@ -1146,7 +1827,7 @@ func file_offchainreporting2_messages_proto_init() {
 			}
 		}
 		file_offchainreporting2_messages_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MessageReportReq); i {
+			switch v := v.(*SignedObservationCommitment); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1158,7 +1839,7 @@ func file_offchainreporting2_messages_proto_init() {
 			}
 		}
 		file_offchainreporting2_messages_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AttestedReportOne); i {
+			switch v := v.(*AttributedSignedObservationCommitment); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1170,7 +1851,7 @@ func file_offchainreporting2_messages_proto_init() {
 			}
 		}
 		file_offchainreporting2_messages_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MessageReport); i {
+			switch v := v.(*MessageObserveCommit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1182,7 +1863,7 @@ func file_offchainreporting2_messages_proto_init() {
 			}
 		}
 		file_offchainreporting2_messages_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AttestedReportMany); i {
+			switch v := v.(*MessageRevealReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1194,7 +1875,7 @@ func file_offchainreporting2_messages_proto_init() {
 			}
 		}
 		file_offchainreporting2_messages_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MessageFinal); i {
+			switch v := v.(*MessageObserveReveal); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1206,7 +1887,7 @@ func file_offchainreporting2_messages_proto_init() {
 			}
 		}
 		file_offchainreporting2_messages_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MessageFinalEcho); i {
+			switch v := v.(*MessageReportReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1218,6 +1899,102 @@ func file_offchainreporting2_messages_proto_init() {
 			}
 		}
 		file_offchainreporting2_messages_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttestedReportOne); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting2_messages_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignedOutcomeState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting2_messages_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttributedOutcomeSignature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting2_messages_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CertifiedOutcomeState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting2_messages_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MessageReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting2_messages_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AttestedReportMany); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting2_messages_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MessageFinal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting2_messages_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MessageFinalEcho); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting2_messages_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MessageWrapper); i {
 			case 0:
 				return &v.state
@@ -1230,7 +2007,7 @@ func file_offchainreporting2_messages_proto_init() {
 			}
 		}
 	}
-	file_offchainreporting2_messages_proto_msgTypes[12].OneofWrappers = []interface{}{
+	file_offchainreporting2_messages_proto_msgTypes[20].OneofWrappers = []interface{}{
 		(*MessageWrapper_MessageNewEpoch)(nil),
 		(*MessageWrapper_MessageObserveReq)(nil),
 		(*MessageWrapper_MessageObserve)(nil),
@@ -1238,6 +2015,9 @@ func file_offchainreporting2_messages_proto_init() {
 		(*MessageWrapper_MessageReport)(nil),
 		(*MessageWrapper_MessageFinal)(nil),
 		(*MessageWrapper_MessageFinalEcho)(nil),
+		(*MessageWrapper_MessageObserveCommit)(nil),
+		(*MessageWrapper_MessageRevealReq)(nil),
+		(*MessageWrapper_MessageObserveReveal)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1245,7 +2025,7 @@ func file_offchainreporting2_messages_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_offchainreporting2_messages_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
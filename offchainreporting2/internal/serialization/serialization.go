@@ -79,11 +79,53 @@ func toProtoMessage(m protocol.Message) (*MessageWrapper, error) {
 			signedObservationToProtoMessage(v.SignedObservation),
 		}
 		msgWrapper.Msg = &MessageWrapper_MessageObserve{pm}
+	case protocol.MessageObserveCommit:
+		pm := &MessageObserveCommit{
+			// zero-initialize protobuf built-ins
+			protoimpl.MessageState{},
+			0,
+			nil,
+			// fields
+			uint64(v.Epoch),
+			uint32(v.Round),
+			signedObservationCommitmentToProtoMessage(v.SignedCommitment),
+		}
+		msgWrapper.Msg = &MessageWrapper_MessageObserveCommit{pm}
+	case protocol.MessageRevealReq:
+		pm := &MessageRevealReq{
+			// zero-initialize protobuf built-ins
+			protoimpl.MessageState{},
+			0,
+			nil,
+			// fields
+			uint64(v.Epoch),
+			uint32(v.Round),
+			attributedSignedObservationCommitmentsToProtoMessage(v.AttributedSignedCommitments),
+		}
+		msgWrapper.Msg = &MessageWrapper_MessageRevealReq{pm}
+	case protocol.MessageObserveReveal:
+		pm := &MessageObserveReveal{
+			// zero-initialize protobuf built-ins
+			protoimpl.MessageState{},
+			0,
+			nil,
+			// fields
+			uint64(v.Epoch),
+			uint32(v.Round),
+			signedObservationToProtoMessage(v.SignedObservation),
+			v.Nonce[:],
+		}
+		msgWrapper.Msg = &MessageWrapper_MessageObserveReveal{pm}
 	case protocol.MessageReportReq:
 		pbasos := make([]*AttributedSignedObservation, 0, len(v.AttributedSignedObservations))
 		for _, aso := range v.AttributedSignedObservations {
 			pbasos = append(pbasos, attributedSignedObservationToProtoMessage(aso))
 		}
+		var pbnonces [][]byte
+		for _, nonce := range v.ObservationNonces {
+			nonce := nonce // have to copy or we append the same nonce over and over
+			pbnonces = append(pbnonces, nonce[:])
+		}
 		pm := &MessageReportReq{
 			// zero-initialize protobuf built-ins
 			protoimpl.MessageState{},
@@ -94,6 +136,8 @@ func toProtoMessage(m protocol.Message) (*MessageWrapper, error) {
 			uint32(v.Round),
 			v.Query,
 			pbasos,
+			pbnonces,
+			attributedSignedObservationCommitmentsToProtoMessage(v.AttributedSignedCommitments),
 		}
 		msgWrapper.Msg = &MessageWrapper_MessageReportReq{pm}
 	case protocol.MessageReport:
@@ -105,7 +149,8 @@ func toProtoMessage(m protocol.Message) (*MessageWrapper, error) {
 			// fields
 			uint64(v.Epoch),
 			uint32(v.Round),
-			attestedReportOneToProtoMessage(v.AttestedReport),
+			attestedReportsOneToProtoMessage(v.AttestedReports),
+			signedOutcomeStateToProtoMessage(v.SignedOutcomeState),
 		}
 		msgWrapper.Msg = &MessageWrapper_MessageReport{pm}
 	case protocol.MessageFinal:
@@ -140,6 +185,34 @@ func signedObservationToProtoMessage(o protocol.SignedObservation) *SignedObserv
 	}
 }
 
+func signedObservationCommitmentToProtoMessage(soc protocol.SignedObservationCommitment) *SignedObservationCommitment {
+	return &SignedObservationCommitment{
+		// zero-initialize protobuf built-ins
+		protoimpl.MessageState{},
+		0,
+		nil,
+		// fields
+		soc.Commitment[:],
+		soc.Signature,
+	}
+}
+
+func attributedSignedObservationCommitmentsToProtoMessage(asocs []protocol.AttributedSignedObservationCommitment) []*AttributedSignedObservationCommitment {
+	pbasocs := make([]*AttributedSignedObservationCommitment, 0, len(asocs))
+	for _, asoc := range asocs {
+		pbasocs = append(pbasocs, &AttributedSignedObservationCommitment{
+			// zero-initialize protobuf built-ins
+			protoimpl.MessageState{},
+			0,
+			nil,
+			// fields
+			signedObservationCommitmentToProtoMessage(asoc.SignedCommitment),
+			uint32(asoc.Observer),
+		})
+	}
+	return pbasocs
+}
+
 func attributedSignedObservationToProtoMessage(aso protocol.AttributedSignedObservation) *AttributedSignedObservation {
 	return &AttributedSignedObservation{
 		// zero-initialize protobuf built-ins
@@ -199,7 +272,60 @@ func finalToProtoMessage(v protocol.MessageFinal) *MessageFinal {
 		uint64(v.Epoch),
 		uint32(v.Round),
 		v.H[:],
-		attestedReportManyToProtoMessage(v.AttestedReport),
+		attestedReportsManyToProtoMessage(v.AttestedReports),
+		certifiedOutcomeStateToProtoMessage(v.CertifiedOutcomeState),
+	}
+}
+
+func attestedReportsOneToProtoMessage(aros []protocol.AttestedReportOne) []*AttestedReportOne {
+	pbaros := make([]*AttestedReportOne, 0, len(aros))
+	for _, aro := range aros {
+		pbaros = append(pbaros, attestedReportOneToProtoMessage(aro))
+	}
+	return pbaros
+}
+
+func attestedReportsManyToProtoMessage(arms []protocol.AttestedReportMany) []*AttestedReportMany {
+	pbarms := make([]*AttestedReportMany, 0, len(arms))
+	for _, arm := range arms {
+		pbarms = append(pbarms, attestedReportManyToProtoMessage(arm))
+	}
+	return pbarms
+}
+
+func signedOutcomeStateToProtoMessage(sos protocol.SignedOutcomeState) *SignedOutcomeState {
+	return &SignedOutcomeState{
+		// zero-initialize protobuf built-ins
+		protoimpl.MessageState{},
+		0,
+		nil,
+		// fields
+		sos.OutcomeState,
+		sos.Signature,
+	}
+}
+
+func certifiedOutcomeStateToProtoMessage(cos protocol.CertifiedOutcomeState) *CertifiedOutcomeState {
+	pbaoss := make([]*AttributedOutcomeSignature, 0, len(cos.AttributedSignatures))
+	for _, aos := range cos.AttributedSignatures {
+		pbaoss = append(pbaoss, &AttributedOutcomeSignature{
+			// zero-initialize protobuf built-ins
+			protoimpl.MessageState{},
+			0,
+			nil,
+			// fields
+			aos.Signature,
+			uint32(aos.Signer),
+		})
+	}
+	return &CertifiedOutcomeState{
+		// zero-initialize protobuf built-ins
+		protoimpl.MessageState{},
+		0,
+		nil,
+		// fields
+		cos.OutcomeState,
+		pbaoss,
 	}
 }
 
@@ -215,6 +341,12 @@ func messageWrapperFromProtoMessage(wrapper *MessageWrapper) (protocol.Message,
 		return messageObserveReqFromProtoMessage(wrapper.GetMessageObserveReq())
 	case *MessageWrapper_MessageObserve:
 		return messageObserveFromProtoMessage(wrapper.GetMessageObserve())
+	case *MessageWrapper_MessageObserveCommit:
+		return messageObserveCommitFromProtoMessage(wrapper.GetMessageObserveCommit())
+	case *MessageWrapper_MessageRevealReq:
+		return messageRevealReqFromProtoMessage(wrapper.GetMessageRevealReq())
+	case *MessageWrapper_MessageObserveReveal:
+		return messageObserveRevealFromProtoMessage(wrapper.GetMessageObserveReveal())
 	case *MessageWrapper_MessageReportReq:
 		return messageReportReqFromProtoMessage(wrapper.GetMessageReportReq())
 	case *MessageWrapper_MessageReport:
@@ -263,6 +395,56 @@ func messageObserveFromProtoMessage(m *MessageObserve) (protocol.MessageObserve,
 	}, nil
 }
 
+func messageObserveCommitFromProtoMessage(m *MessageObserveCommit) (protocol.MessageObserveCommit, error) {
+	if m == nil {
+		return protocol.MessageObserveCommit{}, fmt.Errorf("unable to extract a MessageObserveCommit value")
+	}
+	soc, err := signedObservationCommitmentFromProtoMessage(m.SignedObservationCommitment)
+	if err != nil {
+		return protocol.MessageObserveCommit{}, err
+	}
+	return protocol.MessageObserveCommit{
+		uint32(m.Epoch),
+		uint8(m.Round),
+		soc,
+	}, nil
+}
+
+func messageRevealReqFromProtoMessage(m *MessageRevealReq) (protocol.MessageRevealReq, error) {
+	if m == nil {
+		return protocol.MessageRevealReq{}, fmt.Errorf("unable to extract a MessageRevealReq value")
+	}
+	asocs, err := attributedSignedObservationCommitmentsFromProtoMessage(m.AttributedSignedObservationCommitments)
+	if err != nil {
+		return protocol.MessageRevealReq{}, err
+	}
+	return protocol.MessageRevealReq{
+		uint32(m.Epoch),
+		uint8(m.Round),
+		asocs,
+	}, nil
+}
+
+func messageObserveRevealFromProtoMessage(m *MessageObserveReveal) (protocol.MessageObserveReveal, error) {
+	if m == nil {
+		return protocol.MessageObserveReveal{}, fmt.Errorf("unable to extract a MessageObserveReveal value")
+	}
+	so, err := signedObservationFromProtoMessage(m.SignedObservation)
+	if err != nil {
+		return protocol.MessageObserveReveal{}, err
+	}
+	nonce, err := observationNonceFromProtoMessage(m.Nonce)
+	if err != nil {
+		return protocol.MessageObserveReveal{}, err
+	}
+	return protocol.MessageObserveReveal{
+		uint32(m.Epoch),
+		uint8(m.Round),
+		so,
+		nonce,
+	}, nil
+}
+
 func messageReportReqFromProtoMessage(m *MessageReportReq) (protocol.MessageReportReq, error) {
 	if m == nil {
 		return protocol.MessageReportReq{}, fmt.Errorf("unable to extract a MessageReportReq value")
@@ -271,14 +453,46 @@ func messageReportReqFromProtoMessage(m *MessageReportReq) (protocol.MessageRepo
 	if err != nil {
 		return protocol.MessageReportReq{}, err
 	}
+	var nonces []protocol.ObservationNonce
+	for _, pbnonce := range m.ObservationNonces {
+		nonce, err := observationNonceFromProtoMessage(pbnonce)
+		if err != nil {
+			return protocol.MessageReportReq{}, err
+		}
+		nonces = append(nonces, nonce)
+	}
+	asocs, err := attributedSignedObservationCommitmentsFromProtoMessage(m.AttributedSignedObservationCommitments)
+	if err != nil {
+		return protocol.MessageReportReq{}, err
+	}
 	return protocol.MessageReportReq{
 		uint32(m.Epoch),
 		uint8(m.Round),
 		m.Query,
 		asos,
+		nonces,
+		asocs,
 	}, nil
 }
 
+func attributedSignedObservationCommitmentsFromProtoMessage(pbasocs []*AttributedSignedObservationCommitment) ([]protocol.AttributedSignedObservationCommitment, error) {
+	asocs := make([]protocol.AttributedSignedObservationCommitment, 0, len(pbasocs))
+	for i, pbasoc := range pbasocs {
+		if pbasoc == nil {
+			return nil, fmt.Errorf("unable to extract an AttributedSignedObservationCommitment value because AttributedSignedObservationCommitments[%v] is nil", i)
+		}
+		soc, err := signedObservationCommitmentFromProtoMessage(pbasoc.SignedObservationCommitment)
+		if err != nil {
+			return nil, err
+		}
+		asocs = append(asocs, protocol.AttributedSignedObservationCommitment{
+			soc,
+			commontypes.OracleID(pbasoc.Observer),
+		})
+	}
+	return asocs, nil
+}
+
 func attestedReportOneFromProtoMessage(m *AttestedReportOne) (protocol.AttestedReportOne, error) {
 	if m == nil {
 		return protocol.AttestedReportOne{}, fmt.Errorf("unable to extract a AttestedReportOne value")
@@ -296,18 +510,35 @@ func messageReportFromProtoMessage(m *MessageReport) (protocol.MessageReport, er
 		return protocol.MessageReport{}, fmt.Errorf("unable to extract a MessageReport value")
 	}
 
-	report, err := attestedReportOneFromProtoMessage(m.AttestedReport)
-	if err != nil {
-		return protocol.MessageReport{}, err
+	reports := make([]protocol.AttestedReportOne, 0, len(m.AttestedReports))
+	for i, pbaro := range m.AttestedReports {
+		report, err := attestedReportOneFromProtoMessage(pbaro)
+		if err != nil {
+			return protocol.MessageReport{}, fmt.Errorf("AttestedReports[%v]: %w", i, err)
+		}
+		reports = append(reports, report)
 	}
 
 	return protocol.MessageReport{
 		uint32(m.Epoch),
 		uint8(m.Round),
-		report,
+		reports,
+		signedOutcomeStateFromProtoMessage(m.SignedOutcomeState),
 	}, nil
 }
 
+// signedOutcomeStateFromProtoMessage maps a missing SignedOutcomeState to an
+// empty one, since oracles that don't use outcome states may omit it.
+func signedOutcomeStateFromProtoMessage(m *SignedOutcomeState) protocol.SignedOutcomeState {
+	if m == nil {
+		return protocol.SignedOutcomeState{}
+	}
+	return protocol.SignedOutcomeState{
+		m.OutcomeState,
+		m.Signature,
+	}
+}
+
 func attestedReportManyFromProtoMessage(m *AttestedReportMany) (protocol.AttestedReportMany, error) {
 	if m == nil {
 		return protocol.AttestedReportMany{}, fmt.Errorf("unable to extract a AttestedReportMany value")
@@ -334,20 +565,53 @@ func messageFinalFromProtoMessage(m *MessageFinal) (protocol.MessageFinal, error
 	if m == nil {
 		return protocol.MessageFinal{}, fmt.Errorf("unable to extract a MessageFinal value")
 	}
-	report, err := attestedReportManyFromProtoMessage(m.AttestedReport)
-	if err != nil {
-		return protocol.MessageFinal{}, err
+	reports := make([]protocol.AttestedReportMany, 0, len(m.AttestedReports))
+	for i, pbarm := range m.AttestedReports {
+		report, err := attestedReportManyFromProtoMessage(pbarm)
+		if err != nil {
+			return protocol.MessageFinal{}, fmt.Errorf("AttestedReports[%v]: %w", i, err)
+		}
+		reports = append(reports, report)
 	}
 	var h [32]byte
 	if len(m.H) != len(h) {
 		return protocol.MessageFinal{}, fmt.Errorf("wrong length for MessageFinal.H. got %v but wanted %v", len(m.H), len(h))
 	}
 	copy(h[:], m.H)
+	cos, err := certifiedOutcomeStateFromProtoMessage(m.CertifiedOutcomeState)
+	if err != nil {
+		return protocol.MessageFinal{}, err
+	}
 	return protocol.MessageFinal{
 		uint32(m.Epoch),
 		uint8(m.Round),
 		h,
-		report,
+		reports,
+		cos,
+	}, nil
+}
+
+// certifiedOutcomeStateFromProtoMessage maps a missing CertifiedOutcomeState
+// to an empty one, since oracles that don't use outcome states may omit it.
+func certifiedOutcomeStateFromProtoMessage(m *CertifiedOutcomeState) (protocol.CertifiedOutcomeState, error) {
+	if m == nil {
+		return protocol.CertifiedOutcomeState{}, nil
+	}
+
+	aoss := make([]protocol.AttributedOutcomeSignature, 0, len(m.AttributedSignatures))
+	for i, aos := range m.AttributedSignatures {
+		if aos == nil {
+			return protocol.CertifiedOutcomeState{}, fmt.Errorf("unable to extract a CertifiedOutcomeState value because AttributedSignatures[%v] is nil", i)
+		}
+		aoss = append(aoss, protocol.AttributedOutcomeSignature{
+			aos.Signature,
+			commontypes.OracleID(aos.Signer),
+		})
+	}
+
+	return protocol.CertifiedOutcomeState{
+		m.OutcomeState,
+		aoss,
 	}, nil
 }
 
@@ -400,3 +664,29 @@ func signedObservationFromProtoMessage(m *SignedObservation) (protocol.SignedObs
 		m.Signature,
 	}, nil
 }
+
+func signedObservationCommitmentFromProtoMessage(m *SignedObservationCommitment) (protocol.SignedObservationCommitment, error) {
+	if m == nil {
+		return protocol.SignedObservationCommitment{}, fmt.Errorf("unable to extract a SignedObservationCommitment value")
+	}
+
+	var commitment protocol.ObservationCommitment
+	if len(m.Commitment) != len(commitment) {
+		return protocol.SignedObservationCommitment{}, fmt.Errorf("wrong length for SignedObservationCommitment.Commitment. got %v but wanted %v", len(m.Commitment), len(commitment))
+	}
+	copy(commitment[:], m.Commitment)
+
+	return protocol.SignedObservationCommitment{
+		commitment,
+		m.Signature,
+	}, nil
+}
+
+func observationNonceFromProtoMessage(b []byte) (protocol.ObservationNonce, error) {
+	var nonce protocol.ObservationNonce
+	if len(b) != len(nonce) {
+		return protocol.ObservationNonce{}, fmt.Errorf("wrong length for ObservationNonce. got %v but wanted %v", len(b), len(nonce))
+	}
+	copy(nonce[:], b)
+	return nonce, nil
+}
@@ -0,0 +1,216 @@
+// Package multiaccount implements types.MultiAccountContractTransmitter on
+// top of several single-account ContractTransmitters, one per transmit
+// account.
+//
+// To rotate an oracle's transmit account, add a ContractTransmitter for the
+// new account with AddTransmitter, fund it, and update the contract
+// configuration to list the new account. Once the oracle has enacted the new
+// configuration, it transmits from the new account and the old
+// ContractTransmitter can be removed with RemoveTransmitter.
+//
+// If all underlying ContractTransmitters implement
+// types.TransmissionResultReporter, use NewResultReportingContractTransmitter
+// to report their results, too.
+package multiaccount
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+)
+
+var _ types.MultiAccountContractTransmitter = (*ContractTransmitter)(nil)
+
+type ContractTransmitter struct {
+	// nil unless all transmitters are TransmissionResultReporters
+	chResults chan types.TransmissionResult
+
+	ctx          context.Context
+	cancel       context.CancelFunc
+	subprocesses subprocesses.Subprocesses
+
+	mutex          sync.Mutex
+	transmitters   []types.ContractTransmitter
+	active         types.ContractTransmitter
+	stopForwarding map[types.Account]context.CancelFunc
+}
+
+// NewContractTransmitter returns a ContractTransmitter that delegates to
+// whichever of transmitters transmits from the active account. Initially,
+// transmitters[0] is active.
+func NewContractTransmitter(transmitters ...types.ContractTransmitter) (*ContractTransmitter, error) {
+	return newContractTransmitter(nil, transmitters)
+}
+
+// ResultReportingContractTransmitter is a ContractTransmitter whose underlying
+// ContractTransmitters are all TransmissionResultReporters. It reports the
+// results of all of them, including those of accounts that are no longer
+// active.
+type ResultReportingContractTransmitter struct {
+	*ContractTransmitter
+}
+
+var _ types.TransmissionResultReporter = ResultReportingContractTransmitter{}
+
+// NewResultReportingContractTransmitter is like NewContractTransmitter, but
+// all transmitters (including those added later) must implement
+// types.TransmissionResultReporter. Call Close once the ContractTransmitter is
+// no longer needed.
+func NewResultReportingContractTransmitter(transmitters ...types.ContractTransmitter) (ResultReportingContractTransmitter, error) {
+	ct, err := newContractTransmitter(make(chan types.TransmissionResult), transmitters)
+	if err != nil {
+		return ResultReportingContractTransmitter{}, err
+	}
+	return ResultReportingContractTransmitter{ct}, nil
+}
+
+func (ct ResultReportingContractTransmitter) TransmissionResults() <-chan types.TransmissionResult {
+	return ct.chResults
+}
+
+func newContractTransmitter(chResults chan types.TransmissionResult, transmitters []types.ContractTransmitter) (*ContractTransmitter, error) {
+	if len(transmitters) == 0 {
+		return nil, fmt.Errorf("need at least one ContractTransmitter")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ct := &ContractTransmitter{
+		chResults,
+
+		ctx,
+		cancel,
+		subprocesses.Subprocesses{},
+
+		sync.Mutex{},
+		nil,
+		nil,
+		map[types.Account]context.CancelFunc{},
+	}
+	for _, transmitter := range transmitters {
+		if err := ct.AddTransmitter(transmitter); err != nil {
+			ct.Close()
+			return nil, err
+		}
+	}
+	// only now that all transmitters have been validated
+	ct.active = transmitters[0]
+	return ct, nil
+}
+
+// AddTransmitter adds a ContractTransmitter for a new account
+func (ct *ContractTransmitter) AddTransmitter(transmitter types.ContractTransmitter) error {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+
+	account := transmitter.FromAccount()
+	for _, t := range ct.transmitters {
+		if t.FromAccount() == account {
+			return fmt.Errorf("duplicate account %v", account)
+		}
+	}
+
+	if ct.chResults != nil {
+		reporter, ok := transmitter.(types.TransmissionResultReporter)
+		if !ok {
+			return fmt.Errorf("ContractTransmitter for account %v doesn't implement TransmissionResultReporter", account)
+		}
+		ctx, cancel := context.WithCancel(ct.ctx)
+		ct.stopForwarding[account] = cancel
+		chResults := reporter.TransmissionResults()
+		ct.subprocesses.Go(func() {
+			forwardTransmissionResults(ctx, chResults, ct.chResults)
+		})
+	}
+
+	ct.transmitters = append(ct.transmitters, transmitter)
+	return nil
+}
+
+// RemoveTransmitter removes the ContractTransmitter for account, which must
+// not be active. Results of pending transmissions from account are no longer
+// reported.
+func (ct *ContractTransmitter) RemoveTransmitter(account types.Account) error {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+
+	if ct.active.FromAccount() == account {
+		return fmt.Errorf("cannot remove ContractTransmitter for active account %v", account)
+	}
+	for i, transmitter := range ct.transmitters {
+		if transmitter.FromAccount() == account {
+			ct.transmitters = append(ct.transmitters[:i], ct.transmitters[i+1:]...)
+			if stop, ok := ct.stopForwarding[account]; ok {
+				stop()
+				delete(ct.stopForwarding, account)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("no ContractTransmitter for account %v", account)
+}
+
+// Close stops reporting transmission results. It is a no-op for
+// ContractTransmitters returned by NewContractTransmitter.
+func (ct *ContractTransmitter) Close() {
+	ct.cancel()
+	ct.subprocesses.Wait()
+}
+
+func (ct *ContractTransmitter) activeTransmitter() types.ContractTransmitter {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+	return ct.active
+}
+
+func (ct *ContractTransmitter) Transmit(ctx context.Context, repctx types.ReportContext, report types.Report, signatures []types.AttributedOnchainSignature) error {
+	return ct.activeTransmitter().Transmit(ctx, repctx, report, signatures)
+}
+
+// LatestConfigDigestAndEpoch reads from the contract and therefore doesn't
+// depend on the active account.
+func (ct *ContractTransmitter) LatestConfigDigestAndEpoch(ctx context.Context) (types.ConfigDigest, uint32, error) {
+	return ct.activeTransmitter().LatestConfigDigestAndEpoch(ctx)
+}
+
+func (ct *ContractTransmitter) FromAccount() types.Account {
+	return ct.activeTransmitter().FromAccount()
+}
+
+func (ct *ContractTransmitter) FromAccounts() []types.Account {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+	accounts := make([]types.Account, 0, len(ct.transmitters))
+	for _, transmitter := range ct.transmitters {
+		accounts = append(accounts, transmitter.FromAccount())
+	}
+	return accounts
+}
+
+func (ct *ContractTransmitter) SetActiveAccount(account types.Account) error {
+	ct.mutex.Lock()
+	defer ct.mutex.Unlock()
+	for _, transmitter := range ct.transmitters {
+		if transmitter.FromAccount() == account {
+			ct.active = transmitter
+			return nil
+		}
+	}
+	return fmt.Errorf("no ContractTransmitter for account %v", account)
+}
+
+func forwardTransmissionResults(ctx context.Context, chIn <-chan types.TransmissionResult, chOut chan<- types.TransmissionResult) {
+	for {
+		select {
+		case result := <-chIn:
+			select {
+			case chOut <- result:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package multiaccount
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// testTransmitter transmits from account. All other functions panic.
+type testTransmitter struct {
+	types.ContractTransmitter
+	account types.Account
+}
+
+func (t testTransmitter) FromAccount() types.Account {
+	return t.account
+}
+
+type testReportingTransmitter struct {
+	testTransmitter
+	chResults chan types.TransmissionResult
+}
+
+func (t testReportingTransmitter) TransmissionResults() <-chan types.TransmissionResult {
+	return t.chResults
+}
+
+func newTestReportingTransmitter(account types.Account) testReportingTransmitter {
+	return testReportingTransmitter{testTransmitter{nil, account}, make(chan types.TransmissionResult)}
+}
+
+func TestNewContractTransmitter(t *testing.T) {
+	if _, err := NewContractTransmitter(); err == nil {
+		t.Error("expected error without transmitters")
+	}
+	if _, err := NewContractTransmitter(testTransmitter{nil, "a"}, testTransmitter{nil, "a"}); err == nil {
+		t.Error("expected error for duplicate account")
+	}
+	if _, err := NewResultReportingContractTransmitter(newTestReportingTransmitter("a"), testTransmitter{nil, "b"}); err == nil {
+		t.Error("expected error for transmitter that doesn't report results")
+	}
+
+	ct, err := NewContractTransmitter(testTransmitter{nil, "a"}, testTransmitter{nil, "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ct.Close()
+	if ct.FromAccount() != "a" {
+		t.Errorf("expected first account to be active, got %v", ct.FromAccount())
+	}
+	if err := ct.RemoveTransmitter("a"); err == nil {
+		t.Error("expected error when removing active account")
+	}
+	if err := ct.SetActiveAccount("c"); err == nil {
+		t.Error("expected error when activating unknown account")
+	}
+	if err := ct.SetActiveAccount("b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ct.RemoveTransmitter("a"); err != nil {
+		t.Fatal(err)
+	}
+	if accounts := ct.FromAccounts(); len(accounts) != 1 || accounts[0] != "b" {
+		t.Errorf("expected only account b, got %v", accounts)
+	}
+}
+
+func TestResultReportingContractTransmitter(t *testing.T) {
+	a, b := newTestReportingTransmitter("a"), newTestReportingTransmitter("b")
+	ct, err := NewResultReportingContractTransmitter(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ct.Close()
+
+	// results of inactive accounts are reported, too
+	result := types.TransmissionResult{types.ReportContext{types.ReportTimestamp{types.ConfigDigest{1}, 1, 1}, [32]byte{}, 0}, true}
+	for _, transmitter := range []testReportingTransmitter{a, b} {
+		transmitter.chResults <- result
+		select {
+		case r := <-ct.TransmissionResults():
+			if r != result {
+				t.Errorf("unexpected result %v", r)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for result of account %v", transmitter.account)
+		}
+	}
+}
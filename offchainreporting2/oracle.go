@@ -7,7 +7,12 @@ import (
 
 	"github.com/smartcontractkit/libocr/commontypes"
 	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/confighistory"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/config"
 	"github.com/smartcontractkit/libocr/offchainreporting2/internal/managed"
+	"github.com/smartcontractkit/libocr/offchainreporting2/internal/protocol"
+	"github.com/smartcontractkit/libocr/offchainreporting2/pendingtransmission"
+	"github.com/smartcontractkit/libocr/offchainreporting2/transmissionschedule"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 	"github.com/smartcontractkit/libocr/subprocesses"
 )
@@ -29,11 +34,30 @@ type OracleArgs struct {
 	// Interfaces with the OCR2Aggregator smart contract's transmission related logic.
 	ContractTransmitter types.ContractTransmitter
 
-	// Database provides persistent storage.
+	// AdditionalTransmissionTargets optionally lists further contracts, e.g.
+	// on other chains, that reports are transmitted to in addition to
+	// ContractTransmitter. Each target has its own transmission schedule and
+	// TransmissionFilter, while report generation is shared. Names must be
+	// non-empty and unique, and TransmissionFilters must be non-nil.
+	AdditionalTransmissionTargets []types.TransmissionTarget
+
+	// Database provides persistent storage. If the ReportingPlugin uses
+	// outcome states, Database should also implement
+	// types.OutcomeStateDatabase. If Database implements
+	// types.ConfigHistoryDatabase, the oracle records every config it enacts.
 	Database types.Database
 
+	// If DryRunRecorder is not nil, the oracle runs in dry-run mode: The full
+	// protocol runs, including signing and finalization, but instead of being
+	// transmitted through ContractTransmitter, reports are passed to
+	// DryRunRecorder. ContractTransmitter is still used for reading from the
+	// contract. See package dryrun for implementations.
+	DryRunRecorder types.TransmissionRecorder
+
 	// LocalConfig contains oracle-specific configuration details which are not
 	// mandated by the on-chain configuration specification via OffchainAggregatoo.SetConfig.
+	// It can be changed while the oracle is running with
+	// Oracle.UpdateLocalConfig.
 	LocalConfig types.LocalConfig
 
 	// Logger logs stuff.
@@ -54,8 +78,15 @@ type OracleArgs struct {
 	OnchainKeyring types.OnchainKeyring
 
 	// ReportingPluginFactory creates ReportingPlugins that determine the
-	// "application logic" used in a OCR2 protocol instance.
+	// "application logic" used in a OCR2 protocol instance. ReportingPlugins
+	// may implement types.OutcomeStateReportingPlugin to maintain replicated
+	// state across rounds.
 	ReportingPluginFactory types.ReportingPluginFactory
+
+	// TransmissionScheduler determines when this oracle transmits reports. If
+	// nil, transmissionschedule.Staged() is used. All oracles should use the
+	// same TransmissionScheduler.
+	TransmissionScheduler types.TransmissionScheduler
 }
 
 type oracleState int
@@ -63,6 +94,7 @@ type oracleState int
 const (
 	oracleStateUnstarted oracleState = iota
 	oracleStateStarted
+	oracleStatePaused
 	oracleStateClosed
 )
 
@@ -73,11 +105,20 @@ type Oracle struct {
 
 	oracleArgs OracleArgs
 
-	// subprocesses tracks completion of all go routines on Oracle.Close()
+	// subprocesses tracks completion of all go routines on Oracle.Close() and
+	// Oracle.Pause()
 	subprocesses subprocesses.Subprocesses
 
 	// cancel sends a cancel message to all subprocesses, via a context.Context
 	cancel context.CancelFunc
+
+	// chTransmissionAdmin passes requests from PendingTransmissions and
+	// CancelPendingTransmission to the running protocol instance
+	chTransmissionAdmin chan protocol.TransmissionAdminRequest
+
+	// localConfig is shared with the running protocol instances, so that
+	// UpdateLocalConfig takes effect without restarting them
+	localConfig *config.LocalConfigHolder
 }
 
 // NewOracle returns a newly initialized Oracle using the provided services
@@ -86,12 +127,17 @@ func NewOracle(args OracleArgs) (*Oracle, error) {
 	if err := SanityCheckLocalConfig(args.LocalConfig); err != nil {
 		return nil, fmt.Errorf("bad local config while creating new oracle: %w", err)
 	}
+	if err := checkAdditionalTransmissionTargets(args.AdditionalTransmissionTargets); err != nil {
+		return nil, fmt.Errorf("bad additional transmission targets while creating new oracle: %w", err)
+	}
 	return &Oracle{
 		sync.Mutex{},
 		oracleStateUnstarted,
 		args,
 		subprocesses.Subprocesses{},
 		nil,
+		make(chan protocol.TransmissionAdminRequest),
+		config.NewLocalConfigHolder(args.LocalConfig),
 	}, nil
 }
 
@@ -105,7 +151,21 @@ func (o *Oracle) Start() error {
 	}
 	o.state = oracleStateStarted
 
+	o.run()
+	return nil
+}
+
+// run spins up the managed oracle. Must be called with o.lock held.
+func (o *Oracle) run() {
 	logger := loghelper.MakeRootLoggerWithContext(o.oracleArgs.Logger)
+	if o.oracleArgs.DryRunRecorder != nil {
+		logger.Info("Oracle: running in dry-run mode, reports will be recorded instead of transmitted", nil)
+	}
+
+	transmissionScheduler := o.oracleArgs.TransmissionScheduler
+	if transmissionScheduler == nil {
+		transmissionScheduler = transmissionschedule.Staged()
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	o.cancel = cancel
@@ -114,11 +174,15 @@ func (o *Oracle) Start() error {
 		managed.RunManagedOracle(
 			ctx,
 
+			o.chTransmissionAdmin,
+
 			o.oracleArgs.V2Bootstrappers,
 			o.oracleArgs.ContractConfigTracker,
 			o.oracleArgs.ContractTransmitter,
+			o.oracleArgs.AdditionalTransmissionTargets,
 			o.oracleArgs.Database,
-			o.oracleArgs.LocalConfig,
+			o.oracleArgs.DryRunRecorder,
+			o.localConfig,
 			logger,
 			o.oracleArgs.MonitoringEndpoint,
 			o.oracleArgs.BinaryNetworkEndpointFactory,
@@ -126,8 +190,43 @@ func (o *Oracle) Start() error {
 			o.oracleArgs.OffchainKeyring,
 			o.oracleArgs.OnchainKeyring,
 			o.oracleArgs.ReportingPluginFactory,
+			transmissionScheduler,
 		)
 	})
+}
+
+// Pause stops a started oracle for maintenance, e.g. of its RPC endpoints or
+// keys, without tearing it down. A paused oracle doesn't participate in the
+// protocol and doesn't transmit. Its network endpoint is closed, so peers see
+// it as absent rather than misbehaving. Persisted state, including pending
+// transmissions, is kept in the Database. Pause blocks until all
+// subprocesses have shut down.
+func (o *Oracle) Pause() error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if o.state != oracleStateStarted {
+		return fmt.Errorf("can only pause a started Oracle")
+	}
+	o.state = oracleStatePaused
+
+	o.cancel()
+	o.subprocesses.Wait()
+	return nil
+}
+
+// Resume restarts a paused oracle. As on Start, the oracle restores its
+// contract configuration and protocol state from the Database.
+func (o *Oracle) Resume() error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if o.state != oracleStatePaused {
+		return fmt.Errorf("can only resume a paused Oracle")
+	}
+	o.state = oracleStateStarted
+
+	o.run()
 	return nil
 }
 
@@ -136,8 +235,8 @@ func (o *Oracle) Close() error {
 	o.lock.Lock()
 	defer o.lock.Unlock()
 
-	if o.state != oracleStateStarted {
-		return fmt.Errorf("can only close a started Oracle")
+	if !(o.state == oracleStateStarted || o.state == oracleStatePaused) {
+		return fmt.Errorf("can only close a started or paused Oracle")
 	}
 	o.state = oracleStateClosed
 
@@ -149,3 +248,141 @@ func (o *Oracle) Close() error {
 	o.subprocesses.Wait()
 	return nil
 }
+
+// UpdateLocalConfig replaces the oracle's LocalConfig without restarting the
+// protocol instance, e.g. to raise BlockchainTimeout during a chain incident.
+// localConfig must pass SanityCheckLocalConfig. DevelopmentMode cannot be
+// changed.
+//
+// Timeouts and poll intervals apply to operations started after the update.
+// ContractConfigTrackerPollInterval takes effect after the currently
+// scheduled poll. ConfigHandoverWindow applies to subsequent configuration
+// changes.
+func (o *Oracle) UpdateLocalConfig(localConfig types.LocalConfig) error {
+	if err := SanityCheckLocalConfig(localConfig); err != nil {
+		return fmt.Errorf("bad local config: %w", err)
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if o.state == oracleStateClosed {
+		return fmt.Errorf("cannot update LocalConfig of closed Oracle")
+	}
+	if localConfig.DevelopmentMode != o.localConfig.Get().DevelopmentMode {
+		return fmt.Errorf("DevelopmentMode cannot be changed")
+	}
+	o.localConfig.Set(localConfig)
+	o.oracleArgs.LocalConfig = localConfig
+	return nil
+}
+
+// ConfigHistory returns the configs this oracle has enacted, ordered by
+// Sequence. It requires the Database to implement
+// types.ConfigHistoryDatabase.
+func (o *Oracle) ConfigHistory(ctx context.Context) ([]types.ConfigHistoryEntry, error) {
+	return confighistory.Read(ctx, o.oracleArgs.Database)
+}
+
+// PendingTransmissions lists the transmissions queued by the running protocol
+// instance, along with the pending transmissions persisted in the Database for
+// the same config digest. It blocks until the protocol instance processes the
+// request, so ctx should have a deadline. During a config handover (see
+// LocalConfig.ConfigHandoverWindow), the protocol instance for the newest
+// config serves the request.
+func (o *Oracle) PendingTransmissions(ctx context.Context) ([]pendingtransmission.Entry, error) {
+	chResponse := make(chan protocol.ListTransmissionQueueResponse, 1)
+	if err := o.sendTransmissionAdminRequest(ctx, protocol.ListTransmissionQueueRequest{chResponse}); err != nil {
+		return nil, err
+	}
+	var response protocol.ListTransmissionQueueResponse
+	select {
+	case response = <-chResponse:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("no running protocol instance processed the request: %w", ctx.Err())
+	}
+
+	entries, err := pendingtransmission.List(ctx, o.oracleArgs.Database, response.ConfigDigest)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading pending transmissions from database: %w", err)
+	}
+	persisted := map[types.PendingTransmissionKey]int{}
+	for i, entry := range entries {
+		persisted[entry.Key] = i
+	}
+	for _, item := range response.Items {
+		if i, ok := persisted[item.PendingTransmissionKey]; ok {
+			// the queued version is authoritative, e.g. if persisting an
+			// update is still in progress
+			entries[i].Transmission = item.PendingTransmission
+			entries[i].Queued = true
+		} else {
+			entries = append(entries, pendingtransmission.Entry{
+				item.PendingTransmissionKey,
+				item.PendingTransmission,
+				true,
+				false,
+			})
+		}
+	}
+	pendingtransmission.Sort(entries)
+	return entries, nil
+}
+
+// CancelPendingTransmission removes a pending transmission from the running
+// protocol instance's queue and from the Database. Like PendingTransmissions,
+// it blocks until the protocol instance processes the request. During a config
+// handover, the request goes to the instance for key's config digest.
+func (o *Oracle) CancelPendingTransmission(ctx context.Context, key types.PendingTransmissionKey) error {
+	chResponse := make(chan bool, 1)
+	if err := o.sendTransmissionAdminRequest(ctx, protocol.CancelQueuedTransmissionRequest{key, chResponse}); err != nil {
+		return err
+	}
+	select {
+	case <-chResponse:
+	case <-ctx.Done():
+		return fmt.Errorf("no running protocol instance processed the request: %w", ctx.Err())
+	}
+	// the transmission may have been persisted without being queued, e.g.
+	// because it belongs to an earlier config
+	if err := pendingtransmission.Cancel(ctx, o.oracleArgs.Database, key); err != nil {
+		return fmt.Errorf("error while deleting pending transmission from database: %w", err)
+	}
+	return nil
+}
+
+func (o *Oracle) sendTransmissionAdminRequest(ctx context.Context, req protocol.TransmissionAdminRequest) error {
+	o.lock.Lock()
+	state := o.state
+	o.lock.Unlock()
+	if state != oracleStateStarted {
+		return fmt.Errorf("Oracle is not running")
+	}
+
+	select {
+	case o.chTransmissionAdmin <- req:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("no running protocol instance processed the request: %w", ctx.Err())
+	}
+}
+
+func checkAdditionalTransmissionTargets(targets []types.TransmissionTarget) error {
+	names := map[string]bool{}
+	for i, target := range targets {
+		if target.Name == "" {
+			return fmt.Errorf("target %v has empty name", i)
+		}
+		if names[target.Name] {
+			return fmt.Errorf("duplicate target name %q", target.Name)
+		}
+		names[target.Name] = true
+		if target.ContractTransmitter == nil {
+			return fmt.Errorf("target %q has nil ContractTransmitter", target.Name)
+		}
+		if target.TransmissionFilter == nil {
+			return fmt.Errorf("target %q has nil TransmissionFilter", target.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package pendingtransmission
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+const commandUsage = `usage:
+  list <configDigest>
+      list the pending transmissions persisted for configDigest
+  cancel [-target name] <configDigest> <epoch> <round> [<reportIndex>]
+      delete a pending transmission. reportIndex defaults to 0, target to the
+      primary target.
+`
+
+// RunCommand implements a command line interface for List and Cancel against
+// database. Since this library doesn't implement types.Database, applications
+// embed RunCommand in their own binaries, e.g. as a subcommand, passing the
+// remaining command line arguments as args. Output is written to out.
+func RunCommand(ctx context.Context, database types.Database, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(out, commandUsage)
+		return fmt.Errorf("missing subcommand")
+	}
+
+	switch args[0] {
+	case "list":
+		if len(args) != 2 {
+			fmt.Fprint(out, commandUsage)
+			return fmt.Errorf("list expects exactly one argument")
+		}
+		configDigest, err := parseConfigDigest(args[1])
+		if err != nil {
+			return err
+		}
+		entries, err := List(ctx, database, configDigest)
+		if err != nil {
+			return fmt.Errorf("error while listing pending transmissions: %w", err)
+		}
+		return WriteTable(out, entries)
+	case "cancel":
+		flags := flag.NewFlagSet("cancel", flag.ContinueOnError)
+		flags.SetOutput(out)
+		target := flags.String("target", "", "name of the transmission target, empty for the primary target")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		key, err := parseKey(flags.Args(), *target)
+		if err != nil {
+			fmt.Fprint(out, commandUsage)
+			return err
+		}
+		if err := Cancel(ctx, database, key); err != nil {
+			return fmt.Errorf("error while cancelling pending transmission: %w", err)
+		}
+		fmt.Fprintf(out, "cancelled pending transmission for epoch %v, round %v, report index %v, target %q\n", key.Epoch, key.Round, key.ReportIndex, key.Target)
+		return nil
+	}
+
+	fmt.Fprint(out, commandUsage)
+	return fmt.Errorf("unknown subcommand %q", args[0])
+}
+
+// WriteTable writes entries to out as a human-readable table
+func WriteTable(out io.Writer, entries []Entry) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "EPOCH\tROUND\tINDEX\tTARGET\tSCHEDULED\tQUEUED\tPERSISTED\tSIGNERS")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%q\t%v\t%v\t%v\t%v\n",
+			e.Key.Epoch,
+			e.Key.Round,
+			e.Key.ReportIndex,
+			e.Key.Target,
+			e.Transmission.Time.Format(time.RFC3339),
+			e.Queued,
+			e.Persisted,
+			e.Signers(),
+		)
+	}
+	return w.Flush()
+}
+
+func parseConfigDigest(s string) (types.ConfigDigest, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return types.ConfigDigest{}, fmt.Errorf("configDigest is not hex: %w", err)
+	}
+	configDigest, err := types.BytesToConfigDigest(b)
+	if err != nil {
+		return types.ConfigDigest{}, fmt.Errorf("invalid configDigest: %w", err)
+	}
+	return configDigest, nil
+}
+
+func parseKey(args []string, target string) (types.PendingTransmissionKey, error) {
+	if !(len(args) == 3 || len(args) == 4) {
+		return types.PendingTransmissionKey{}, fmt.Errorf("cancel expects three or four arguments")
+	}
+	configDigest, err := parseConfigDigest(args[0])
+	if err != nil {
+		return types.PendingTransmissionKey{}, err
+	}
+	epoch, err := strconv.ParseUint(args[1], 10, 32)
+	if err != nil {
+		return types.PendingTransmissionKey{}, fmt.Errorf("invalid epoch: %w", err)
+	}
+	round, err := strconv.ParseUint(args[2], 10, 8)
+	if err != nil {
+		return types.PendingTransmissionKey{}, fmt.Errorf("invalid round: %w", err)
+	}
+	var reportIndex uint64
+	if len(args) == 4 {
+		reportIndex, err = strconv.ParseUint(args[3], 10, 8)
+		if err != nil {
+			return types.PendingTransmissionKey{}, fmt.Errorf("invalid reportIndex: %w", err)
+		}
+	}
+	return types.PendingTransmissionKey{
+		types.ReportTimestamp{configDigest, uint32(epoch), uint8(round)},
+		uint8(reportIndex),
+		target,
+	}, nil
+}
@@ -0,0 +1,80 @@
+// Package pendingtransmission helps operators inspect and cancel pending
+// transmissions, e.g. when a node keeps trying to transmit a stale report.
+//
+// Oracle.PendingTransmissions and Oracle.CancelPendingTransmission act on a
+// running oracle. The functions in this package act directly on a
+// types.Database and can also be used while the oracle is stopped. Note that
+// a running oracle only reads pending transmissions from the Database on
+// startup and after configuration changes, so cancelling a pending
+// transmission in the Database alone doesn't affect the oracle's in-memory
+// transmission queue.
+package pendingtransmission
+
+import (
+	"context"
+	"sort"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// Entry describes a pending transmission
+type Entry struct {
+	Key          types.PendingTransmissionKey
+	Transmission types.PendingTransmission
+	// Queued is true if the transmission is in the in-memory queue of a
+	// running oracle.
+	Queued bool
+	// Persisted is true if the transmission is stored in the Database.
+	Persisted bool
+}
+
+// Signers returns the oracles whose signatures are attached to the report
+func (e Entry) Signers() []commontypes.OracleID {
+	signers := make([]commontypes.OracleID, 0, len(e.Transmission.AttributedSignatures))
+	for _, as := range e.Transmission.AttributedSignatures {
+		signers = append(signers, as.Signer)
+	}
+	return signers
+}
+
+// List returns the pending transmissions persisted in database for
+// configDigest, ordered by scheduled transmission time.
+func List(ctx context.Context, database types.Database, configDigest types.ConfigDigest) ([]Entry, error) {
+	pending, err := database.PendingTransmissionsWithConfigDigest(ctx, configDigest)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(pending))
+	for key, transmission := range pending {
+		entries = append(entries, Entry{key, transmission, false, true})
+	}
+	Sort(entries)
+	return entries, nil
+}
+
+// Cancel deletes a pending transmission from database
+func Cancel(ctx context.Context, database types.Database, key types.PendingTransmissionKey) error {
+	return database.DeletePendingTransmission(ctx, key)
+}
+
+// Sort orders entries by scheduled transmission time, breaking ties by
+// (epoch, round, report index, target).
+func Sort(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if !a.Transmission.Time.Equal(b.Transmission.Time) {
+			return a.Transmission.Time.Before(b.Transmission.Time)
+		}
+		if a.Key.Epoch != b.Key.Epoch {
+			return a.Key.Epoch < b.Key.Epoch
+		}
+		if a.Key.Round != b.Key.Round {
+			return a.Key.Round < b.Key.Round
+		}
+		if a.Key.ReportIndex != b.Key.ReportIndex {
+			return a.Key.ReportIndex < b.Key.ReportIndex
+		}
+		return a.Key.Target < b.Key.Target
+	})
+}
@@ -0,0 +1,616 @@
+// Package conformance checks ReportingPluginFactories for the robustness
+// properties that the documentation of types.ReportingPlugin asks for. Use it
+// from a plugin's tests:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Run(t, factory, conformance.Options{Configuration: configuration})
+//	}
+//
+// The checks exercise a plugin in the ways the protocol may legitimately
+// exercise it (skipped epochs and rounds, missing own observation, out of
+// order calls, ...) as well as in the ways faulty oracles may (malformed and
+// oversized queries, observations, and reports). Plugins may return errors in
+// response to bad inputs, but they must not panic, block, exceed their
+// ReportingPluginLimits, or produce reports when all observations are
+// garbage.
+//
+// Each check gets a fresh ReportingPlugin instance from the factory, so the
+// factory's dependencies (data sources, contracts, ...) should be fakes that
+// behave deterministically.
+package conformance
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+const (
+	defaultMaxCallDuration                   = 10 * time.Second
+	defaultMaxCallDurationAfterContextExpiry = 100 * time.Millisecond
+
+	// Number of random malformed inputs tried per check.
+	fuzzIterations = 20
+)
+
+type Options struct {
+	// Configuration is passed to ReportingPluginFactory.NewReportingPlugin.
+	// It should be a valid configuration for the plugin, including
+	// OffchainConfig and OnchainConfig. Configuration.N and Configuration.F
+	// must be set.
+	Configuration types.ReportingPluginConfig
+	// MaxCallDuration bounds the duration of every call to the plugin with a
+	// live context. Defaults to 10s.
+	MaxCallDuration time.Duration
+	// MaxCallDurationAfterContextExpiry bounds the duration of calls to the
+	// plugin with an expired context. Defaults to 100ms.
+	MaxCallDurationAfterContextExpiry time.Duration
+	// Seed for generating malformed inputs.
+	Seed int64
+}
+
+// Run runs all checks against factory, each as a subtest of t.
+func Run(t *testing.T, factory types.ReportingPluginFactory, options Options) {
+	t.Helper()
+
+	if !(0 < options.Configuration.N && 0 <= options.Configuration.F && options.Configuration.F < options.Configuration.N) {
+		t.Fatalf("invalid Options.Configuration: need 0 <= F (%v) < N (%v)", options.Configuration.F, options.Configuration.N)
+	}
+	if options.MaxCallDuration == 0 {
+		options.MaxCallDuration = defaultMaxCallDuration
+	}
+	if options.MaxCallDurationAfterContextExpiry == 0 {
+		options.MaxCallDurationAfterContextExpiry = defaultMaxCallDurationAfterContextExpiry
+	}
+
+	checks := []struct {
+		name  string
+		check func(c *checker)
+	}{
+		{"Info", checkInfo},
+		{"HappyPath", checkHappyPath},
+		{"SkippedEpochsAndRounds", checkSkippedEpochsAndRounds},
+		{"MissingOwnObservation", checkMissingOwnObservation},
+		{"MalformedQueries", checkMalformedQueries},
+		{"MalformedObservations", checkMalformedObservations},
+		{"OutOfOrderShouldAcceptFinalizedReport", checkOutOfOrderShouldAcceptFinalizedReport},
+		{"NeverSeenReports", checkNeverSeenReports},
+		{"ExpiredContext", checkExpiredContext},
+		{"DoubleClose", checkDoubleClose},
+	}
+	for _, check := range checks {
+		check := check
+		t.Run(check.name, func(t *testing.T) {
+			c := &checker{
+				t,
+				factory,
+				options,
+				rand.New(rand.NewSource(options.Seed)),
+				nil,
+				types.ReportingPluginInfo{},
+			}
+			c.newPlugin()
+			defer c.close()
+			check.check(c)
+		})
+	}
+}
+
+type checker struct {
+	t       *testing.T
+	factory types.ReportingPluginFactory
+	options Options
+	rng     *rand.Rand
+
+	// set by newPlugin
+	plugin types.ReportingPlugin
+	info   types.ReportingPluginInfo
+}
+
+func (c *checker) newPlugin() {
+	c.t.Helper()
+	plugin, info, err := c.factory.NewReportingPlugin(c.options.Configuration)
+	if err != nil {
+		c.t.Fatalf("NewReportingPlugin failed: %v", err)
+	}
+	c.plugin = plugin
+	c.info = info
+}
+
+func (c *checker) close() {
+	c.t.Helper()
+	if err, ok := call(c, "Close", context.Background(), func(_ context.Context, plugin types.ReportingPlugin) error {
+		return plugin.Close()
+	}); ok && err != nil {
+		c.t.Logf("Close returned error: %v", err)
+	}
+}
+
+type result[T any] struct {
+	value T
+	err   error
+}
+
+type reportResult struct {
+	should bool
+	report types.Report
+	err    error
+}
+
+// call runs f against the current plugin, failing the test if f panics or
+// doesn't return in time. Returns false if f didn't complete normally. f
+// returns its results instead of writing them to captured variables, since it
+// keeps running in the background once call gives up on it.
+func call[T any](c *checker, name string, ctx context.Context, f func(ctx context.Context, plugin types.ReportingPlugin) T) (T, bool) {
+	c.t.Helper()
+	var zero T
+	plugin := c.plugin
+
+	maxDuration := c.options.MaxCallDuration
+	if ctx.Err() != nil {
+		maxDuration = c.options.MaxCallDurationAfterContextExpiry
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.options.MaxCallDuration)
+	defer cancel()
+
+	type outcome struct {
+		value    T
+		panicked bool
+		panic    interface{}
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		panicked := true
+		defer func() {
+			if panicked {
+				done <- outcome{zero, true, recover()}
+			}
+		}()
+		value := f(ctx, plugin)
+		panicked = false
+		done <- outcome{value, false, nil}
+	}()
+
+	timer := time.NewTimer(maxDuration)
+	defer timer.Stop()
+	select {
+	case o := <-done:
+		if o.panicked {
+			c.t.Errorf("%v panicked: %v", name, o.panic)
+			return zero, false
+		}
+		return o.value, true
+	case <-timer.C:
+		c.t.Errorf("%v didn't return within %v", name, maxDuration)
+		return zero, false
+	}
+}
+
+func (c *checker) query(ctx context.Context, repts types.ReportTimestamp) (types.Query, bool) {
+	c.t.Helper()
+	r, ok := call(c, "Query", ctx, func(ctx context.Context, plugin types.ReportingPlugin) result[types.Query] {
+		query, err := plugin.Query(ctx, repts)
+		return result[types.Query]{query, err}
+	})
+	if !ok {
+		return nil, false
+	}
+	query, err := r.value, r.err
+	if err != nil {
+		c.t.Logf("Query(%v) returned error: %v", repts, err)
+		return nil, false
+	}
+	if !(len(query) <= c.info.Limits.MaxQueryLength) {
+		c.t.Errorf("Query(%v) violates MaxQueryLength (%v vs %v)", repts, len(query), c.info.Limits.MaxQueryLength)
+	}
+	return query, true
+}
+
+func (c *checker) observation(ctx context.Context, repts types.ReportTimestamp, query types.Query) (types.Observation, bool) {
+	c.t.Helper()
+	r, ok := call(c, "Observation", ctx, func(ctx context.Context, plugin types.ReportingPlugin) result[types.Observation] {
+		observation, err := plugin.Observation(ctx, repts, query)
+		return result[types.Observation]{observation, err}
+	})
+	if !ok {
+		return nil, false
+	}
+	observation, err := r.value, r.err
+	if err != nil {
+		c.t.Logf("Observation(%v) returned error: %v", repts, err)
+		return nil, false
+	}
+	if !(len(observation) <= c.info.Limits.MaxObservationLength) {
+		c.t.Errorf("Observation(%v) violates MaxObservationLength (%v vs %v)", repts, len(observation), c.info.Limits.MaxObservationLength)
+	}
+	return observation, true
+}
+
+func (c *checker) report(ctx context.Context, repts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) (types.Report, bool) {
+	c.t.Helper()
+	r, ok := call(c, "Report", ctx, func(ctx context.Context, plugin types.ReportingPlugin) reportResult {
+		should, report, err := plugin.Report(ctx, repts, query, aos)
+		return reportResult{should, report, err}
+	})
+	if !ok {
+		return nil, false
+	}
+	should, report, err := r.should, r.report, r.err
+	if err != nil {
+		c.t.Logf("Report(%v) returned error: %v", repts, err)
+		return nil, false
+	}
+	if !should {
+		return nil, false
+	}
+	if !(len(report) <= c.info.Limits.MaxReportLength) {
+		c.t.Errorf("Report(%v) violates MaxReportLength (%v vs %v)", repts, len(report), c.info.Limits.MaxReportLength)
+	}
+	return report, true
+}
+
+func (c *checker) shouldAccept(ctx context.Context, repts types.ReportTimestamp, report types.Report) (bool, bool) {
+	c.t.Helper()
+	r, ok := call(c, "ShouldAcceptFinalizedReport", ctx, func(ctx context.Context, plugin types.ReportingPlugin) result[bool] {
+		should, err := plugin.ShouldAcceptFinalizedReport(ctx, repts, report)
+		return result[bool]{should, err}
+	})
+	if !ok {
+		return false, false
+	}
+	should, err := r.value, r.err
+	if err != nil {
+		c.t.Logf("ShouldAcceptFinalizedReport(%v) returned error: %v", repts, err)
+		return false, false
+	}
+	return should, true
+}
+
+func (c *checker) shouldTransmit(ctx context.Context, repts types.ReportTimestamp, report types.Report) (bool, bool) {
+	c.t.Helper()
+	r, ok := call(c, "ShouldTransmitAcceptedReport", ctx, func(ctx context.Context, plugin types.ReportingPlugin) result[bool] {
+		should, err := plugin.ShouldTransmitAcceptedReport(ctx, repts, report)
+		return result[bool]{should, err}
+	})
+	if !ok {
+		return false, false
+	}
+	should, err := r.value, r.err
+	if err != nil {
+		c.t.Logf("ShouldTransmitAcceptedReport(%v) returned error: %v", repts, err)
+		return false, false
+	}
+	return should, true
+}
+
+// round runs a complete round in which every oracle's observation equals our
+// own. Returns the query, our observation, and the report, as far as they were
+// produced.
+func (c *checker) round(repts types.ReportTimestamp) (query types.Query, observation types.Observation, report types.Report, ok bool) {
+	c.t.Helper()
+	ctx := context.Background()
+
+	query, ok = c.query(ctx, repts)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	observation, ok = c.observation(ctx, repts, query)
+	if !ok {
+		return query, nil, nil, false
+	}
+	report, ok = c.report(ctx, repts, query, c.unanimous(observation))
+	if !ok {
+		return query, observation, nil, false
+	}
+	return query, observation, report, true
+}
+
+func (c *checker) unanimous(observation types.Observation) []types.AttributedObservation {
+	aos := make([]types.AttributedObservation, 0, c.options.Configuration.N)
+	for i := 0; i < c.options.Configuration.N; i++ {
+		aos = append(aos, types.AttributedObservation{Observation: observation, Observer: commontypes.OracleID(i)})
+	}
+	return aos
+}
+
+// garbage returns random bytes of length between 1 and maxLength. Unlike
+// randomBytes, it never returns an empty byte string, which a plugin might
+// legitimately use as an observation.
+func (c *checker) garbage(maxLength int) []byte {
+	if maxLength < 1 {
+		maxLength = 1
+	}
+	b := make([]byte, 1+c.rng.Intn(maxLength))
+	c.rng.Read(b)
+	return b
+}
+
+func (c *checker) randomBytes(maxLength int) []byte {
+	if maxLength < 0 {
+		maxLength = 0
+	}
+	b := make([]byte, c.rng.Intn(maxLength+1))
+	c.rng.Read(b)
+	return b
+}
+
+// oversized returns a byte string exceeding limit, capped to keep tests
+// reasonably fast for plugins with huge limits.
+func (c *checker) oversized(limit int) []byte {
+	const maxOversizedLength = 1024 * 1024
+	if !(limit < maxOversizedLength) {
+		return nil
+	}
+	b := make([]byte, limit+1)
+	c.rng.Read(b)
+	return b
+}
+
+func timestamp(epoch uint32, round uint8) types.ReportTimestamp {
+	return types.ReportTimestamp{ConfigDigest: types.ConfigDigest{}, Epoch: epoch, Round: round}
+}
+
+func checkInfo(c *checker) {
+	limits := c.info.Limits
+	for _, l := range []struct {
+		name   string
+		value  int
+		maxMax int
+	}{
+		{"MaxQueryLength", limits.MaxQueryLength, types.MaxMaxQueryLength},
+		{"MaxObservationLength", limits.MaxObservationLength, types.MaxMaxObservationLength},
+		{"MaxReportLength", limits.MaxReportLength, types.MaxMaxReportLength},
+	} {
+		if !(0 <= l.value && l.value <= l.maxMax) {
+			c.t.Errorf("%v (%v) out of range, must be between 0 and %v", l.name, l.value, l.maxMax)
+		}
+	}
+	if c.info.Name == "" {
+		c.t.Errorf("Name is empty")
+	}
+}
+
+func checkHappyPath(c *checker) {
+	repts := timestamp(1, 1)
+	_, _, report, ok := c.round(repts)
+	if !ok {
+		c.t.Logf("no report produced, skipping rest of check")
+		return
+	}
+	accept, ok := c.shouldAccept(context.Background(), repts, report)
+	if ok && accept {
+		c.shouldTransmit(context.Background(), repts, report)
+	}
+}
+
+func checkSkippedEpochsAndRounds(c *checker) {
+	for _, repts := range []types.ReportTimestamp{
+		timestamp(1, 1),
+		timestamp(1, 2),
+		timestamp(1, 200),
+		timestamp(2, 1),
+		timestamp(1000, 7),
+		timestamp(1000, math.MaxUint8),
+		timestamp(math.MaxUint32-1, 1),
+		timestamp(math.MaxUint32, math.MaxUint8),
+	} {
+		_, _, report, ok := c.round(repts)
+		if ok {
+			accept, ok := c.shouldAccept(context.Background(), repts, report)
+			if ok && accept {
+				c.shouldTransmit(context.Background(), repts, report)
+			}
+		}
+	}
+}
+
+func checkMissingOwnObservation(c *checker) {
+	ctx := context.Background()
+	own := c.options.Configuration.OracleID
+	repts := timestamp(1, 1)
+	query, ok := c.query(ctx, repts)
+	if !ok {
+		c.t.Logf("no query produced, skipping check")
+		return
+	}
+	observation, ok := c.observation(ctx, repts, query)
+	if !ok {
+		c.t.Logf("no observation produced, skipping check")
+		return
+	}
+
+	var withoutOwn []types.AttributedObservation
+	for _, ao := range c.unanimous(observation) {
+		if ao.Observer != own {
+			withoutOwn = append(withoutOwn, ao)
+		}
+	}
+	c.report(ctx, repts, query, withoutOwn)
+
+	// Report without any observations, also without a prior call to
+	// Observation
+	repts = timestamp(2, 1)
+	if query, ok := c.query(ctx, repts); ok {
+		c.report(ctx, repts, query, nil)
+	}
+}
+
+func checkMalformedQueries(c *checker) {
+	ctx := context.Background()
+
+	// a valid observation for use with malformed queries
+	_, observation, _, _ := c.round(timestamp(1, 1))
+
+	for i := 0; i < fuzzIterations; i++ {
+		repts := timestamp(2, uint8(i+1))
+		query := types.Query(c.randomBytes(c.info.Limits.MaxQueryLength))
+		c.observation(ctx, repts, query)
+		if observation != nil {
+			c.report(ctx, repts, query, c.unanimous(observation))
+		}
+	}
+
+	if oversized := c.oversized(c.info.Limits.MaxQueryLength); oversized != nil {
+		repts := timestamp(3, 1)
+		c.observation(ctx, repts, oversized)
+		if observation != nil {
+			c.report(ctx, repts, oversized, c.unanimous(observation))
+		}
+	}
+}
+
+func checkMalformedObservations(c *checker) {
+	ctx := context.Background()
+	n := c.options.Configuration.N
+	f := c.options.Configuration.F
+
+	var round uint8
+	for i := 0; i < fuzzIterations; i++ {
+		round++
+		repts := timestamp(1, round)
+		query, ok := c.query(ctx, repts)
+		if !ok {
+			c.t.Logf("no query produced, skipping check")
+			return
+		}
+
+		// all observations are garbage
+		garbage := make([]types.AttributedObservation, 0, n)
+		for j := 0; j < n; j++ {
+			garbage = append(garbage, types.AttributedObservation{
+				Observation: types.Observation(c.garbage(c.info.Limits.MaxObservationLength)),
+				Observer:    commontypes.OracleID(j),
+			})
+		}
+		if _, ok := c.report(ctx, repts, query, garbage); ok {
+			c.t.Errorf("Report(%v) produced a report although all observations are garbage", repts)
+		}
+
+		// f garbage observations, the rest valid
+		observation, ok := c.observation(ctx, repts, query)
+		if !ok {
+			continue
+		}
+		mixed := c.unanimous(observation)
+		for _, j := range c.rng.Perm(n)[:f] {
+			mixed[j].Observation = types.Observation(c.randomBytes(c.info.Limits.MaxObservationLength))
+		}
+		c.report(ctx, repts, query, mixed)
+	}
+
+	round++
+	repts := timestamp(1, round)
+	query, ok := c.query(ctx, repts)
+	if !ok {
+		return
+	}
+	observation, ok := c.observation(ctx, repts, query)
+	if !ok {
+		return
+	}
+
+	// duplicate observers
+	duplicated := c.unanimous(observation)
+	duplicated = append(duplicated, duplicated...)
+	c.report(ctx, repts, query, duplicated)
+
+	// empty and oversized observations
+	aos := c.unanimous(observation)
+	for j := 0; j < f && j < len(aos); j++ {
+		if j%2 == 0 {
+			aos[j].Observation = types.Observation{}
+		} else {
+			aos[j].Observation = c.oversized(c.info.Limits.MaxObservationLength)
+		}
+	}
+	c.report(ctx, repts, query, aos)
+}
+
+func checkOutOfOrderShouldAcceptFinalizedReport(c *checker) {
+	var reports []types.Report
+	var timestamps []types.ReportTimestamp
+	for round := uint8(1); round <= 5; round++ {
+		repts := timestamp(10, round)
+		if _, _, report, ok := c.round(repts); ok {
+			reports = append(reports, report)
+			timestamps = append(timestamps, repts)
+		}
+	}
+	if len(reports) == 0 {
+		c.t.Logf("no report produced, skipping check")
+		return
+	}
+
+	ctx := context.Background()
+	for i := len(reports) - 1; i >= 0; i-- {
+		c.shouldAccept(ctx, timestamps[i], reports[i])
+	}
+	for i := range reports {
+		c.shouldAccept(ctx, timestamps[i], reports[i])
+	}
+	// epochs and rounds from before any other call
+	c.shouldAccept(ctx, timestamp(1, 1), reports[0])
+	c.shouldAccept(ctx, timestamp(0, 0), reports[0])
+	for i := len(reports) - 1; i >= 0; i-- {
+		c.shouldTransmit(ctx, timestamps[i], reports[i])
+	}
+}
+
+func checkNeverSeenReports(c *checker) {
+	ctx := context.Background()
+
+	// a valid report from a different instance
+	_, _, report, haveReport := c.round(timestamp(1, 1))
+	c.close()
+	c.newPlugin()
+
+	if haveReport {
+		c.shouldTransmit(ctx, timestamp(1, 1), report)
+		c.shouldTransmit(ctx, timestamp(1000, 1), report)
+	}
+
+	// garbage reports
+	c.shouldTransmit(ctx, timestamp(1, 1), nil)
+	c.shouldTransmit(ctx, timestamp(1, 1), types.Report{})
+	for i := 0; i < fuzzIterations; i++ {
+		garbage := types.Report(c.randomBytes(c.info.Limits.MaxReportLength))
+		c.shouldTransmit(ctx, timestamp(1, uint8(i)), garbage)
+		c.shouldAccept(ctx, timestamp(2, uint8(i)), garbage)
+	}
+	if oversized := c.oversized(c.info.Limits.MaxReportLength); oversized != nil {
+		c.shouldTransmit(ctx, timestamp(3, 1), oversized)
+		c.shouldAccept(ctx, timestamp(3, 1), oversized)
+	}
+}
+
+func checkExpiredContext(c *checker) {
+	// Get valid inputs with a live context first.
+	repts := timestamp(1, 1)
+	query, observation, report, _ := c.round(repts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Errors are expected here, we only care that the calls return in time.
+	repts = timestamp(2, 1)
+	c.query(ctx, repts)
+	c.observation(ctx, repts, query)
+	if observation != nil {
+		c.report(ctx, repts, query, c.unanimous(observation))
+	}
+	if report != nil {
+		c.shouldAccept(ctx, repts, report)
+		c.shouldTransmit(ctx, repts, report)
+	}
+}
+
+func checkDoubleClose(c *checker) {
+	c.round(timestamp(1, 1))
+	c.close()
+	// second Close happens in Run
+}
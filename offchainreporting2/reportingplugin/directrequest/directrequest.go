@@ -0,0 +1,423 @@
+// Package directrequest implements a ReportingPlugin for the direct-request
+// pattern: Users make requests (typically by emitting a log from a contract),
+// the oracles execute them, agree on the responses, and report batches of
+// fulfilled requests back to the contract.
+//
+// Where requests come from, how they are executed, how reports are encoded
+// and how fulfillments are tracked is pluggable through RequestSource,
+// Executor, ReportCodec, and FulfillmentDatabase respectively.
+package directrequest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+	"google.golang.org/protobuf/proto"
+)
+
+// We ask the RequestSource for this many times MaxBatchSize requests, since
+// some of them might already be pending locally.
+const pendingRequestsOverfetchFactor = 4
+
+// Generous upper bound on the protobuf overhead per response and per
+// observation.
+const protobufOverhead = 16
+
+var _ types.ReportingPluginFactory = DirectRequestFactory{}
+
+type DirectRequestFactory struct {
+	Database      FulfillmentDatabase
+	Executor      Executor
+	Logger        commontypes.Logger
+	ReportCodec   ReportCodec
+	RequestSource RequestSource
+
+	// MaxBatchSize is the maximum number of requests fulfilled by a single
+	// report.
+	MaxBatchSize int
+	// MaxResponseLength is the maximum length of a response returned by
+	// Executor. Longer responses are dropped.
+	MaxResponseLength int
+	// PendingDuration is the duration for which a request contained in an
+	// accepted report is considered pending. A pending request is not
+	// observed again. If the request still hasn't been fulfilled on-chain
+	// after PendingDuration, it will be observed again.
+	PendingDuration time.Duration
+}
+
+func (fac DirectRequestFactory) NewReportingPlugin(configuration types.ReportingPluginConfig) (types.ReportingPlugin, types.ReportingPluginInfo, error) {
+	if !(0 < fac.MaxBatchSize) {
+		return nil, types.ReportingPluginInfo{}, fmt.Errorf("MaxBatchSize (%v) must be positive", fac.MaxBatchSize)
+	}
+	if !(0 <= fac.MaxResponseLength) {
+		return nil, types.ReportingPluginInfo{}, fmt.Errorf("MaxResponseLength (%v) must not be negative", fac.MaxResponseLength)
+	}
+	if !(0 < fac.PendingDuration) {
+		return nil, types.ReportingPluginInfo{}, fmt.Errorf("PendingDuration (%v) must be positive", fac.PendingDuration)
+	}
+
+	maxObservationLength := fac.MaxBatchSize*(len(RequestID{})+fac.MaxResponseLength+protobufOverhead) + protobufOverhead
+	if !(0 < maxObservationLength && maxObservationLength <= types.MaxMaxObservationLength) {
+		return nil, types.ReportingPluginInfo{}, fmt.Errorf("MaxBatchSize (%v) and MaxResponseLength (%v) result in MaxObservationLength (%v) exceeding %v",
+			fac.MaxBatchSize, fac.MaxResponseLength, maxObservationLength, types.MaxMaxObservationLength)
+	}
+	maxReportLength := fac.ReportCodec.MaxReportLength(fac.MaxBatchSize, fac.MaxResponseLength)
+	if !(0 < maxReportLength && maxReportLength <= types.MaxMaxReportLength) {
+		return nil, types.ReportingPluginInfo{}, fmt.Errorf("MaxReportLength (%v) returned by ReportCodec out of range, must be between 1 and %v",
+			maxReportLength, types.MaxMaxReportLength)
+	}
+
+	logger := loghelper.MakeRootLoggerWithContext(fac.Logger).MakeChild(commontypes.LogFields{
+		"configDigest":    configuration.ConfigDigest,
+		"reportingPlugin": "DirectRequest",
+	})
+
+	return &directRequest{
+			fac.Database,
+			fac.Executor,
+			logger,
+			fac.ReportCodec,
+			fac.RequestSource,
+
+			configuration.F,
+			fac.MaxBatchSize,
+			fac.MaxResponseLength,
+			maxReportLength,
+			fac.PendingDuration,
+		}, types.ReportingPluginInfo{
+			Name:          "DirectRequest",
+			UniqueReports: false,
+			Limits: types.ReportingPluginLimits{
+				MaxQueryLength:       0,
+				MaxObservationLength: maxObservationLength,
+				MaxReportLength:      maxReportLength,
+			},
+		}, nil
+}
+
+var _ types.ReportingPlugin = (*directRequest)(nil)
+
+type directRequest struct {
+	database      FulfillmentDatabase
+	executor      Executor
+	logger        loghelper.LoggerWithContext
+	reportCodec   ReportCodec
+	requestSource RequestSource
+
+	f                 int
+	maxBatchSize      int
+	maxResponseLength int
+	maxReportLength   int
+	pendingDuration   time.Duration
+}
+
+func (dr *directRequest) Query(ctx context.Context, repts types.ReportTimestamp) (types.Query, error) {
+	return nil, nil
+}
+
+func (dr *directRequest) Observation(ctx context.Context, repts types.ReportTimestamp, query types.Query) (types.Observation, error) {
+	if len(query) != 0 {
+		return nil, fmt.Errorf("expected empty query")
+	}
+
+	candidates, err := dr.requestSource.PendingRequests(ctx, pendingRequestsOverfetchFactor*dr.maxBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("error in RequestSource.PendingRequests: %w", err)
+	}
+
+	now := time.Now()
+	requests := make([]Request, 0, dr.maxBatchSize)
+	seen := map[RequestID]bool{}
+	for _, request := range candidates {
+		if len(requests) == dr.maxBatchSize {
+			break
+		}
+		if seen[request.ID] {
+			continue
+		}
+		seen[request.ID] = true
+
+		state, err := dr.database.ReadFulfillmentState(ctx, request.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error in FulfillmentDatabase.ReadFulfillmentState: %w", err)
+		}
+		if state.Status == FulfillmentStatusFulfilled ||
+			(state.Status == FulfillmentStatusPending && now.Before(state.PendingUntil)) {
+			continue
+		}
+		requests = append(requests, request)
+	}
+
+	responses := make([][]byte, len(requests))
+	{
+		var subs subprocesses.Subprocesses
+		for i, request := range requests {
+			i, request := i, request
+			subs.Go(func() {
+				response, err := dr.executor.Execute(ctx, request)
+				if err != nil {
+					dr.logger.ErrorIfNotCanceled("Observation: error in Executor.Execute", ctx, commontypes.LogFields{
+						"requestID": request.ID,
+						"error":     err,
+					})
+					return
+				}
+				if !(len(response) <= dr.maxResponseLength) {
+					dr.logger.Warn("Observation: dropping response exceeding MaxResponseLength", commontypes.LogFields{
+						"requestID":         request.ID,
+						"responseLength":    len(response),
+						"maxResponseLength": dr.maxResponseLength,
+					})
+					return
+				}
+				if response == nil {
+					response = []byte{}
+				}
+				responses[i] = response
+			})
+		}
+		subs.Wait()
+	}
+
+	observationProto := DirectRequestObservationProto{}
+	for i, request := range requests {
+		if responses[i] == nil {
+			continue
+		}
+		id := request.ID
+		observationProto.Responses = append(observationProto.Responses, &DirectRequestResponseProto{
+			RequestID: id[:],
+			Response:  responses[i],
+		})
+	}
+	sort.Slice(observationProto.Responses, func(i, j int) bool {
+		return bytes.Compare(observationProto.Responses[i].RequestID, observationProto.Responses[j].RequestID) < 0
+	})
+
+	return proto.Marshal(&observationProto)
+}
+
+func parseObservation(observation types.Observation, maxBatchSize int, maxResponseLength int) ([]Fulfillment, error) {
+	var observationProto DirectRequestObservationProto
+	if err := proto.Unmarshal(observation, &observationProto); err != nil {
+		return nil, fmt.Errorf("observation cannot be unmarshaled: %w", err)
+	}
+	if !(len(observationProto.Responses) <= maxBatchSize) {
+		return nil, fmt.Errorf("observation contains too many responses (%v vs %v)", len(observationProto.Responses), maxBatchSize)
+	}
+	fulfillments := make([]Fulfillment, 0, len(observationProto.Responses))
+	seen := map[RequestID]bool{}
+	for _, responseProto := range observationProto.Responses {
+		var id RequestID
+		if len(responseProto.RequestID) != len(id) {
+			return nil, fmt.Errorf("observation contains requestID of wrong length %v", len(responseProto.RequestID))
+		}
+		copy(id[:], responseProto.RequestID)
+		if seen[id] {
+			return nil, fmt.Errorf("observation contains duplicate requestID %v", id)
+		}
+		seen[id] = true
+		if !(len(responseProto.Response) <= maxResponseLength) {
+			return nil, fmt.Errorf("observation contains response exceeding MaxResponseLength (%v vs %v)", len(responseProto.Response), maxResponseLength)
+		}
+		fulfillments = append(fulfillments, Fulfillment{id, responseProto.Response})
+	}
+	return fulfillments, nil
+}
+
+func (dr *directRequest) Report(ctx context.Context, repts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) (bool, types.Report, error) {
+	if len(query) != 0 {
+		return false, nil, fmt.Errorf("expected empty query")
+	}
+
+	type vote struct {
+		id       RequestID
+		response string
+	}
+	votes := map[vote]int{}
+	seenObservers := map[commontypes.OracleID]bool{}
+	for i, ao := range aos {
+		if seenObservers[ao.Observer] {
+			dr.logger.Warn("Report: dropping duplicate observation", commontypes.LogFields{
+				"observer": ao.Observer,
+				"i":        i,
+			})
+			continue
+		}
+		seenObservers[ao.Observer] = true
+
+		fulfillments, err := parseObservation(ao.Observation, dr.maxBatchSize, dr.maxResponseLength)
+		if err != nil {
+			dr.logger.Warn("Report: dropping invalid observation", commontypes.LogFields{
+				"observer": ao.Observer,
+				"error":    err,
+				"i":        i,
+			})
+			continue
+		}
+		for _, fulfillment := range fulfillments {
+			votes[vote{fulfillment.RequestID, string(fulfillment.Response)}]++
+		}
+	}
+
+	// By assumption, at most f oracles are faulty. A response observed by at
+	// least f+1 oracles has thus been observed by at least one honest oracle.
+	// If honest oracles disagree, we deterministically pick the response with
+	// the most votes, breaking ties by lexicographic order.
+	winners := map[RequestID]vote{}
+	for v, count := range votes {
+		if !(dr.f < count) {
+			continue
+		}
+		current, ok := winners[v.id]
+		if !ok ||
+			votes[current] < count ||
+			(votes[current] == count && v.response < current.response) {
+			winners[v.id] = v
+		}
+	}
+
+	if len(winners) == 0 {
+		return false, nil, nil
+	}
+
+	fulfillments := make([]Fulfillment, 0, len(winners))
+	for _, v := range winners {
+		fulfillments = append(fulfillments, Fulfillment{v.id, []byte(v.response)})
+	}
+	sort.Slice(fulfillments, func(i, j int) bool {
+		return bytes.Compare(fulfillments[i].RequestID[:], fulfillments[j].RequestID[:]) < 0
+	})
+	if len(fulfillments) > dr.maxBatchSize {
+		fulfillments = fulfillments[:dr.maxBatchSize]
+	}
+
+	report, err := dr.reportCodec.BuildReport(fulfillments)
+	if err != nil {
+		return false, nil, fmt.Errorf("error in ReportCodec.BuildReport: %w", err)
+	}
+	if !(len(report) <= dr.maxReportLength) {
+		return false, nil, fmt.Errorf("report violates MaxReportLength limit set by ReportCodec (%v vs %v)", len(report), dr.maxReportLength)
+	}
+
+	dr.logger.Debug("Report: reporting fulfillments", commontypes.LogFields{
+		"timestamp":       repts,
+		"numFulfillments": len(fulfillments),
+	})
+
+	return true, report, nil
+}
+
+func (dr *directRequest) ShouldAcceptFinalizedReport(ctx context.Context, repts types.ReportTimestamp, report types.Report) (bool, error) {
+	fulfillments, err := dr.decodeReport(report)
+	if err != nil {
+		dr.logger.Warn("ShouldAcceptFinalizedReport() = false, invalid report", commontypes.LogFields{
+			"timestamp": repts,
+			"error":     err,
+		})
+		return false, nil
+	}
+
+	now := time.Now()
+	var unfulfilled []RequestID
+	for _, fulfillment := range fulfillments {
+		state, err := dr.database.ReadFulfillmentState(ctx, fulfillment.RequestID)
+		if err != nil {
+			return false, fmt.Errorf("error in FulfillmentDatabase.ReadFulfillmentState: %w", err)
+		}
+		if state.Status == FulfillmentStatusFulfilled ||
+			(state.Status == FulfillmentStatusPending && now.Before(state.PendingUntil)) {
+			continue
+		}
+		unfulfilled = append(unfulfilled, fulfillment.RequestID)
+	}
+
+	if len(unfulfilled) == 0 {
+		dr.logger.Debug("ShouldAcceptFinalizedReport() = false, all requests are already pending or fulfilled", commontypes.LogFields{
+			"timestamp": repts,
+		})
+		return false, nil
+	}
+
+	pendingUntil := now.Add(dr.pendingDuration)
+	for _, id := range unfulfilled {
+		err := dr.database.WriteFulfillmentState(ctx, id, FulfillmentState{FulfillmentStatusPending, pendingUntil})
+		if err != nil {
+			return false, fmt.Errorf("error in FulfillmentDatabase.WriteFulfillmentState: %w", err)
+		}
+	}
+
+	dr.logger.Debug("ShouldAcceptFinalizedReport() = true", commontypes.LogFields{
+		"timestamp":      repts,
+		"numUnfulfilled": len(unfulfilled),
+	})
+	return true, nil
+}
+
+func (dr *directRequest) ShouldTransmitAcceptedReport(ctx context.Context, repts types.ReportTimestamp, report types.Report) (bool, error) {
+	fulfillments, err := dr.decodeReport(report)
+	if err != nil {
+		dr.logger.Warn("ShouldTransmitAcceptedReport() = false, invalid report", commontypes.LogFields{
+			"timestamp": repts,
+			"error":     err,
+		})
+		return false, nil
+	}
+
+	unfulfilledCount := 0
+	for _, fulfillment := range fulfillments {
+		state, err := dr.database.ReadFulfillmentState(ctx, fulfillment.RequestID)
+		if err != nil {
+			return false, fmt.Errorf("error in FulfillmentDatabase.ReadFulfillmentState: %w", err)
+		}
+		if state.Status == FulfillmentStatusFulfilled {
+			continue
+		}
+
+		fulfilled, err := dr.requestSource.IsFulfilled(ctx, fulfillment.RequestID)
+		if err != nil {
+			return false, fmt.Errorf("error in RequestSource.IsFulfilled: %w", err)
+		}
+		if fulfilled {
+			err := dr.database.WriteFulfillmentState(ctx, fulfillment.RequestID, FulfillmentState{FulfillmentStatusFulfilled, time.Time{}})
+			if err != nil {
+				return false, fmt.Errorf("error in FulfillmentDatabase.WriteFulfillmentState: %w", err)
+			}
+			continue
+		}
+		unfulfilledCount++
+	}
+
+	result := unfulfilledCount != 0
+	dr.logger.Debug("ShouldTransmitAcceptedReport() = result", commontypes.LogFields{
+		"timestamp":        repts,
+		"unfulfilledCount": unfulfilledCount,
+		"result":           result,
+	})
+	return result, nil
+}
+
+func (dr *directRequest) decodeReport(report types.Report) ([]Fulfillment, error) {
+	if !(len(report) <= dr.maxReportLength) {
+		return nil, fmt.Errorf("report violates MaxReportLength limit set by ReportCodec (%v vs %v)", len(report), dr.maxReportLength)
+	}
+	fulfillments, err := dr.reportCodec.FulfillmentsFromReport(report)
+	if err != nil {
+		return nil, fmt.Errorf("error in ReportCodec.FulfillmentsFromReport: %w", err)
+	}
+	if len(fulfillments) == 0 {
+		return nil, fmt.Errorf("report contains no fulfillments")
+	}
+	return fulfillments, nil
+}
+
+func (dr *directRequest) Close() error {
+	return nil
+}
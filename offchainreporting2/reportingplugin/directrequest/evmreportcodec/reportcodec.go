@@ -0,0 +1,86 @@
+package evmreportcodec
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/directrequest"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+var reportTypes = getReportTypes()
+
+func getReportTypes() abi.Arguments {
+	mustNewType := func(t string) abi.Type {
+		result, err := abi.NewType(t, "", []abi.ArgumentMarshaling{})
+		if err != nil {
+			panic(fmt.Sprintf("Unexpected error during abi.NewType: %s", err))
+		}
+		return result
+	}
+	return abi.Arguments([]abi.Argument{
+		{Name: "requestIDs", Type: mustNewType("bytes32[]")},
+		{Name: "responses", Type: mustNewType("bytes[]")},
+	})
+}
+
+var _ directrequest.ReportCodec = ReportCodec{}
+
+// ReportCodec encodes reports as the abi-encoded tuple
+// (bytes32[] requestIDs, bytes[] responses) where responses[i] is the
+// response to requestIDs[i].
+type ReportCodec struct{}
+
+func (ReportCodec) BuildReport(fulfillments []directrequest.Fulfillment) (types.Report, error) {
+	if len(fulfillments) == 0 {
+		return nil, fmt.Errorf("cannot build report from empty fulfillments")
+	}
+
+	requestIDs := make([][32]byte, 0, len(fulfillments))
+	responses := make([][]byte, 0, len(fulfillments))
+	for _, fulfillment := range fulfillments {
+		requestIDs = append(requestIDs, fulfillment.RequestID)
+		responses = append(responses, fulfillment.Response)
+	}
+
+	reportBytes, err := reportTypes.Pack(requestIDs, responses)
+	return types.Report(reportBytes), err
+}
+
+func (ReportCodec) FulfillmentsFromReport(report types.Report) ([]directrequest.Fulfillment, error) {
+	unpacked, err := reportTypes.Unpack(report)
+	if err != nil {
+		return nil, fmt.Errorf("error during unpack: %w", err)
+	}
+	if len(unpacked) != 2 {
+		return nil, fmt.Errorf("unpacked report has %v elements, expected 2", len(unpacked))
+	}
+
+	requestIDs, ok := unpacked[0].([][32]byte)
+	if !ok {
+		return nil, fmt.Errorf("cannot cast requestIDs to [][32]byte, type is %T", unpacked[0])
+	}
+	responses, ok := unpacked[1].([][]byte)
+	if !ok {
+		return nil, fmt.Errorf("cannot cast responses to [][]byte, type is %T", unpacked[1])
+	}
+	if len(requestIDs) != len(responses) {
+		return nil, fmt.Errorf("length mismatch between requestIDs (%v) and responses (%v)", len(requestIDs), len(responses))
+	}
+
+	fulfillments := make([]directrequest.Fulfillment, 0, len(requestIDs))
+	for i := range requestIDs {
+		fulfillments = append(fulfillments, directrequest.Fulfillment{
+			RequestID: requestIDs[i],
+			Response:  responses[i],
+		})
+	}
+	return fulfillments, nil
+}
+
+func (ReportCodec) MaxReportLength(maxBatchSize int, maxResponseLength int) int {
+	paddedResponseLength := (maxResponseLength + 31) / 32 * 32
+	return 2*32 /* offsets */ +
+		32 + maxBatchSize*32 /* requestIDs */ +
+		32 + maxBatchSize*(32 /* offset */ +32 /* length */ +paddedResponseLength) /* responses */
+}
@@ -0,0 +1,35 @@
+package directrequest
+
+import (
+	"context"
+	"sync"
+)
+
+var _ FulfillmentDatabase = (*InMemoryFulfillmentDatabase)(nil)
+
+// InMemoryFulfillmentDatabase is a FulfillmentDatabase that doesn't persist
+// anything. It's only suitable for testing and development.
+type InMemoryFulfillmentDatabase struct {
+	lock   sync.Mutex
+	states map[RequestID]FulfillmentState
+}
+
+func NewInMemoryFulfillmentDatabase() *InMemoryFulfillmentDatabase {
+	return &InMemoryFulfillmentDatabase{
+		sync.Mutex{},
+		map[RequestID]FulfillmentState{},
+	}
+}
+
+func (db *InMemoryFulfillmentDatabase) ReadFulfillmentState(ctx context.Context, id RequestID) (FulfillmentState, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	return db.states[id], nil
+}
+
+func (db *InMemoryFulfillmentDatabase) WriteFulfillmentState(ctx context.Context, id RequestID, state FulfillmentState) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	db.states[id] = state
+	return nil
+}
@@ -0,0 +1,224 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.20.0
+// source: offchainreporting2_directrequest_observation.proto
+
+package directrequest
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DirectRequestObservationProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Responses []*DirectRequestResponseProto `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
+}
+
+func (x *DirectRequestObservationProto) Reset() {
+	*x = DirectRequestObservationProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting2_directrequest_observation_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DirectRequestObservationProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DirectRequestObservationProto) ProtoMessage() {}
+
+func (x *DirectRequestObservationProto) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_directrequest_observation_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DirectRequestObservationProto.ProtoReflect.Descriptor instead.
+func (*DirectRequestObservationProto) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_directrequest_observation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DirectRequestObservationProto) GetResponses() []*DirectRequestResponseProto {
+	if x != nil {
+		return x.Responses
+	}
+	return nil
+}
+
+type DirectRequestResponseProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RequestID []byte `protobuf:"bytes,1,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	Response  []byte `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+}
+
+func (x *DirectRequestResponseProto) Reset() {
+	*x = DirectRequestResponseProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting2_directrequest_observation_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DirectRequestResponseProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DirectRequestResponseProto) ProtoMessage() {}
+
+func (x *DirectRequestResponseProto) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_directrequest_observation_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DirectRequestResponseProto.ProtoReflect.Descriptor instead.
+func (*DirectRequestResponseProto) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_directrequest_observation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DirectRequestResponseProto) GetRequestID() []byte {
+	if x != nil {
+		return x.RequestID
+	}
+	return nil
+}
+
+func (x *DirectRequestResponseProto) GetResponse() []byte {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+var File_offchainreporting2_directrequest_observation_proto protoreflect.FileDescriptor
+
+var file_offchainreporting2_directrequest_observation_proto_rawDesc = []byte{
+	0x0a, 0x32, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x69, 0x6e, 0x67, 0x32, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x22, 0x6d, 0x0a, 0x1d, 0x44, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x4c, 0x0a, 0x09, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6f,
+	0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67,
+	0x32, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x52, 0x09, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x22, 0x56, 0x0a, 0x1a, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x11, 0x5a, 0x0f, 0x2e, 0x3b, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_offchainreporting2_directrequest_observation_proto_rawDescOnce sync.Once
+	file_offchainreporting2_directrequest_observation_proto_rawDescData = file_offchainreporting2_directrequest_observation_proto_rawDesc
+)
+
+func file_offchainreporting2_directrequest_observation_proto_rawDescGZIP() []byte {
+	file_offchainreporting2_directrequest_observation_proto_rawDescOnce.Do(func() {
+		file_offchainreporting2_directrequest_observation_proto_rawDescData = protoimpl.X.CompressGZIP(file_offchainreporting2_directrequest_observation_proto_rawDescData)
+	})
+	return file_offchainreporting2_directrequest_observation_proto_rawDescData
+}
+
+var file_offchainreporting2_directrequest_observation_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_offchainreporting2_directrequest_observation_proto_goTypes = []interface{}{
+	(*DirectRequestObservationProto)(nil), // 0: offchainreporting2.DirectRequestObservationProto
+	(*DirectRequestResponseProto)(nil),    // 1: offchainreporting2.DirectRequestResponseProto
+}
+var file_offchainreporting2_directrequest_observation_proto_depIdxs = []int32{
+	1, // 0: offchainreporting2.DirectRequestObservationProto.responses:type_name -> offchainreporting2.DirectRequestResponseProto
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_offchainreporting2_directrequest_observation_proto_init() }
+func file_offchainreporting2_directrequest_observation_proto_init() {
+	if File_offchainreporting2_directrequest_observation_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_offchainreporting2_directrequest_observation_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DirectRequestObservationProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_offchainreporting2_directrequest_observation_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DirectRequestResponseProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_offchainreporting2_directrequest_observation_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_offchainreporting2_directrequest_observation_proto_goTypes,
+		DependencyIndexes: file_offchainreporting2_directrequest_observation_proto_depIdxs,
+		MessageInfos:      file_offchainreporting2_directrequest_observation_proto_msgTypes,
+	}.Build()
+	File_offchainreporting2_directrequest_observation_proto = out.File
+	file_offchainreporting2_directrequest_observation_proto_rawDesc = nil
+	file_offchainreporting2_directrequest_observation_proto_goTypes = nil
+	file_offchainreporting2_directrequest_observation_proto_depIdxs = nil
+}
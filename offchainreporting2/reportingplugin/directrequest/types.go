@@ -0,0 +1,110 @@
+package directrequest
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// RequestID uniquely identifies a request, e.g. by the hash of the log that
+// emitted it.
+type RequestID [32]byte
+
+func (id RequestID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// Request is a request for data made by a user of the contract.
+type Request struct {
+	ID RequestID
+	// Payload describes what the request asks for. It is only interpreted by
+	// the Executor.
+	Payload []byte
+}
+
+// Fulfillment pairs a request with the response the oracles agreed on.
+type Fulfillment struct {
+	RequestID RequestID
+	Response  []byte
+}
+
+// RequestSource provides the requests that the oracles should fulfill.
+//
+// All its functions should be thread-safe.
+type RequestSource interface {
+	// PendingRequests returns up to max requests that have not been
+	// fulfilled on-chain yet, ordered oldest first. Only return requests that
+	// are sufficiently confirmed such that different oracles will come to
+	// a common view of them.
+	PendingRequests(ctx context.Context, max int) ([]Request, error)
+
+	// IsFulfilled returns whether a fulfillment for the given request has
+	// been recorded on-chain.
+	IsFulfilled(ctx context.Context, id RequestID) (bool, error)
+}
+
+// Executor computes the response to a request.
+//
+// All its functions should be thread-safe.
+type Executor interface {
+	// Execute returns the response to the request. Honest oracles should
+	// return identical responses for the same request, since a response is
+	// only included in a report if at least f+1 oracles observed it.
+	//
+	// Execute should stop any blocking interactions with outside services
+	// once ctx has expired.
+	Execute(ctx context.Context, request Request) (response []byte, err error)
+}
+
+// ReportCodec encodes batches of fulfillments into reports understood by the
+// target contract.
+//
+// All functions on ReportCodec should be pure and thread-safe.
+// Be careful validating and parsing any data passed.
+type ReportCodec interface {
+	// BuildReport encodes the fulfillments into a report. Fulfillments are
+	// passed sorted by RequestID and contain no duplicate RequestIDs.
+	BuildReport([]Fulfillment) (types.Report, error)
+
+	// FulfillmentsFromReport decodes a report. The input to this function
+	// should be an output of BuildReport in the benign case. Nevertheless,
+	// make sure to treat the input to this function as untrusted.
+	FulfillmentsFromReport(types.Report) ([]Fulfillment, error)
+
+	// Returns the maximum length of a report containing at most
+	// maxBatchSize fulfillments, each of which has a response of length at
+	// most maxResponseLength. The output of BuildReport must respect this
+	// maximum length.
+	MaxReportLength(maxBatchSize int, maxResponseLength int) int
+}
+
+type FulfillmentStatus int
+
+const (
+	FulfillmentStatusUnknown FulfillmentStatus = iota
+	// A report fulfilling the request has been accepted for transmission.
+	FulfillmentStatusPending
+	// The request has been fulfilled on-chain.
+	FulfillmentStatusFulfilled
+)
+
+type FulfillmentState struct {
+	Status FulfillmentStatus
+	// Only meaningful if Status is FulfillmentStatusPending. After this time,
+	// the request is considered unfulfilled again.
+	PendingUntil time.Time
+}
+
+// FulfillmentDatabase persistently tracks the fulfillment status of
+// requests, so that restarted oracles don't repeatedly fulfill the same
+// requests.
+//
+// All its functions should be thread-safe.
+type FulfillmentDatabase interface {
+	// ReadFulfillmentState returns the state of the request. Unknown requests
+	// should result in a zero FulfillmentState, not an error.
+	ReadFulfillmentState(ctx context.Context, id RequestID) (FulfillmentState, error)
+	WriteFulfillmentState(ctx context.Context, id RequestID, state FulfillmentState) error
+}
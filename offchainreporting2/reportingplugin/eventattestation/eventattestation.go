@@ -0,0 +1,400 @@
+// Package eventattestation implements a ReportingPlugin for relaying events
+// from a source chain to a destination contract, e.g. for a bridge: The
+// oracles observe events past a confirmation depth, agree on an ordered batch
+// of event hashes following the last sequence number reported to the
+// destination, and report it. The destination contract verifies the oracles'
+// signatures on the report like for any other OCR2 report and can then treat
+// the contained event hashes as authentic.
+//
+// Where events come from and how reports are encoded is pluggable through
+// EventSource and ReportCodec respectively.
+package eventattestation
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/runtime/protoimpl"
+)
+
+// Generous upper bound on the protobuf overhead per event hash and per
+// observation.
+const protobufOverhead = 16
+
+// The query consists of the sequence number of the latest event reported to
+// the destination contract, as seen by the leader.
+const queryLength = 8
+
+var _ types.ReportingPluginFactory = EventAttestationFactory{}
+
+type EventAttestationFactory struct {
+	DestinationContract DestinationContract
+	EventSource         EventSource
+	Logger              commontypes.Logger
+	ReportCodec         ReportCodec
+
+	// MaxBatchSize is the maximum number of events attested to by a single
+	// report.
+	MaxBatchSize int
+	// PendingDuration is the duration for which the events contained in an
+	// accepted report are considered pending. Reports that don't contain any
+	// events beyond the pending ones aren't accepted. If the destination
+	// contract still hasn't caught up after PendingDuration, the pending
+	// events will be reported again.
+	PendingDuration time.Duration
+}
+
+func (fac EventAttestationFactory) NewReportingPlugin(configuration types.ReportingPluginConfig) (types.ReportingPlugin, types.ReportingPluginInfo, error) {
+	if !(0 < fac.MaxBatchSize) {
+		return nil, types.ReportingPluginInfo{}, fmt.Errorf("MaxBatchSize (%v) must be positive", fac.MaxBatchSize)
+	}
+	if !(0 < fac.PendingDuration) {
+		return nil, types.ReportingPluginInfo{}, fmt.Errorf("PendingDuration (%v) must be positive", fac.PendingDuration)
+	}
+
+	maxObservationLength := fac.MaxBatchSize*(len(EventHash{})+protobufOverhead) + protobufOverhead
+	if !(0 < maxObservationLength && maxObservationLength <= types.MaxMaxObservationLength) {
+		return nil, types.ReportingPluginInfo{}, fmt.Errorf("MaxBatchSize (%v) results in MaxObservationLength (%v) exceeding %v",
+			fac.MaxBatchSize, maxObservationLength, types.MaxMaxObservationLength)
+	}
+	maxReportLength := fac.ReportCodec.MaxReportLength(fac.MaxBatchSize)
+	if !(0 < maxReportLength && maxReportLength <= types.MaxMaxReportLength) {
+		return nil, types.ReportingPluginInfo{}, fmt.Errorf("MaxReportLength (%v) returned by ReportCodec out of range, must be between 1 and %v",
+			maxReportLength, types.MaxMaxReportLength)
+	}
+
+	logger := loghelper.MakeRootLoggerWithContext(fac.Logger).MakeChild(commontypes.LogFields{
+		"configDigest":    configuration.ConfigDigest,
+		"reportingPlugin": "EventAttestation",
+	})
+
+	return &eventAttestation{
+		fac.DestinationContract,
+		fac.EventSource,
+		logger,
+		fac.ReportCodec,
+
+		configuration.F,
+		fac.MaxBatchSize,
+		maxReportLength,
+		fac.PendingDuration,
+
+		epochRound{},
+		0,
+		time.Time{},
+	}, types.ReportingPluginInfo{
+		Name:          "EventAttestation",
+		UniqueReports: false,
+		Limits: types.ReportingPluginLimits{
+			MaxQueryLength:       queryLength,
+			MaxObservationLength: maxObservationLength,
+			MaxReportLength:      maxReportLength,
+		},
+	}, nil
+}
+
+var _ types.ReportingPlugin = (*eventAttestation)(nil)
+
+type eventAttestation struct {
+	destinationContract DestinationContract
+	eventSource         EventSource
+	logger              loghelper.LoggerWithContext
+	reportCodec         ReportCodec
+
+	f               int
+	maxBatchSize    int
+	maxReportLength int
+	pendingDuration time.Duration
+
+	latestAcceptedEpochRound     epochRound
+	latestAcceptedSequenceNumber uint64
+	latestAcceptedPendingUntil   time.Time
+}
+
+// Query returns the sequence number of the latest event reported to the
+// destination contract. All oracles observe the events following it, so that
+// their observations line up even if their views of the destination contract
+// differ. A malicious leader gains nothing from lying: The destination
+// contract only accepts events that directly follow the latest reported one.
+func (ea *eventAttestation) Query(ctx context.Context, repts types.ReportTimestamp) (types.Query, error) {
+	latestSequenceNumber, err := ea.destinationContract.LatestSequenceNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error in DestinationContract.LatestSequenceNumber: %w", err)
+	}
+	query := make([]byte, queryLength)
+	binary.BigEndian.PutUint64(query, latestSequenceNumber)
+	return query, nil
+}
+
+func parseQuery(query types.Query) (uint64, error) {
+	if len(query) != queryLength {
+		return 0, fmt.Errorf("query has wrong length, expected %v, got %v", queryLength, len(query))
+	}
+	return binary.BigEndian.Uint64(query), nil
+}
+
+func (ea *eventAttestation) Observation(ctx context.Context, repts types.ReportTimestamp, query types.Query) (types.Observation, error) {
+	afterSequenceNumber, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := ea.eventSource.Events(ctx, afterSequenceNumber, ea.maxBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("error in EventSource.Events: %w", err)
+	}
+	if !(len(events) <= ea.maxBatchSize) {
+		return nil, fmt.Errorf("EventSource.Events returned too many events (%v vs %v)", len(events), ea.maxBatchSize)
+	}
+
+	eventHashes := make([][]byte, 0, len(events))
+	for i, event := range events {
+		if event.SequenceNumber != afterSequenceNumber+1+uint64(i) {
+			return nil, fmt.Errorf("EventSource.Events returned unexpected sequence number at index %v, expected %v, got %v",
+				i, afterSequenceNumber+1+uint64(i), event.SequenceNumber)
+		}
+		hash := event.Hash
+		eventHashes = append(eventHashes, hash[:])
+	}
+
+	observationProto := EventAttestationObservationProto{
+		// zero-initialize protobuf built-ins
+		protoimpl.MessageState{},
+		0,
+		nil,
+		// fields
+		eventHashes,
+	}
+	return proto.Marshal(&observationProto)
+}
+
+func parseObservation(observation types.Observation, maxBatchSize int) ([]EventHash, error) {
+	var observationProto EventAttestationObservationProto
+	if err := proto.Unmarshal(observation, &observationProto); err != nil {
+		return nil, fmt.Errorf("observation cannot be unmarshaled: %w", err)
+	}
+	if !(len(observationProto.EventHashes) <= maxBatchSize) {
+		return nil, fmt.Errorf("observation contains too many event hashes (%v vs %v)", len(observationProto.EventHashes), maxBatchSize)
+	}
+	hashes := make([]EventHash, 0, len(observationProto.EventHashes))
+	for _, hashBytes := range observationProto.EventHashes {
+		var hash EventHash
+		if len(hashBytes) != len(hash) {
+			return nil, fmt.Errorf("observation contains event hash of wrong length %v", len(hashBytes))
+		}
+		copy(hash[:], hashBytes)
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+func (ea *eventAttestation) Report(ctx context.Context, repts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) (bool, types.Report, error) {
+	afterSequenceNumber, err := parseQuery(query)
+	if err != nil {
+		return false, nil, err
+	}
+
+	// votes[i][h] counts the oracles that observed h as the hash of the event
+	// with sequence number afterSequenceNumber+1+i
+	votes := make([]map[EventHash]int, ea.maxBatchSize)
+	for i := range votes {
+		votes[i] = map[EventHash]int{}
+	}
+	seenObservers := map[commontypes.OracleID]bool{}
+	for i, ao := range aos {
+		if seenObservers[ao.Observer] {
+			ea.logger.Warn("Report: dropping duplicate observation", commontypes.LogFields{
+				"observer": ao.Observer,
+				"i":        i,
+			})
+			continue
+		}
+		seenObservers[ao.Observer] = true
+
+		hashes, err := parseObservation(ao.Observation, ea.maxBatchSize)
+		if err != nil {
+			ea.logger.Warn("Report: dropping invalid observation", commontypes.LogFields{
+				"observer": ao.Observer,
+				"error":    err,
+				"i":        i,
+			})
+			continue
+		}
+		for j, hash := range hashes {
+			votes[j][hash]++
+		}
+	}
+
+	// By assumption, at most f oracles are faulty. A hash observed by at least
+	// f+1 oracles has thus been observed by at least one honest oracle. We
+	// report the longest prefix of events for which there is a unique such
+	// hash. If there are several (which can only happen if honest oracles
+	// disagree, e.g. because of a reorg deeper than the confirmation depth),
+	// we stop and leave the event for a later round.
+	var events []Event
+	for i := range votes {
+		if afterSequenceNumber > math.MaxUint64-1-uint64(i) {
+			break
+		}
+		var winner EventHash
+		winners := 0
+		for hash, count := range votes[i] {
+			if ea.f < count {
+				winner = hash
+				winners++
+			}
+		}
+		if winners != 1 {
+			if winners > 1 {
+				ea.logger.Warn("Report: honest oracles disagree on event hash", commontypes.LogFields{
+					"sequenceNumber": afterSequenceNumber + 1 + uint64(i),
+				})
+			}
+			break
+		}
+		events = append(events, Event{afterSequenceNumber + 1 + uint64(i), winner})
+	}
+
+	if len(events) == 0 {
+		return false, nil, nil
+	}
+
+	report, err := ea.reportCodec.BuildReport(events)
+	if err != nil {
+		return false, nil, fmt.Errorf("error in ReportCodec.BuildReport: %w", err)
+	}
+	if !(len(report) <= ea.maxReportLength) {
+		return false, nil, fmt.Errorf("report violates MaxReportLength limit set by ReportCodec (%v vs %v)", len(report), ea.maxReportLength)
+	}
+
+	ea.logger.Debug("Report: reporting events", commontypes.LogFields{
+		"timestamp":           repts,
+		"firstSequenceNumber": events[0].SequenceNumber,
+		"numEvents":           len(events),
+	})
+
+	return true, report, nil
+}
+
+func (ea *eventAttestation) ShouldAcceptFinalizedReport(ctx context.Context, repts types.ReportTimestamp, report types.Report) (bool, error) {
+	reportEpochRound := epochRound{repts.Epoch, repts.Round}
+	if !ea.latestAcceptedEpochRound.Less(reportEpochRound) {
+		ea.logger.Debug("ShouldAcceptFinalizedReport() = false, report is stale", commontypes.LogFields{
+			"latestAcceptedEpochRound": ea.latestAcceptedEpochRound,
+			"reportEpochRound":         reportEpochRound,
+		})
+		return false, nil
+	}
+
+	events, err := ea.decodeReport(report)
+	if err != nil {
+		ea.logger.Warn("ShouldAcceptFinalizedReport() = false, invalid report", commontypes.LogFields{
+			"timestamp": repts,
+			"error":     err,
+		})
+		return false, nil
+	}
+
+	applicable, err := ea.isApplicable(ctx, events)
+	if err != nil {
+		return false, err
+	}
+	if !applicable {
+		ea.logger.Debug("ShouldAcceptFinalizedReport() = false, report doesn't follow latest sequence number of destination contract", commontypes.LogFields{
+			"timestamp": repts,
+		})
+		return false, nil
+	}
+
+	now := time.Now()
+	lastSequenceNumber := events[len(events)-1].SequenceNumber
+	if lastSequenceNumber <= ea.latestAcceptedSequenceNumber && now.Before(ea.latestAcceptedPendingUntil) {
+		ea.logger.Debug("ShouldAcceptFinalizedReport() = false, all events are already pending", commontypes.LogFields{
+			"timestamp":                    repts,
+			"lastSequenceNumber":           lastSequenceNumber,
+			"latestAcceptedSequenceNumber": ea.latestAcceptedSequenceNumber,
+		})
+		return false, nil
+	}
+
+	ea.latestAcceptedEpochRound = reportEpochRound
+	ea.latestAcceptedSequenceNumber = lastSequenceNumber
+	ea.latestAcceptedPendingUntil = now.Add(ea.pendingDuration)
+
+	ea.logger.Debug("ShouldAcceptFinalizedReport() = true", commontypes.LogFields{
+		"timestamp":           repts,
+		"firstSequenceNumber": events[0].SequenceNumber,
+		"lastSequenceNumber":  lastSequenceNumber,
+	})
+	return true, nil
+}
+
+func (ea *eventAttestation) ShouldTransmitAcceptedReport(ctx context.Context, repts types.ReportTimestamp, report types.Report) (bool, error) {
+	events, err := ea.decodeReport(report)
+	if err != nil {
+		ea.logger.Warn("ShouldTransmitAcceptedReport() = false, invalid report", commontypes.LogFields{
+			"timestamp": repts,
+			"error":     err,
+		})
+		return false, nil
+	}
+
+	result, err := ea.isApplicable(ctx, events)
+	if err != nil {
+		return false, err
+	}
+	ea.logger.Debug("ShouldTransmitAcceptedReport() = result", commontypes.LogFields{
+		"timestamp": repts,
+		"result":    result,
+	})
+	return result, nil
+}
+
+// isApplicable returns true if the destination contract would accept the
+// events, i.e. they directly follow the latest event reported to it.
+func (ea *eventAttestation) isApplicable(ctx context.Context, events []Event) (bool, error) {
+	latestSequenceNumber, err := ea.destinationContract.LatestSequenceNumber(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error in DestinationContract.LatestSequenceNumber: %w", err)
+	}
+	return latestSequenceNumber < math.MaxUint64 && events[0].SequenceNumber == latestSequenceNumber+1, nil
+}
+
+func (ea *eventAttestation) decodeReport(report types.Report) ([]Event, error) {
+	if !(len(report) <= ea.maxReportLength) {
+		return nil, fmt.Errorf("report violates MaxReportLength limit set by ReportCodec (%v vs %v)", len(report), ea.maxReportLength)
+	}
+	events, err := ea.reportCodec.EventsFromReport(report)
+	if err != nil {
+		return nil, fmt.Errorf("error in ReportCodec.EventsFromReport: %w", err)
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("report contains no events")
+	}
+	for i, event := range events {
+		if event.SequenceNumber != events[0].SequenceNumber+uint64(i) {
+			return nil, fmt.Errorf("report contains non-consecutive sequence number at index %v", i)
+		}
+	}
+	return events, nil
+}
+
+func (ea *eventAttestation) Close() error {
+	return nil
+}
+
+type epochRound struct {
+	Epoch uint32
+	Round uint8
+}
+
+func (x epochRound) Less(y epochRound) bool {
+	return x.Epoch < y.Epoch || (x.Epoch == y.Epoch && x.Round < y.Round)
+}
@@ -0,0 +1,88 @@
+package evmreportcodec
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/eventattestation"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+var reportTypes = getReportTypes()
+
+func getReportTypes() abi.Arguments {
+	mustNewType := func(t string) abi.Type {
+		result, err := abi.NewType(t, "", []abi.ArgumentMarshaling{})
+		if err != nil {
+			panic(fmt.Sprintf("Unexpected error during abi.NewType: %s", err))
+		}
+		return result
+	}
+	return abi.Arguments([]abi.Argument{
+		{Name: "firstSequenceNumber", Type: mustNewType("uint64")},
+		{Name: "eventHashes", Type: mustNewType("bytes32[]")},
+	})
+}
+
+var _ eventattestation.ReportCodec = ReportCodec{}
+
+// ReportCodec encodes reports as the abi-encoded tuple
+// (uint64 firstSequenceNumber, bytes32[] eventHashes) where eventHashes[i] is
+// the hash of the event with sequence number firstSequenceNumber+i.
+type ReportCodec struct{}
+
+func (ReportCodec) BuildReport(events []eventattestation.Event) (types.Report, error) {
+	if len(events) == 0 {
+		return nil, fmt.Errorf("cannot build report from empty events")
+	}
+
+	firstSequenceNumber := events[0].SequenceNumber
+	eventHashes := make([][32]byte, 0, len(events))
+	for i, event := range events {
+		if event.SequenceNumber != firstSequenceNumber+uint64(i) {
+			return nil, fmt.Errorf("events are not consecutive, expected sequence number %v at index %v, got %v",
+				firstSequenceNumber+uint64(i), i, event.SequenceNumber)
+		}
+		eventHashes = append(eventHashes, event.Hash)
+	}
+
+	reportBytes, err := reportTypes.Pack(firstSequenceNumber, eventHashes)
+	return types.Report(reportBytes), err
+}
+
+func (ReportCodec) EventsFromReport(report types.Report) ([]eventattestation.Event, error) {
+	unpacked, err := reportTypes.Unpack(report)
+	if err != nil {
+		return nil, fmt.Errorf("error during unpack: %w", err)
+	}
+	if len(unpacked) != 2 {
+		return nil, fmt.Errorf("unpacked report has %v elements, expected 2", len(unpacked))
+	}
+
+	firstSequenceNumber, ok := unpacked[0].(uint64)
+	if !ok {
+		return nil, fmt.Errorf("cannot cast firstSequenceNumber to uint64, type is %T", unpacked[0])
+	}
+	eventHashes, ok := unpacked[1].([][32]byte)
+	if !ok {
+		return nil, fmt.Errorf("cannot cast eventHashes to [][32]byte, type is %T", unpacked[1])
+	}
+	if firstSequenceNumber+uint64(len(eventHashes)) < firstSequenceNumber {
+		return nil, fmt.Errorf("sequence numbers overflow")
+	}
+
+	events := make([]eventattestation.Event, 0, len(eventHashes))
+	for i, hash := range eventHashes {
+		events = append(events, eventattestation.Event{
+			SequenceNumber: firstSequenceNumber + uint64(i),
+			Hash:           hash,
+		})
+	}
+	return events, nil
+}
+
+func (ReportCodec) MaxReportLength(maxBatchSize int) int {
+	return 32 /* firstSequenceNumber */ +
+		32 /* offset */ +
+		32 + maxBatchSize*32 /* eventHashes */
+}
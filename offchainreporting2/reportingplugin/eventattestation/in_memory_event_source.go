@@ -0,0 +1,42 @@
+package eventattestation
+
+import (
+	"context"
+	"sync"
+)
+
+var _ EventSource = (*InMemoryEventSource)(nil)
+
+// InMemoryEventSource is an EventSource backed by a slice. Every appended
+// event is immediately considered confirmed. It's only suitable for testing
+// and development.
+type InMemoryEventSource struct {
+	lock   sync.Mutex
+	hashes []EventHash
+}
+
+func NewInMemoryEventSource() *InMemoryEventSource {
+	return &InMemoryEventSource{
+		sync.Mutex{},
+		nil,
+	}
+}
+
+// Append adds an event with the given hash and returns its sequence number.
+func (src *InMemoryEventSource) Append(hash EventHash) uint64 {
+	src.lock.Lock()
+	defer src.lock.Unlock()
+	src.hashes = append(src.hashes, hash)
+	return uint64(len(src.hashes))
+}
+
+func (src *InMemoryEventSource) Events(ctx context.Context, afterSequenceNumber uint64, max int) ([]Event, error) {
+	src.lock.Lock()
+	defer src.lock.Unlock()
+
+	events := []Event{}
+	for seqNr := afterSequenceNumber + 1; seqNr <= uint64(len(src.hashes)) && len(events) < max; seqNr++ {
+		events = append(events, Event{seqNr, src.hashes[seqNr-1]})
+	}
+	return events, nil
+}
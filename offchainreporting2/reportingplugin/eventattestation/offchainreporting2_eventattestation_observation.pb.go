@@ -0,0 +1,147 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.20.0
+// source: offchainreporting2_eventattestation_observation.proto
+
+package eventattestation
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EventAttestationObservationProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventHashes [][]byte `protobuf:"bytes,1,rep,name=event_hashes,json=eventHashes,proto3" json:"event_hashes,omitempty"`
+}
+
+func (x *EventAttestationObservationProto) Reset() {
+	*x = EventAttestationObservationProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting2_eventattestation_observation_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EventAttestationObservationProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventAttestationObservationProto) ProtoMessage() {}
+
+func (x *EventAttestationObservationProto) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_eventattestation_observation_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventAttestationObservationProto.ProtoReflect.Descriptor instead.
+func (*EventAttestationObservationProto) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_eventattestation_observation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EventAttestationObservationProto) GetEventHashes() [][]byte {
+	if x != nil {
+		return x.EventHashes
+	}
+	return nil
+}
+
+var File_offchainreporting2_eventattestation_observation_proto protoreflect.FileDescriptor
+
+var file_offchainreporting2_eventattestation_observation_proto_rawDesc = []byte{
+	0x0a, 0x35, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x69, 0x6e, 0x67, 0x32, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32, 0x22, 0x45, 0x0a, 0x20, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f,
+	0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x21, 0x0a, 0x0c, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68,
+	0x65, 0x73, 0x42, 0x14, 0x5a, 0x12, 0x2e, 0x3b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_offchainreporting2_eventattestation_observation_proto_rawDescOnce sync.Once
+	file_offchainreporting2_eventattestation_observation_proto_rawDescData = file_offchainreporting2_eventattestation_observation_proto_rawDesc
+)
+
+func file_offchainreporting2_eventattestation_observation_proto_rawDescGZIP() []byte {
+	file_offchainreporting2_eventattestation_observation_proto_rawDescOnce.Do(func() {
+		file_offchainreporting2_eventattestation_observation_proto_rawDescData = protoimpl.X.CompressGZIP(file_offchainreporting2_eventattestation_observation_proto_rawDescData)
+	})
+	return file_offchainreporting2_eventattestation_observation_proto_rawDescData
+}
+
+var file_offchainreporting2_eventattestation_observation_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_offchainreporting2_eventattestation_observation_proto_goTypes = []interface{}{
+	(*EventAttestationObservationProto)(nil), // 0: offchainreporting2.EventAttestationObservationProto
+}
+var file_offchainreporting2_eventattestation_observation_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_offchainreporting2_eventattestation_observation_proto_init() }
+func file_offchainreporting2_eventattestation_observation_proto_init() {
+	if File_offchainreporting2_eventattestation_observation_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_offchainreporting2_eventattestation_observation_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EventAttestationObservationProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_offchainreporting2_eventattestation_observation_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_offchainreporting2_eventattestation_observation_proto_goTypes,
+		DependencyIndexes: file_offchainreporting2_eventattestation_observation_proto_depIdxs,
+		MessageInfos:      file_offchainreporting2_eventattestation_observation_proto_msgTypes,
+	}.Build()
+	File_offchainreporting2_eventattestation_observation_proto = out.File
+	file_offchainreporting2_eventattestation_observation_proto_rawDesc = nil
+	file_offchainreporting2_eventattestation_observation_proto_goTypes = nil
+	file_offchainreporting2_eventattestation_observation_proto_depIdxs = nil
+}
@@ -0,0 +1,76 @@
+package eventattestation
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// EventHash uniquely identifies an event, e.g. by the hash of the source chain
+// log that emitted it. The destination contract relies on the report to
+// authenticate the event, so the hash should commit to the event's entire
+// payload.
+type EventHash [32]byte
+
+func (h EventHash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// Event is an event on the source chain that should be relayed to the
+// destination.
+type Event struct {
+	// Events are numbered consecutively starting at 1, e.g. by a counter in
+	// the source contract. The destination contract accepts events strictly in
+	// order.
+	SequenceNumber uint64
+	Hash           EventHash
+}
+
+// EventSource provides the events that the oracles should attest to.
+//
+// All its functions should be thread-safe.
+type EventSource interface {
+	// Events returns up to max events with sequence numbers
+	// afterSequenceNumber+1, afterSequenceNumber+2, ..., in that order and
+	// without gaps. Only return events that are sufficiently confirmed (e.g.
+	// past a confirmation depth on the source chain) such that different
+	// oracles will come to a common view of them. If fewer events are
+	// available, return fewer; if none are, return an empty slice, not an
+	// error.
+	//
+	// Events should stop any blocking interactions with outside services
+	// once ctx has expired.
+	Events(ctx context.Context, afterSequenceNumber uint64, max int) ([]Event, error)
+}
+
+// DestinationContract provides information about the contract that reports
+// are transmitted to.
+//
+// All its functions should be thread-safe.
+type DestinationContract interface {
+	// LatestSequenceNumber returns the sequence number of the latest event
+	// that has been reported to the contract. If no event has been reported
+	// yet, it should return zero, not an error.
+	LatestSequenceNumber(ctx context.Context) (uint64, error)
+}
+
+// ReportCodec encodes batches of events into reports understood by the
+// destination contract.
+//
+// All functions on ReportCodec should be pure and thread-safe.
+// Be careful validating and parsing any data passed.
+type ReportCodec interface {
+	// BuildReport encodes the events into a report. Events are passed
+	// ordered by sequence number, without gaps.
+	BuildReport([]Event) (types.Report, error)
+
+	// EventsFromReport decodes a report. The input to this function should be
+	// an output of BuildReport in the benign case. Nevertheless, make sure to
+	// treat the input to this function as untrusted.
+	EventsFromReport(types.Report) ([]Event, error)
+
+	// Returns the maximum length of a report containing at most maxBatchSize
+	// events. The output of BuildReport must respect this maximum length.
+	MaxReportLength(maxBatchSize int) int
+}
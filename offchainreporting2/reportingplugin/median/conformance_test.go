@@ -0,0 +1,73 @@
+package median_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/conformance"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median/evmreportcodec"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Trace(string, commontypes.LogFields)    {}
+func (nopLogger) Debug(string, commontypes.LogFields)    {}
+func (nopLogger) Info(string, commontypes.LogFields)     {}
+func (nopLogger) Warn(string, commontypes.LogFields)     {}
+func (nopLogger) Error(string, commontypes.LogFields)    {}
+func (nopLogger) Critical(string, commontypes.LogFields) {}
+
+type constantDataSource int64
+
+func (ds constantDataSource) Observe(context.Context) (*big.Int, error) {
+	return big.NewInt(int64(ds)), nil
+}
+
+// fakeContract has never received a transmission, so the plugin always wants
+// to report.
+type fakeContract struct{}
+
+func (fakeContract) LatestTransmissionDetails(context.Context) (types.ConfigDigest, uint32, uint8, *big.Int, time.Time, error) {
+	return types.ConfigDigest{}, 0, 0, big.NewInt(0), time.Time{}, nil
+}
+
+func (fakeContract) LatestRoundRequested(context.Context, time.Duration) (types.ConfigDigest, uint32, uint8, error) {
+	return types.ConfigDigest{}, 0, 0, nil
+}
+
+func TestConformance(t *testing.T) {
+	onchainConfig, err := median.StandardOnchainConfigCodec{}.Encode(median.OnchainConfig{median.MinValue(), median.MaxValue()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	offchainConfig := median.OffchainConfig{
+		false,
+		10_000_000, // 1%
+		false,
+		10_000_000, // 1%
+		time.Hour,
+	}
+
+	conformance.Run(t, median.NumericalMedianFactory{
+		fakeContract{},
+		constantDataSource(1000),
+		constantDataSource(1),
+		nopLogger{},
+		median.StandardOnchainConfigCodec{},
+		evmreportcodec.ReportCodec{},
+	}, conformance.Options{
+		Configuration: types.ReportingPluginConfig{
+			ConfigDigest:   types.ConfigDigest{1},
+			OracleID:       0,
+			N:              4,
+			F:              1,
+			OnchainConfig:  onchainConfig,
+			OffchainConfig: offchainConfig.Encode(),
+		},
+	})
+}
@@ -0,0 +1,275 @@
+package median
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/subprocesses"
+	"go.uber.org/multierr"
+)
+
+var _ DataSource = MedianDataSource{}
+
+// MedianDataSource medianizes the values returned by a number of underlying
+// DataSources. All underlying DataSources are queried concurrently. For an even
+// number of values, the upper of the two middle values is returned, matching the
+// median the plugin computes over the oracles' observations. This way, the
+// result is always a value that an underlying DataSource actually returned.
+//
+// Observe returns once all underlying DataSources have returned, so that none
+// of them keeps running in the background. Once the context passed to Observe
+// expires, underlying DataSources should return promptly as described in the
+// documentation of DataSource.Observe, and MedianDataSource computes the
+// median over the values it has received.
+type MedianDataSource struct {
+	DataSources []DataSource
+	// MinResponses is the minimum number of underlying DataSources that need
+	// to return a value for Observe to succeed. Values smaller than one are
+	// treated as one.
+	MinResponses int
+}
+
+func (mds MedianDataSource) Observe(ctx context.Context) (*big.Int, error) {
+	minResponses := mds.MinResponses
+	if minResponses < 1 {
+		minResponses = 1
+	}
+	if len(mds.DataSources) < minResponses {
+		return nil, fmt.Errorf("MedianDataSource: MinResponses (%v) exceeds number of DataSources (%v)", minResponses, len(mds.DataSources))
+	}
+
+	type result struct {
+		value *big.Int
+		err   error
+	}
+
+	chResults := make(chan result, len(mds.DataSources))
+	var subs subprocesses.Subprocesses
+	for i, ds := range mds.DataSources {
+		i, ds := i, ds
+		subs.Go(func() {
+			value, err := ds.Observe(ctx)
+			if err == nil && value == nil {
+				err = fmt.Errorf("returned nil big.Int which should never happen")
+			}
+			if err != nil {
+				err = fmt.Errorf("DataSources[%v].Observe: %w", i, err)
+			}
+			chResults <- result{value, err}
+		})
+	}
+	subs.Wait()
+	close(chResults)
+
+	var values []*big.Int
+	var errs error
+	for r := range chResults {
+		if r.err != nil {
+			errs = multierr.Append(errs, r.err)
+		} else {
+			values = append(values, r.value)
+		}
+	}
+
+	if len(values) < minResponses {
+		if ctx.Err() != nil {
+			errs = multierr.Append(errs, ctx.Err())
+		}
+		return nil, fmt.Errorf("MedianDataSource: only received %v values, but need at least %v: %w", len(values), minResponses, errs)
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Cmp(values[j]) < 0
+	})
+	// upper median, see above
+	return new(big.Int).Set(values[len(values)/2]), nil
+}
+
+var _ DataSource = FallbackDataSource{}
+
+// FallbackDataSource queries its underlying DataSources in order and returns
+// the first value it obtains. It stops falling back once the context passed to
+// Observe has expired.
+type FallbackDataSource struct {
+	DataSources []DataSource
+}
+
+func (fds FallbackDataSource) Observe(ctx context.Context) (*big.Int, error) {
+	var errs error
+	for i, ds := range fds.DataSources {
+		if ctx.Err() != nil {
+			errs = multierr.Append(errs, ctx.Err())
+			break
+		}
+		value, err := ds.Observe(ctx)
+		if err == nil && value == nil {
+			err = fmt.Errorf("returned nil big.Int which should never happen")
+		}
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("DataSources[%v].Observe: %w", i, err))
+			continue
+		}
+		return value, nil
+	}
+	return nil, fmt.Errorf("FallbackDataSource: no DataSource returned a value: %w", errs)
+}
+
+type cachingDataSourceState int
+
+const (
+	cachingDataSourceStateUnstarted cachingDataSourceState = iota
+	cachingDataSourceStateStarted
+	cachingDataSourceStateClosed
+)
+
+var _ DataSource = (*CachingDataSource)(nil)
+
+// CachingDataSource prefetches values from an underlying DataSource in the
+// background and serves the last good value from its cache. Since Observe never
+// blocks on the underlying DataSource, it returns well within
+// MaxDurationObservation even if the underlying DataSource is slow.
+//
+// Cached values older than maxStaleness are never returned.
+type CachingDataSource struct {
+	dataSource      DataSource
+	refreshInterval time.Duration
+	refreshTimeout  time.Duration
+	maxStaleness    time.Duration
+	logger          loghelper.LoggerWithContext
+
+	lock    sync.RWMutex
+	state   cachingDataSourceState
+	value   *big.Int
+	updated time.Time
+	err     error
+
+	subprocesses subprocesses.Subprocesses
+	cancel       context.CancelFunc
+}
+
+// NewCachingDataSource returns a CachingDataSource that refreshes its cache
+// from dataSource every refreshInterval. Each refresh may take at most
+// refreshTimeout. You must call Start before calling Observe, and Close once
+// the CachingDataSource is no longer needed.
+func NewCachingDataSource(
+	dataSource DataSource,
+	refreshInterval time.Duration,
+	refreshTimeout time.Duration,
+	maxStaleness time.Duration,
+	logger commontypes.Logger,
+) (*CachingDataSource, error) {
+	if !(0 < refreshInterval) {
+		return nil, fmt.Errorf("refreshInterval (%v) must be positive", refreshInterval)
+	}
+	if !(0 < refreshTimeout) {
+		return nil, fmt.Errorf("refreshTimeout (%v) must be positive", refreshTimeout)
+	}
+	if !(refreshInterval <= maxStaleness) {
+		return nil, fmt.Errorf("maxStaleness (%v) must not be smaller than refreshInterval (%v)", maxStaleness, refreshInterval)
+	}
+	return &CachingDataSource{
+		dataSource:      dataSource,
+		refreshInterval: refreshInterval,
+		refreshTimeout:  refreshTimeout,
+		maxStaleness:    maxStaleness,
+		logger: loghelper.MakeRootLoggerWithContext(logger).MakeChild(commontypes.LogFields{
+			"dataSource": "CachingDataSource",
+		}),
+	}, nil
+}
+
+// Start spins up the background prefetching of values.
+func (cds *CachingDataSource) Start() error {
+	cds.lock.Lock()
+	defer cds.lock.Unlock()
+
+	if cds.state != cachingDataSourceStateUnstarted {
+		return fmt.Errorf("can only start CachingDataSource once")
+	}
+	cds.state = cachingDataSourceStateStarted
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cds.cancel = cancel
+	cds.subprocesses.Go(func() {
+		cds.run(ctx)
+	})
+	return nil
+}
+
+// Close stops the background prefetching of values and waits for it to exit.
+func (cds *CachingDataSource) Close() error {
+	cds.lock.Lock()
+	if cds.state != cachingDataSourceStateStarted {
+		cds.lock.Unlock()
+		return fmt.Errorf("can only close a started CachingDataSource")
+	}
+	cds.state = cachingDataSourceStateClosed
+	cds.cancel()
+	cds.lock.Unlock()
+
+	// Wait outside of the lock, since refresh needs the lock to store results.
+	cds.subprocesses.Wait()
+	return nil
+}
+
+func (cds *CachingDataSource) run(ctx context.Context) {
+	ticker := time.NewTicker(cds.refreshInterval)
+	defer ticker.Stop()
+
+	cds.refresh(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			cds.refresh(ctx)
+		case <-ctx.Done():
+			cds.logger.Debug("CachingDataSource: exiting", nil)
+			return
+		}
+	}
+}
+
+func (cds *CachingDataSource) refresh(ctx context.Context) {
+	refreshCtx, refreshCancel := context.WithTimeout(ctx, cds.refreshTimeout)
+	defer refreshCancel()
+
+	value, err := cds.dataSource.Observe(refreshCtx)
+	if err == nil && value == nil {
+		err = fmt.Errorf("underlying DataSource returned nil big.Int which should never happen")
+	}
+
+	cds.lock.Lock()
+	defer cds.lock.Unlock()
+	if err != nil {
+		cds.err = err
+		cds.logger.ErrorIfNotCanceled("CachingDataSource: error while refreshing value", refreshCtx, commontypes.LogFields{
+			"error":       err,
+			"lastUpdated": cds.updated,
+		})
+		return
+	}
+	cds.value = new(big.Int).Set(value)
+	cds.updated = time.Now()
+	cds.err = nil
+}
+
+func (cds *CachingDataSource) Observe(ctx context.Context) (*big.Int, error) {
+	cds.lock.RLock()
+	defer cds.lock.RUnlock()
+
+	if cds.state != cachingDataSourceStateStarted {
+		return nil, fmt.Errorf("CachingDataSource is not running")
+	}
+	if cds.value == nil {
+		return nil, fmt.Errorf("CachingDataSource has no cached value yet, latest error: %v", cds.err)
+	}
+	if age := time.Since(cds.updated); age > cds.maxStaleness {
+		return nil, fmt.Errorf("CachingDataSource's cached value is stale (age %v exceeds maxStaleness %v), latest error: %v", age, cds.maxStaleness, cds.err)
+	}
+	return new(big.Int).Set(cds.value), nil
+}
@@ -0,0 +1,92 @@
+package median_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
+)
+
+type failingDataSource struct{}
+
+func (failingDataSource) Observe(context.Context) (*big.Int, error) {
+	return nil, errors.New("boom")
+}
+
+// blockingDataSource returns once ctx expires and records that it has returned
+type blockingDataSource struct {
+	returned *int32
+}
+
+func (ds blockingDataSource) Observe(ctx context.Context) (*big.Int, error) {
+	<-ctx.Done()
+	// simulate some cleanup after cancellation
+	time.Sleep(10 * time.Millisecond)
+	atomic.StoreInt32(ds.returned, 1)
+	return nil, ctx.Err()
+}
+
+func TestMedianDataSource(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		dataSources  []median.DataSource
+		minResponses int
+		expected     int64
+		err          bool
+	}{
+		{"single", []median.DataSource{constantDataSource(4)}, 0, 4, false},
+		{"odd", []median.DataSource{constantDataSource(3), constantDataSource(1), constantDataSource(2)}, 0, 2, false},
+		{"even takes upper median", []median.DataSource{constantDataSource(4), constantDataSource(1), constantDataSource(3), constantDataSource(2)}, 0, 3, false},
+		{"failures are skipped", []median.DataSource{constantDataSource(1), failingDataSource{}, constantDataSource(5)}, 2, 5, false},
+		{"too few responses", []median.DataSource{constantDataSource(1), failingDataSource{}, failingDataSource{}}, 2, 0, true},
+		{"MinResponses exceeds DataSources", []median.DataSource{constantDataSource(1)}, 2, 0, true},
+		{"no DataSources", nil, 0, 0, true},
+	} {
+		value, err := median.MedianDataSource{tc.dataSources, tc.minResponses}.Observe(context.Background())
+		if tc.err {
+			if err == nil {
+				t.Errorf("%v: expected error, got %v", tc.name, value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if value.Int64() != tc.expected {
+			t.Errorf("%v: expected %v, got %v", tc.name, tc.expected, value)
+		}
+	}
+}
+
+func TestMedianDataSourceContextExpiry(t *testing.T) {
+	var returned int32
+	mds := median.MedianDataSource{
+		[]median.DataSource{constantDataSource(1), blockingDataSource{&returned}, constantDataSource(3)},
+		2,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	value, err := mds.Observe(ctx)
+	if atomic.LoadInt32(&returned) != 1 {
+		t.Fatal("Observe returned before all underlying DataSources had returned")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.Int64() != 3 {
+		t.Fatalf("expected upper median 3 of values received before expiry, got %v", value)
+	}
+
+	mds.MinResponses = 3
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := mds.Observe(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected error wrapping context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// Limiter hands out slots for calls, e.g. to cap the number of concurrent
+// calls across all plugins of an oracle instance. See supervisor.CallLimiter
+// for an implementation.
+type Limiter interface {
+	// Acquire blocks until a slot is available or ctx expires. If it returns
+	// nil error, the caller must call release once the call has returned.
+	Acquire(ctx context.Context) (release func(), err error)
+}
+
+// ConcurrencyLimit returns a Middleware that acquires a slot from limiter
+// before every call to the wrapped plugin. Calls that can't acquire a slot
+// before their context expires fail without reaching the plugin. Close and
+// OutcomeStateCommitted are not limited.
+func ConcurrencyLimit(limiter Limiter) Middleware {
+	return func(plugin types.ReportingPlugin, _ types.ReportingPluginConfig, _ types.ReportingPluginInfo) types.ReportingPlugin {
+		return withExtensionsOf(plugin, concurrencyLimitPlugin{plugin, limiter})
+	}
+}
+
+type concurrencyLimitPlugin struct {
+	plugin  types.ReportingPlugin
+	limiter Limiter
+}
+
+var _ extendedPlugin = concurrencyLimitPlugin{}
+
+func (rp concurrencyLimitPlugin) acquire(ctx context.Context, method Method) (func(), error) {
+	release, err := rp.limiter.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ConcurrencyLimit: could not acquire slot for %v: %w", method, err)
+	}
+	return release, nil
+}
+
+func (rp concurrencyLimitPlugin) Query(ctx context.Context, ts types.ReportTimestamp) (types.Query, error) {
+	release, err := rp.acquire(ctx, MethodQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return rp.plugin.Query(ctx, ts)
+}
+
+func (rp concurrencyLimitPlugin) Observation(ctx context.Context, ts types.ReportTimestamp, query types.Query) (types.Observation, error) {
+	release, err := rp.acquire(ctx, MethodObservation)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return rp.plugin.Observation(ctx, ts, query)
+}
+
+func (rp concurrencyLimitPlugin) Report(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) (bool, types.Report, error) {
+	release, err := rp.acquire(ctx, MethodReport)
+	if err != nil {
+		return false, nil, err
+	}
+	defer release()
+	return rp.plugin.Report(ctx, ts, query, aos)
+}
+
+func (rp concurrencyLimitPlugin) ShouldAcceptFinalizedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	release, err := rp.acquire(ctx, MethodShouldAcceptFinalizedReport)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	return rp.plugin.ShouldAcceptFinalizedReport(ctx, ts, report)
+}
+
+func (rp concurrencyLimitPlugin) ShouldTransmitAcceptedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	release, err := rp.acquire(ctx, MethodShouldTransmitAcceptedReport)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	return rp.plugin.ShouldTransmitAcceptedReport(ctx, ts, report)
+}
+
+func (rp concurrencyLimitPlugin) Reports(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) ([]types.Report, error) {
+	release, err := rp.acquire(ctx, MethodReports)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return rp.plugin.(types.MultiReportReportingPlugin).Reports(ctx, ts, query, aos)
+}
+
+func (rp concurrencyLimitPlugin) Outcome(ctx context.Context, ts types.ReportTimestamp, previousOutcomeState types.OutcomeState, query types.Query, aos []types.AttributedObservation) (types.OutcomeState, error) {
+	release, err := rp.acquire(ctx, MethodOutcome)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return rp.plugin.(types.OutcomeStateReportingPlugin).Outcome(ctx, ts, previousOutcomeState, query, aos)
+}
+
+// OutcomeStateCommitted must be cheap and has no context to bound waiting for
+// a slot, so it isn't limited.
+func (rp concurrencyLimitPlugin) OutcomeStateCommitted(ts types.ReportTimestamp, outcomeState types.OutcomeState) {
+	rp.plugin.(types.OutcomeStateReportingPlugin).OutcomeStateCommitted(ts, outcomeState)
+}
+
+func (rp concurrencyLimitPlugin) Close() error {
+	return rp.plugin.Close()
+}
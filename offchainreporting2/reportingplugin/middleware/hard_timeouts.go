@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// HardTimeouts returns a Middleware for plugins that don't respect context
+// expiry. If a call hasn't returned grace after its context expired, the call
+// is abandoned and an error is returned in its place. The abandoned call keeps
+// running in the background; its eventual result is discarded.
+//
+// Since abandoned calls may still be running, the wrapped plugin must be
+// thread-safe (as required by types.ReportingPlugin anyways). Close and
+// OutcomeStateCommitted are never abandoned.
+//
+// Panics in the wrapped plugin are propagated to the caller, so HardTimeouts
+// can be combined with RecoverPanics in any order.
+func HardTimeouts(logger commontypes.Logger, grace time.Duration) Middleware {
+	return func(plugin types.ReportingPlugin, configuration types.ReportingPluginConfig, info types.ReportingPluginInfo) types.ReportingPlugin {
+		return withExtensionsOf(plugin, hardTimeoutsPlugin{
+			plugin,
+			grace,
+			makeLogger(logger, "HardTimeouts", configuration, info),
+		})
+	}
+}
+
+type hardTimeoutsPlugin struct {
+	plugin types.ReportingPlugin
+	grace  time.Duration
+	logger loghelper.LoggerWithContext
+}
+
+var _ extendedPlugin = hardTimeoutsPlugin{}
+
+type hardTimeoutsResult[T any] struct {
+	value    T
+	err      error
+	panicked bool
+	panic    interface{}
+	stack    []byte
+}
+
+func runWithHardTimeout[T any](ctx context.Context, rp hardTimeoutsPlugin, method Method, f func() (T, error)) (T, error) {
+	start := time.Now()
+	chResult := make(chan hardTimeoutsResult[T], 1)
+	go func() {
+		panicked := true
+		defer func() {
+			if panicked {
+				r := recover()
+				chResult <- hardTimeoutsResult[T]{*new(T), nil, true, r, debug.Stack()}
+			}
+		}()
+		value, err := f()
+		panicked = false
+		chResult <- hardTimeoutsResult[T]{value, err, false, nil, nil}
+	}()
+
+	unwrap := func(r hardTimeoutsResult[T]) (T, error) {
+		if r.panicked {
+			rp.logger.Error("HardTimeouts: re-raising panic from ReportingPlugin", commontypes.LogFields{
+				"method": method,
+				"stack":  string(r.stack),
+			})
+			panic(r.panic)
+		}
+		return r.value, r.err
+	}
+
+	select {
+	case r := <-chResult:
+		return unwrap(r)
+	case <-ctx.Done():
+	}
+
+	timer := time.NewTimer(rp.grace)
+	defer timer.Stop()
+	select {
+	case r := <-chResult:
+		return unwrap(r)
+	case <-timer.C:
+	}
+
+	rp.logger.Error("HardTimeouts: ReportingPlugin ignored context expiry, abandoning call", commontypes.LogFields{
+		"method":  method,
+		"elapsed": time.Since(start).String(),
+		"grace":   rp.grace.String(),
+	})
+	go func() {
+		r := <-chResult
+		if r.panicked {
+			rp.logger.Critical("HardTimeouts: abandoned ReportingPlugin call panicked", commontypes.LogFields{
+				"method": method,
+				"panic":  r.panic,
+				"stack":  string(r.stack),
+			})
+		}
+	}()
+	var zero T
+	return zero, fmt.Errorf("ReportingPlugin didn't return from %v within %v of context expiry: %w", method, rp.grace, ctx.Err())
+}
+
+type reportResult struct {
+	shouldReport bool
+	report       types.Report
+}
+
+func (rp hardTimeoutsPlugin) Query(ctx context.Context, ts types.ReportTimestamp) (types.Query, error) {
+	return runWithHardTimeout(ctx, rp, MethodQuery, func() (types.Query, error) {
+		return rp.plugin.Query(ctx, ts)
+	})
+}
+
+func (rp hardTimeoutsPlugin) Observation(ctx context.Context, ts types.ReportTimestamp, query types.Query) (types.Observation, error) {
+	return runWithHardTimeout(ctx, rp, MethodObservation, func() (types.Observation, error) {
+		return rp.plugin.Observation(ctx, ts, query)
+	})
+}
+
+func (rp hardTimeoutsPlugin) Report(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) (bool, types.Report, error) {
+	result, err := runWithHardTimeout(ctx, rp, MethodReport, func() (reportResult, error) {
+		shouldReport, report, err := rp.plugin.Report(ctx, ts, query, aos)
+		return reportResult{shouldReport, report}, err
+	})
+	return result.shouldReport, result.report, err
+}
+
+func (rp hardTimeoutsPlugin) ShouldAcceptFinalizedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	return runWithHardTimeout(ctx, rp, MethodShouldAcceptFinalizedReport, func() (bool, error) {
+		return rp.plugin.ShouldAcceptFinalizedReport(ctx, ts, report)
+	})
+}
+
+func (rp hardTimeoutsPlugin) ShouldTransmitAcceptedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	return runWithHardTimeout(ctx, rp, MethodShouldTransmitAcceptedReport, func() (bool, error) {
+		return rp.plugin.ShouldTransmitAcceptedReport(ctx, ts, report)
+	})
+}
+
+func (rp hardTimeoutsPlugin) Reports(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) ([]types.Report, error) {
+	return runWithHardTimeout(ctx, rp, MethodReports, func() ([]types.Report, error) {
+		return rp.plugin.(types.MultiReportReportingPlugin).Reports(ctx, ts, query, aos)
+	})
+}
+
+func (rp hardTimeoutsPlugin) Outcome(ctx context.Context, ts types.ReportTimestamp, previousOutcomeState types.OutcomeState, query types.Query, aos []types.AttributedObservation) (types.OutcomeState, error) {
+	return runWithHardTimeout(ctx, rp, MethodOutcome, func() (types.OutcomeState, error) {
+		return rp.plugin.(types.OutcomeStateReportingPlugin).Outcome(ctx, ts, previousOutcomeState, query, aos)
+	})
+}
+
+func (rp hardTimeoutsPlugin) OutcomeStateCommitted(ts types.ReportTimestamp, outcomeState types.OutcomeState) {
+	rp.plugin.(types.OutcomeStateReportingPlugin).OutcomeStateCommitted(ts, outcomeState)
+}
+
+func (rp hardTimeoutsPlugin) Close() error {
+	return rp.plugin.Close()
+}
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// LimitCheckReportingPlugin wraps another ReportingPlugin and checks that
+// its outputs respect limits. We use it to surface violations to authors of
+// ReportingPlugins as early as possible.
+//
+// It does not check inputs since the protocol already checks those. The oracle
+// always wraps the ReportingPlugin in a LimitCheckReportingPlugin.
+type LimitCheckReportingPlugin struct {
+	Plugin types.ReportingPlugin
+	Limits types.ReportingPluginLimits
+}
+
+var _ types.ReportingPlugin = LimitCheckReportingPlugin{}
+
+// LimitCheck returns a Middleware that wraps plugins in a
+// LimitCheckReportingPlugin enforcing the limits from their
+// ReportingPluginInfo.
+func LimitCheck() Middleware {
+	return func(plugin types.ReportingPlugin, _ types.ReportingPluginConfig, info types.ReportingPluginInfo) types.ReportingPlugin {
+		return NewLimitCheckReportingPlugin(plugin, info.Limits)
+	}
+}
+
+// NewLimitCheckReportingPlugin wraps plugin in a LimitCheckReportingPlugin.
+// If plugin implements types.OutcomeStateReportingPlugin or
+// types.MultiReportReportingPlugin, so does the result.
+func NewLimitCheckReportingPlugin(plugin types.ReportingPlugin, limits types.ReportingPluginLimits) types.ReportingPlugin {
+	rp := LimitCheckReportingPlugin{plugin, limits}
+	outcomeStatePlugin, outcomeStates := plugin.(types.OutcomeStateReportingPlugin)
+	multiReportPlugin, multiReports := plugin.(types.MultiReportReportingPlugin)
+	switch {
+	case outcomeStates && multiReports:
+		return limitCheckOutcomeStateMultiReportReportingPlugin{
+			LimitCheckOutcomeStateReportingPlugin{rp, outcomeStatePlugin},
+			multiReportPlugin,
+		}
+	case outcomeStates:
+		return LimitCheckOutcomeStateReportingPlugin{rp, outcomeStatePlugin}
+	case multiReports:
+		return LimitCheckMultiReportReportingPlugin{rp, multiReportPlugin}
+	}
+	return rp
+}
+
+func (rp LimitCheckReportingPlugin) Query(ctx context.Context, ts types.ReportTimestamp) (types.Query, error) {
+	query, err := rp.Plugin.Query(ctx, ts)
+	if err != nil {
+		return nil, err
+	}
+	if !(len(query) <= rp.Limits.MaxQueryLength) {
+		return nil, fmt.Errorf("LimitCheckReportingPlugin: underlying ReportingPlugin returned oversize query (%v vs %v)", len(query), rp.Limits.MaxQueryLength)
+	}
+	return query, nil
+}
+
+func (rp LimitCheckReportingPlugin) Observation(ctx context.Context, ts types.ReportTimestamp, query types.Query) (types.Observation, error) {
+	observation, err := rp.Plugin.Observation(ctx, ts, query)
+	if err != nil {
+		return nil, err
+	}
+	if !(len(observation) <= rp.Limits.MaxObservationLength) {
+		return nil, fmt.Errorf("LimitCheckReportingPlugin: underlying ReportingPlugin returned oversize observation (%v vs %v)", len(observation), rp.Limits.MaxObservationLength)
+	}
+	return observation, nil
+}
+
+func (rp LimitCheckReportingPlugin) Report(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) (bool, types.Report, error) {
+	shouldReport, report, err := rp.Plugin.Report(ctx, ts, query, aos)
+	if err != nil {
+		return false, nil, err
+	}
+	if !(len(report) <= rp.Limits.MaxReportLength) {
+		return false, nil, fmt.Errorf("LimitCheckReportingPlugin: underlying ReportingPlugin returned oversize report (%v vs %v)", len(report), rp.Limits.MaxReportLength)
+	}
+	return shouldReport, report, nil
+}
+
+func (rp LimitCheckReportingPlugin) ShouldAcceptFinalizedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	return rp.Plugin.ShouldAcceptFinalizedReport(ctx, ts, report)
+}
+
+func (rp LimitCheckReportingPlugin) ShouldTransmitAcceptedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	return rp.Plugin.ShouldTransmitAcceptedReport(ctx, ts, report)
+}
+
+func (rp LimitCheckReportingPlugin) Close() error {
+	return rp.Plugin.Close()
+}
+
+// LimitCheckOutcomeStateReportingPlugin is the LimitCheckReportingPlugin for
+// ReportingPlugins that use outcome states. It additionally checks that
+// OutcomeStates don't exceed types.MaxOutcomeStateLength.
+type LimitCheckOutcomeStateReportingPlugin struct {
+	LimitCheckReportingPlugin
+	OutcomeStatePlugin types.OutcomeStateReportingPlugin
+}
+
+var _ types.OutcomeStateReportingPlugin = LimitCheckOutcomeStateReportingPlugin{}
+
+func (rp LimitCheckOutcomeStateReportingPlugin) Outcome(ctx context.Context, ts types.ReportTimestamp, previousOutcomeState types.OutcomeState, query types.Query, aos []types.AttributedObservation) (types.OutcomeState, error) {
+	outcomeState, err := rp.OutcomeStatePlugin.Outcome(ctx, ts, previousOutcomeState, query, aos)
+	if err != nil {
+		return nil, err
+	}
+	if !(len(outcomeState) <= types.MaxOutcomeStateLength) {
+		return nil, fmt.Errorf("LimitCheckOutcomeStateReportingPlugin: underlying ReportingPlugin returned oversize outcome state (%v vs %v)", len(outcomeState), types.MaxOutcomeStateLength)
+	}
+	return outcomeState, nil
+}
+
+func (rp LimitCheckOutcomeStateReportingPlugin) OutcomeStateCommitted(ts types.ReportTimestamp, outcomeState types.OutcomeState) {
+	rp.OutcomeStatePlugin.OutcomeStateCommitted(ts, outcomeState)
+}
+
+// LimitCheckMultiReportReportingPlugin is the LimitCheckReportingPlugin for
+// ReportingPlugins that generate multiple reports per round. It additionally
+// checks that Reports returns at most types.MaxReportsPerRound reports.
+type LimitCheckMultiReportReportingPlugin struct {
+	LimitCheckReportingPlugin
+	MultiReportPlugin types.MultiReportReportingPlugin
+}
+
+var _ types.MultiReportReportingPlugin = LimitCheckMultiReportReportingPlugin{}
+
+func (rp LimitCheckMultiReportReportingPlugin) Reports(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) ([]types.Report, error) {
+	return limitCheckReports(ctx, rp.MultiReportPlugin, rp.Limits, ts, query, aos)
+}
+
+type limitCheckOutcomeStateMultiReportReportingPlugin struct {
+	LimitCheckOutcomeStateReportingPlugin
+	multiReportPlugin types.MultiReportReportingPlugin
+}
+
+var _ types.OutcomeStateReportingPlugin = limitCheckOutcomeStateMultiReportReportingPlugin{}
+var _ types.MultiReportReportingPlugin = limitCheckOutcomeStateMultiReportReportingPlugin{}
+
+func (rp limitCheckOutcomeStateMultiReportReportingPlugin) Reports(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) ([]types.Report, error) {
+	return limitCheckReports(ctx, rp.multiReportPlugin, rp.Limits, ts, query, aos)
+}
+
+func limitCheckReports(ctx context.Context, plugin types.MultiReportReportingPlugin, limits types.ReportingPluginLimits, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) ([]types.Report, error) {
+	reports, err := plugin.Reports(ctx, ts, query, aos)
+	if err != nil {
+		return nil, err
+	}
+	if !(len(reports) <= types.MaxReportsPerRound) {
+		return nil, fmt.Errorf("LimitCheckMultiReportReportingPlugin: underlying ReportingPlugin returned too many reports (%v vs %v)", len(reports), types.MaxReportsPerRound)
+	}
+	for i, report := range reports {
+		if !(len(report) <= limits.MaxReportLength) {
+			return nil, fmt.Errorf("LimitCheckMultiReportReportingPlugin: underlying ReportingPlugin returned oversize report %v (%v vs %v)", i, len(report), limits.MaxReportLength)
+		}
+	}
+	return reports, nil
+}
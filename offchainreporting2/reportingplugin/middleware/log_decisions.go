@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// LogDecisions returns a Middleware that logs the outcome of every call to the
+// wrapped plugin at debug level, including whether a report was generated,
+// accepted, or transmitted.
+func LogDecisions(logger commontypes.Logger) Middleware {
+	return func(plugin types.ReportingPlugin, configuration types.ReportingPluginConfig, info types.ReportingPluginInfo) types.ReportingPlugin {
+		return withExtensionsOf(plugin, logDecisionsPlugin{
+			plugin,
+			makeLogger(logger, "LogDecisions", configuration, info),
+		})
+	}
+}
+
+type logDecisionsPlugin struct {
+	plugin types.ReportingPlugin
+	logger loghelper.LoggerWithContext
+}
+
+var _ extendedPlugin = logDecisionsPlugin{}
+
+func (rp logDecisionsPlugin) Query(ctx context.Context, ts types.ReportTimestamp) (types.Query, error) {
+	query, err := rp.plugin.Query(ctx, ts)
+	rp.logger.Debug("LogDecisions: Query", commontypes.LogFields{
+		"timestamp":   ts,
+		"queryLength": len(query),
+		"error":       err,
+	})
+	return query, err
+}
+
+func (rp logDecisionsPlugin) Observation(ctx context.Context, ts types.ReportTimestamp, query types.Query) (types.Observation, error) {
+	observation, err := rp.plugin.Observation(ctx, ts, query)
+	rp.logger.Debug("LogDecisions: Observation", commontypes.LogFields{
+		"timestamp":         ts,
+		"observationLength": len(observation),
+		"error":             err,
+	})
+	return observation, err
+}
+
+func (rp logDecisionsPlugin) Report(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) (bool, types.Report, error) {
+	shouldReport, report, err := rp.plugin.Report(ctx, ts, query, aos)
+	rp.logger.Debug("LogDecisions: Report", commontypes.LogFields{
+		"timestamp":       ts,
+		"numObservations": len(aos),
+		"shouldReport":    shouldReport,
+		"reportLength":    len(report),
+		"error":           err,
+	})
+	return shouldReport, report, err
+}
+
+func (rp logDecisionsPlugin) ShouldAcceptFinalizedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	shouldAccept, err := rp.plugin.ShouldAcceptFinalizedReport(ctx, ts, report)
+	rp.logger.Debug("LogDecisions: ShouldAcceptFinalizedReport", commontypes.LogFields{
+		"timestamp":    ts,
+		"shouldAccept": shouldAccept,
+		"error":        err,
+	})
+	return shouldAccept, err
+}
+
+func (rp logDecisionsPlugin) ShouldTransmitAcceptedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	shouldTransmit, err := rp.plugin.ShouldTransmitAcceptedReport(ctx, ts, report)
+	rp.logger.Debug("LogDecisions: ShouldTransmitAcceptedReport", commontypes.LogFields{
+		"timestamp":      ts,
+		"shouldTransmit": shouldTransmit,
+		"error":          err,
+	})
+	return shouldTransmit, err
+}
+
+func (rp logDecisionsPlugin) Reports(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) ([]types.Report, error) {
+	reports, err := rp.plugin.(types.MultiReportReportingPlugin).Reports(ctx, ts, query, aos)
+	reportLengths := make([]int, 0, len(reports))
+	for _, report := range reports {
+		reportLengths = append(reportLengths, len(report))
+	}
+	rp.logger.Debug("LogDecisions: Reports", commontypes.LogFields{
+		"timestamp":       ts,
+		"numObservations": len(aos),
+		"numReports":      len(reports),
+		"reportLengths":   reportLengths,
+		"error":           err,
+	})
+	return reports, err
+}
+
+func (rp logDecisionsPlugin) Outcome(ctx context.Context, ts types.ReportTimestamp, previousOutcomeState types.OutcomeState, query types.Query, aos []types.AttributedObservation) (types.OutcomeState, error) {
+	outcomeState, err := rp.plugin.(types.OutcomeStateReportingPlugin).Outcome(ctx, ts, previousOutcomeState, query, aos)
+	rp.logger.Debug("LogDecisions: Outcome", commontypes.LogFields{
+		"timestamp":                  ts,
+		"numObservations":            len(aos),
+		"previousOutcomeStateLength": len(previousOutcomeState),
+		"outcomeStateLength":         len(outcomeState),
+		"error":                      err,
+	})
+	return outcomeState, err
+}
+
+func (rp logDecisionsPlugin) OutcomeStateCommitted(ts types.ReportTimestamp, outcomeState types.OutcomeState) {
+	rp.plugin.(types.OutcomeStateReportingPlugin).OutcomeStateCommitted(ts, outcomeState)
+	rp.logger.Debug("LogDecisions: OutcomeStateCommitted", commontypes.LogFields{
+		"timestamp":          ts,
+		"outcomeStateLength": len(outcomeState),
+	})
+}
+
+func (rp logDecisionsPlugin) Close() error {
+	err := rp.plugin.Close()
+	rp.logger.Debug("LogDecisions: Close", commontypes.LogFields{
+		"error": err,
+	})
+	return err
+}
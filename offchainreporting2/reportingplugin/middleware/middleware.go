@@ -0,0 +1,139 @@
+// Package middleware provides composable wrappers around ReportingPlugins.
+// Each Middleware adds one concern (limit checks, panic recovery, hard
+// timeouts, timing, decision logging, concurrency limits) without the wrapped
+// plugin knowing about it. Use WrapFactory to apply a chain of middlewares to
+// every plugin a ReportingPluginFactory creates, e.g.
+//
+//	factory = middleware.WrapFactory(factory,
+//		middleware.RecoverPanics(logger),
+//		middleware.HardTimeouts(logger, time.Second),
+//		middleware.Timing(logger, nil),
+//		middleware.LogDecisions(logger),
+//	)
+//
+// The first middleware is the outermost one, i.e. it sees calls first and
+// results last.
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// Method identifies a function of types.ReportingPlugin.
+type Method string
+
+const (
+	MethodQuery                        Method = "Query"
+	MethodObservation                  Method = "Observation"
+	MethodReport                       Method = "Report"
+	MethodShouldAcceptFinalizedReport  Method = "ShouldAcceptFinalizedReport"
+	MethodShouldTransmitAcceptedReport Method = "ShouldTransmitAcceptedReport"
+	MethodClose                        Method = "Close"
+	// Only for types.OutcomeStateReportingPlugin
+	MethodOutcome               Method = "Outcome"
+	MethodOutcomeStateCommitted Method = "OutcomeStateCommitted"
+	// Only for types.MultiReportReportingPlugin
+	MethodReports Method = "Reports"
+)
+
+// MaxDuration returns the maximum duration configuration allows for method,
+// or zero if there is none (as for Close and OutcomeStateCommitted).
+func (m Method) MaxDuration(configuration types.ReportingPluginConfig) time.Duration {
+	switch m {
+	case MethodQuery:
+		return configuration.MaxDurationQuery
+	case MethodObservation:
+		return configuration.MaxDurationObservation
+	case MethodReport, MethodReports, MethodOutcome:
+		// The protocol bounds Reports and Outcome by MaxDurationReport, too.
+		return configuration.MaxDurationReport
+	case MethodShouldAcceptFinalizedReport:
+		return configuration.MaxDurationShouldAcceptFinalizedReport
+	case MethodShouldTransmitAcceptedReport:
+		return configuration.MaxDurationShouldTransmitAcceptedReport
+	}
+	return 0
+}
+
+// Middleware wraps plugin, which was created for configuration and described
+// by info, into another ReportingPlugin.
+type Middleware func(
+	plugin types.ReportingPlugin,
+	configuration types.ReportingPluginConfig,
+	info types.ReportingPluginInfo,
+) types.ReportingPlugin
+
+// WrapFactory returns a ReportingPluginFactory that wraps every plugin created
+// by factory in middlewares. middlewares[0] is the outermost middleware.
+func WrapFactory(factory types.ReportingPluginFactory, middlewares ...Middleware) types.ReportingPluginFactory {
+	return wrappedFactory{factory, middlewares}
+}
+
+type wrappedFactory struct {
+	factory     types.ReportingPluginFactory
+	middlewares []Middleware
+}
+
+func (fac wrappedFactory) NewReportingPlugin(configuration types.ReportingPluginConfig) (types.ReportingPlugin, types.ReportingPluginInfo, error) {
+	plugin, info, err := fac.factory.NewReportingPlugin(configuration)
+	if err != nil {
+		return nil, types.ReportingPluginInfo{}, err
+	}
+	for i := len(fac.middlewares) - 1; i >= 0; i-- {
+		plugin = fac.middlewares[i](plugin, configuration, info)
+	}
+	return plugin, info, nil
+}
+
+// extendedPlugin is implemented by every middleware's plugin.
+// Middlewares implement the optional extensions of types.ReportingPlugin
+// unconditionally and call withExtensionsOf to hide those that the wrapped
+// plugin doesn't implement, since the protocol detects the extensions through
+// type assertions.
+type extendedPlugin interface {
+	types.OutcomeStateReportingPlugin
+	Reports(context.Context, types.ReportTimestamp, types.Query, []types.AttributedObservation) ([]types.Report, error)
+}
+
+var _ types.MultiReportReportingPlugin = extendedPlugin(nil)
+
+type reportingPluginOnly struct {
+	types.ReportingPlugin
+}
+
+type outcomeStatePluginOnly struct {
+	types.OutcomeStateReportingPlugin
+}
+
+type multiReportPluginOnly struct {
+	types.MultiReportReportingPlugin
+}
+
+// withExtensionsOf returns wrapper such that it implements exactly those
+// optional extensions of types.ReportingPlugin that plugin implements.
+func withExtensionsOf(plugin types.ReportingPlugin, wrapper extendedPlugin) types.ReportingPlugin {
+	_, outcomeStates := plugin.(types.OutcomeStateReportingPlugin)
+	_, multiReports := plugin.(types.MultiReportReportingPlugin)
+	switch {
+	case outcomeStates && multiReports:
+		return wrapper
+	case outcomeStates:
+		return outcomeStatePluginOnly{wrapper}
+	case multiReports:
+		return multiReportPluginOnly{wrapper}
+	}
+	return reportingPluginOnly{wrapper}
+}
+
+func makeLogger(logger commontypes.Logger, middleware string, configuration types.ReportingPluginConfig, info types.ReportingPluginInfo) loghelper.LoggerWithContext {
+	return loghelper.MakeRootLoggerWithContext(logger).MakeChild(commontypes.LogFields{
+		"configDigest":    configuration.ConfigDigest,
+		"reportingPlugin": info.Name,
+		"middleware":      middleware,
+	})
+}
@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// RecoverPanics returns a Middleware that turns panics in the wrapped plugin
+// into errors, logging them together with their stack trace. Without it, a
+// panicking plugin takes down the entire oracle.
+func RecoverPanics(logger commontypes.Logger) Middleware {
+	return func(plugin types.ReportingPlugin, configuration types.ReportingPluginConfig, info types.ReportingPluginInfo) types.ReportingPlugin {
+		return withExtensionsOf(plugin, recoverPanicsPlugin{
+			plugin,
+			makeLogger(logger, "RecoverPanics", configuration, info),
+		})
+	}
+}
+
+type recoverPanicsPlugin struct {
+	plugin types.ReportingPlugin
+	logger loghelper.LoggerWithContext
+}
+
+var _ extendedPlugin = recoverPanicsPlugin{}
+
+func (rp recoverPanicsPlugin) recover(method Method, err *error) {
+	if r := recover(); r != nil {
+		rp.logger.Critical("RecoverPanics: ReportingPlugin panicked", commontypes.LogFields{
+			"method": method,
+			"panic":  r,
+			"stack":  string(debug.Stack()),
+		})
+		*err = fmt.Errorf("ReportingPlugin panicked in %v: %v", method, r)
+	}
+}
+
+func (rp recoverPanicsPlugin) Query(ctx context.Context, ts types.ReportTimestamp) (query types.Query, err error) {
+	defer rp.recover(MethodQuery, &err)
+	return rp.plugin.Query(ctx, ts)
+}
+
+func (rp recoverPanicsPlugin) Observation(ctx context.Context, ts types.ReportTimestamp, query types.Query) (observation types.Observation, err error) {
+	defer rp.recover(MethodObservation, &err)
+	return rp.plugin.Observation(ctx, ts, query)
+}
+
+func (rp recoverPanicsPlugin) Report(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) (shouldReport bool, report types.Report, err error) {
+	defer rp.recover(MethodReport, &err)
+	return rp.plugin.Report(ctx, ts, query, aos)
+}
+
+func (rp recoverPanicsPlugin) ShouldAcceptFinalizedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (shouldAccept bool, err error) {
+	defer rp.recover(MethodShouldAcceptFinalizedReport, &err)
+	return rp.plugin.ShouldAcceptFinalizedReport(ctx, ts, report)
+}
+
+func (rp recoverPanicsPlugin) ShouldTransmitAcceptedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (shouldTransmit bool, err error) {
+	defer rp.recover(MethodShouldTransmitAcceptedReport, &err)
+	return rp.plugin.ShouldTransmitAcceptedReport(ctx, ts, report)
+}
+
+func (rp recoverPanicsPlugin) Reports(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) (reports []types.Report, err error) {
+	defer rp.recover(MethodReports, &err)
+	return rp.plugin.(types.MultiReportReportingPlugin).Reports(ctx, ts, query, aos)
+}
+
+func (rp recoverPanicsPlugin) Outcome(ctx context.Context, ts types.ReportTimestamp, previousOutcomeState types.OutcomeState, query types.Query, aos []types.AttributedObservation) (outcomeState types.OutcomeState, err error) {
+	defer rp.recover(MethodOutcome, &err)
+	return rp.plugin.(types.OutcomeStateReportingPlugin).Outcome(ctx, ts, previousOutcomeState, query, aos)
+}
+
+// OutcomeStateCommitted can't return an error, so a panic is only logged
+func (rp recoverPanicsPlugin) OutcomeStateCommitted(ts types.ReportTimestamp, outcomeState types.OutcomeState) {
+	var err error
+	defer rp.recover(MethodOutcomeStateCommitted, &err)
+	rp.plugin.(types.OutcomeStateReportingPlugin).OutcomeStateCommitted(ts, outcomeState)
+}
+
+func (rp recoverPanicsPlugin) Close() (err error) {
+	defer rp.recover(MethodClose, &err)
+	return rp.plugin.Close()
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// Timing returns a Middleware that measures the duration of every call to the
+// wrapped plugin. Calls exceeding the corresponding MaxDurationX from the
+// ReportingPluginConfig are logged as warnings. If record is not nil, it is
+// called with every measurement, e.g. to export metrics. maxDuration is zero
+// for Close and OutcomeStateCommitted. record must be thread-safe.
+func Timing(logger commontypes.Logger, record func(method Method, duration time.Duration, maxDuration time.Duration)) Middleware {
+	return func(plugin types.ReportingPlugin, configuration types.ReportingPluginConfig, info types.ReportingPluginInfo) types.ReportingPlugin {
+		return withExtensionsOf(plugin, timingPlugin{
+			plugin,
+			configuration,
+			makeLogger(logger, "Timing", configuration, info),
+			record,
+		})
+	}
+}
+
+type timingPlugin struct {
+	plugin        types.ReportingPlugin
+	configuration types.ReportingPluginConfig
+	logger        loghelper.LoggerWithContext
+	record        func(method Method, duration time.Duration, maxDuration time.Duration)
+}
+
+var _ extendedPlugin = timingPlugin{}
+
+func (rp timingPlugin) measure(method Method, ts types.ReportTimestamp, start time.Time) {
+	duration := time.Since(start)
+	maxDuration := method.MaxDuration(rp.configuration)
+	if 0 < maxDuration && maxDuration < duration {
+		rp.logger.Warn("Timing: ReportingPlugin call exceeded MaxDuration", commontypes.LogFields{
+			"method":      method,
+			"timestamp":   ts,
+			"duration":    duration.String(),
+			"maxDuration": maxDuration.String(),
+		})
+	}
+	if rp.record != nil {
+		rp.record(method, duration, maxDuration)
+	}
+}
+
+func (rp timingPlugin) Query(ctx context.Context, ts types.ReportTimestamp) (types.Query, error) {
+	defer rp.measure(MethodQuery, ts, time.Now())
+	return rp.plugin.Query(ctx, ts)
+}
+
+func (rp timingPlugin) Observation(ctx context.Context, ts types.ReportTimestamp, query types.Query) (types.Observation, error) {
+	defer rp.measure(MethodObservation, ts, time.Now())
+	return rp.plugin.Observation(ctx, ts, query)
+}
+
+func (rp timingPlugin) Report(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) (bool, types.Report, error) {
+	defer rp.measure(MethodReport, ts, time.Now())
+	return rp.plugin.Report(ctx, ts, query, aos)
+}
+
+func (rp timingPlugin) ShouldAcceptFinalizedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	defer rp.measure(MethodShouldAcceptFinalizedReport, ts, time.Now())
+	return rp.plugin.ShouldAcceptFinalizedReport(ctx, ts, report)
+}
+
+func (rp timingPlugin) ShouldTransmitAcceptedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	defer rp.measure(MethodShouldTransmitAcceptedReport, ts, time.Now())
+	return rp.plugin.ShouldTransmitAcceptedReport(ctx, ts, report)
+}
+
+func (rp timingPlugin) Reports(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) ([]types.Report, error) {
+	defer rp.measure(MethodReports, ts, time.Now())
+	return rp.plugin.(types.MultiReportReportingPlugin).Reports(ctx, ts, query, aos)
+}
+
+func (rp timingPlugin) Outcome(ctx context.Context, ts types.ReportTimestamp, previousOutcomeState types.OutcomeState, query types.Query, aos []types.AttributedObservation) (types.OutcomeState, error) {
+	defer rp.measure(MethodOutcome, ts, time.Now())
+	return rp.plugin.(types.OutcomeStateReportingPlugin).Outcome(ctx, ts, previousOutcomeState, query, aos)
+}
+
+func (rp timingPlugin) OutcomeStateCommitted(ts types.ReportTimestamp, outcomeState types.OutcomeState) {
+	defer rp.measure(MethodOutcomeStateCommitted, ts, time.Now())
+	rp.plugin.(types.OutcomeStateReportingPlugin).OutcomeStateCommitted(ts, outcomeState)
+}
+
+func (rp timingPlugin) Close() error {
+	defer rp.measure(MethodClose, types.ReportTimestamp{}, time.Now())
+	return rp.plugin.Close()
+}
@@ -0,0 +1,222 @@
+package randomness
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/bn256"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// We use threshold BLS signatures on the BN254 (aka alt_bn128) curve, since
+// the EVM has precompiles for the required pairing checks. Signatures live in
+// G1, public keys in G2.
+
+const (
+	// Length of a marshaled G1 point (x || y), which is identical to the ABI
+	// encoding of a uint256[2].
+	g1PointLength = 64
+	// Length of a marshaled G2 point (x.imag || x.real || y.imag || y.real),
+	// which is the encoding expected by the EVM's pairing precompile.
+	g2PointLength = 128
+)
+
+var (
+	// fieldModulus is the modulus of the field over which BN254 is defined
+	fieldModulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088696311157297823662689037894645226208583", 10)
+	// groupOrder is the order of both G1 and G2
+	groupOrder, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+	// (fieldModulus + 1) / 4, for computing square roots. fieldModulus = 3 mod 4.
+	sqrtExponent = new(big.Int).Rsh(new(big.Int).Add(fieldModulus, big.NewInt(1)), 2)
+	curveB       = big.NewInt(3)
+)
+
+// beaconMessage returns the message whose threshold signature determines the
+// randomness for repts. It equals
+// keccak256(abi.encodePacked(configDigest, epoch, round)) in Solidity.
+func beaconMessage(repts types.ReportTimestamp) []byte {
+	var epoch [4]byte
+	binary.BigEndian.PutUint32(epoch[:], repts.Epoch)
+	return crypto.Keccak256(repts.ConfigDigest[:], epoch[:], []byte{repts.Round})
+}
+
+// hashToG1 deterministically maps msg to a point in G1 using try-and-increment:
+// For counter = 0, 1, ..., we compute x = keccak256(msg || counter) mod p and
+// return (x, y) for y = (x³ + 3)^((p+1)/4) mod p, provided that y² = x³ + 3.
+// This is straightforward to replicate in a contract using the modexp
+// precompile.
+func hashToG1(msg []byte) *bn256.G1 {
+	for counter := 0; counter < 256; counter++ {
+		x := new(big.Int).SetBytes(crypto.Keccak256(msg, []byte{byte(counter)}))
+		x.Mod(x, fieldModulus)
+
+		rhs := new(big.Int).Mul(x, x)
+		rhs.Mul(rhs, x)
+		rhs.Add(rhs, curveB)
+		rhs.Mod(rhs, fieldModulus)
+
+		y := new(big.Int).Exp(rhs, sqrtExponent, fieldModulus)
+		if new(big.Int).Exp(y, big.NewInt(2), fieldModulus).Cmp(rhs) != 0 {
+			continue
+		}
+
+		point, err := unmarshalG1(append(paddedBigEndian(x), paddedBigEndian(y)...))
+		if err != nil {
+			// assertion
+			panic(fmt.Sprintf("hashToG1 produced invalid point: %v", err))
+		}
+		return point
+	}
+	// Each attempt succeeds with probability ~1/2, so this never happens.
+	panic("hashToG1 failed to find a point")
+}
+
+func paddedBigEndian(x *big.Int) []byte {
+	result := make([]byte, 32)
+	return x.FillBytes(result)
+}
+
+func unmarshalG1(b []byte) (*bn256.G1, error) {
+	if len(b) != g1PointLength {
+		return nil, fmt.Errorf("G1 point has wrong length, expected %v, got %v", g1PointLength, len(b))
+	}
+	point := new(bn256.G1)
+	if _, err := point.Unmarshal(b); err != nil {
+		return nil, fmt.Errorf("invalid G1 point: %w", err)
+	}
+	return point, nil
+}
+
+func unmarshalG2(b []byte) (*bn256.G2, error) {
+	if len(b) != g2PointLength {
+		return nil, fmt.Errorf("G2 point has wrong length, expected %v, got %v", g2PointLength, len(b))
+	}
+	point := new(bn256.G2)
+	if _, err := point.Unmarshal(b); err != nil {
+		return nil, fmt.Errorf("invalid G2 point: %w", err)
+	}
+	return point, nil
+}
+
+func sign(secretKey *big.Int, msg []byte) *bn256.G1 {
+	return new(bn256.G1).ScalarMult(hashToG1(msg), secretKey)
+}
+
+// verify checks e(signature, g2) = e(H(msg), publicKey).
+func verify(publicKey *bn256.G2, msg []byte, signature *bn256.G1) bool {
+	// We compute -g2 as (groupOrder-1)*g2 rather than with Neg: Neg leaves the
+	// point's t coordinate zeroed while z may already be one, and PairingCheck
+	// only recomputes t for points with z != 1, so it would compute the
+	// pairing with a corrupted point.
+	negG2 := new(bn256.G2).ScalarBaseMult(new(big.Int).Sub(groupOrder, big.NewInt(1)))
+	return bn256.PairingCheck(
+		[]*bn256.G1{signature, hashToG1(msg)},
+		[]*bn256.G2{negG2, publicKey},
+	)
+}
+
+type signatureShare struct {
+	signer    commontypes.OracleID
+	signature *bn256.G1
+}
+
+// combineSignatureShares interpolates the group signature from exactly
+// threshold signature shares by distinct signers. Oracle i holds the evaluation
+// of the secret polynomial at i+1. Since BLS signatures are unique, the result
+// is the same for any set of valid shares.
+func combineSignatureShares(shares []signatureShare) *bn256.G1 {
+	xs := make([]*big.Int, len(shares))
+	for i, share := range shares {
+		xs[i] = big.NewInt(int64(share.signer) + 1)
+	}
+
+	var result *bn256.G1
+	for i, share := range shares {
+		// lagrange coefficient for evaluation at zero
+		num := big.NewInt(1)
+		den := big.NewInt(1)
+		for j := range shares {
+			if i == j {
+				continue
+			}
+			num.Mul(num, xs[j])
+			num.Mod(num, groupOrder)
+			den.Mul(den, new(big.Int).Sub(xs[j], xs[i]))
+			den.Mod(den, groupOrder)
+		}
+		lambda := num.Mul(num, den.ModInverse(den, groupOrder))
+		lambda.Mod(lambda, groupOrder)
+
+		term := new(bn256.G1).ScalarMult(share.signature, lambda)
+		if result == nil {
+			result = term
+		} else {
+			result = new(bn256.G1).Add(result, term)
+		}
+	}
+	return result
+}
+
+// KeyShares is the output of DealKeyShares.
+type KeyShares struct {
+	// GroupPublicKey verifies the group signatures contained in reports.
+	// It needs to be configured in the contract.
+	GroupPublicKey []byte
+	// PublicKeyShares[i] verifies the signature shares of oracle i.
+	PublicKeyShares [][]byte
+	// SecretKeyShares[i] is the secret key share of oracle i. It must only be
+	// given to oracle i.
+	SecretKeyShares []*big.Int
+}
+
+// DealKeyShares generates a fresh group key and splits it into n shares, such
+// that any f+1 of them can produce group signatures, but no f of them can.
+//
+// Whoever runs DealKeyShares learns the group secret key and can predict all
+// randomness. It is therefore only suitable for testing or for deployments
+// that trust the dealer to destroy the secret. Production deployments should
+// generate key shares using a distributed key generation protocol.
+func DealKeyShares(rng io.Reader, n int, f int) (KeyShares, error) {
+	if rng == nil {
+		rng = rand.Reader
+	}
+	if !(0 <= f && f < n) {
+		return KeyShares{}, fmt.Errorf("need 0 <= f (%v) < n (%v)", f, n)
+	}
+
+	// Random polynomial of degree f. The group secret key is coefficients[0].
+	coefficients := make([]*big.Int, f+1)
+	for i := range coefficients {
+		coefficient, err := rand.Int(rng, groupOrder)
+		if err != nil {
+			return KeyShares{}, err
+		}
+		coefficients[i] = coefficient
+	}
+
+	evaluate := func(x *big.Int) *big.Int {
+		result := big.NewInt(0)
+		for i := len(coefficients) - 1; i >= 0; i-- {
+			result.Mul(result, x)
+			result.Add(result, coefficients[i])
+			result.Mod(result, groupOrder)
+		}
+		return result
+	}
+
+	groupPublicKey := new(bn256.G2).ScalarBaseMult(coefficients[0]).Marshal()
+	publicKeyShares := make([][]byte, 0, n)
+	secretKeyShares := make([]*big.Int, 0, n)
+	for i := 0; i < n; i++ {
+		secretKeyShare := evaluate(big.NewInt(int64(i) + 1))
+		secretKeyShares = append(secretKeyShares, secretKeyShare)
+		publicKeyShares = append(publicKeyShares, new(bn256.G2).ScalarBaseMult(secretKeyShare).Marshal())
+	}
+
+	return KeyShares{groupPublicKey, publicKeyShares, secretKeyShares}, nil
+}
@@ -0,0 +1,157 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.20.0
+// source: offchainreporting2_randomness_config.proto
+
+package randomness
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RandomnessBeaconConfigProto struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupPublicKey  []byte   `protobuf:"bytes,1,opt,name=group_public_key,json=groupPublicKey,proto3" json:"group_public_key,omitempty"`
+	PublicKeyShares [][]byte `protobuf:"bytes,2,rep,name=public_key_shares,json=publicKeyShares,proto3" json:"public_key_shares,omitempty"`
+}
+
+func (x *RandomnessBeaconConfigProto) Reset() {
+	*x = RandomnessBeaconConfigProto{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_offchainreporting2_randomness_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RandomnessBeaconConfigProto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RandomnessBeaconConfigProto) ProtoMessage() {}
+
+func (x *RandomnessBeaconConfigProto) ProtoReflect() protoreflect.Message {
+	mi := &file_offchainreporting2_randomness_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RandomnessBeaconConfigProto.ProtoReflect.Descriptor instead.
+func (*RandomnessBeaconConfigProto) Descriptor() ([]byte, []int) {
+	return file_offchainreporting2_randomness_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RandomnessBeaconConfigProto) GetGroupPublicKey() []byte {
+	if x != nil {
+		return x.GroupPublicKey
+	}
+	return nil
+}
+
+func (x *RandomnessBeaconConfigProto) GetPublicKeyShares() [][]byte {
+	if x != nil {
+		return x.PublicKeyShares
+	}
+	return nil
+}
+
+var File_offchainreporting2_randomness_config_proto protoreflect.FileDescriptor
+
+var file_offchainreporting2_randomness_config_proto_rawDesc = []byte{
+	0x0a, 0x2a, 0x6f, 0x66, 0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x69, 0x6e, 0x67, 0x32, 0x5f, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x5f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x6f, 0x66,
+	0x66, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x32,
+	0x22, 0x73, 0x0a, 0x1b, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x42, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x28, 0x0a, 0x10, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x0f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x73, 0x42, 0x0e, 0x5a, 0x0c, 0x2e, 0x3b, 0x72, 0x61, 0x6e, 0x64, 0x6f,
+	0x6d, 0x6e, 0x65, 0x73, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_offchainreporting2_randomness_config_proto_rawDescOnce sync.Once
+	file_offchainreporting2_randomness_config_proto_rawDescData = file_offchainreporting2_randomness_config_proto_rawDesc
+)
+
+func file_offchainreporting2_randomness_config_proto_rawDescGZIP() []byte {
+	file_offchainreporting2_randomness_config_proto_rawDescOnce.Do(func() {
+		file_offchainreporting2_randomness_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_offchainreporting2_randomness_config_proto_rawDescData)
+	})
+	return file_offchainreporting2_randomness_config_proto_rawDescData
+}
+
+var file_offchainreporting2_randomness_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_offchainreporting2_randomness_config_proto_goTypes = []interface{}{
+	(*RandomnessBeaconConfigProto)(nil), // 0: offchainreporting2.RandomnessBeaconConfigProto
+}
+var file_offchainreporting2_randomness_config_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_offchainreporting2_randomness_config_proto_init() }
+func file_offchainreporting2_randomness_config_proto_init() {
+	if File_offchainreporting2_randomness_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_offchainreporting2_randomness_config_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RandomnessBeaconConfigProto); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_offchainreporting2_randomness_config_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_offchainreporting2_randomness_config_proto_goTypes,
+		DependencyIndexes: file_offchainreporting2_randomness_config_proto_depIdxs,
+		MessageInfos:      file_offchainreporting2_randomness_config_proto_msgTypes,
+	}.Build()
+	File_offchainreporting2_randomness_config_proto = out.File
+	file_offchainreporting2_randomness_config_proto_rawDesc = nil
+	file_offchainreporting2_randomness_config_proto_goTypes = nil
+	file_offchainreporting2_randomness_config_proto_depIdxs = nil
+}
@@ -0,0 +1,322 @@
+// Package randomness implements a ReportingPlugin for a randomness beacon. In
+// each round, every oracle contributes a threshold BLS signature share on a
+// message derived from the round's ReportTimestamp. Any f+1 valid shares are
+// combined into the group signature, which is included in the report. Since
+// BLS signatures are unique, the group signature (and therefore the randomness
+// derived from it) is fully determined by the ReportTimestamp: no set of f
+// oracles can choose a different value for a given ReportTimestamp.
+//
+// The beacon is not free of bias, though. The leader of a round receives the
+// observations, and with them at least f+1 shares, before anybody else. It can
+// therefore compute the round's randomness early and, if it doesn't like the
+// value, selectively abort the round by not finalizing the report. That
+// ReportTimestamp's value is then never published, and consumers get the
+// value of a later round instead. Each faulty leader thus gets to discard
+// outcomes it dislikes, and the published sequence of values is biased
+// accordingly. Consumers that can't tolerate this must not act on "the latest
+// randomness", but fix the ReportTimestamp whose value they will use in
+// advance and treat a missing value as a failure rather than falling back to
+// a later one. Note also that all oracles learn a round's value once its
+// report is finalized, i.e. before it appears onchain.
+//
+// A report is the 64-byte encoding of the group signature, which is identical
+// to the ABI encoding of a uint256[2]. The contract verifies it against the
+// group public key using the EVM's pairing precompile and derives the
+// randomness as keccak256(report). VerifyReport is a reference implementation
+// of the contract's checks.
+package randomness
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/bn256"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/runtime/protoimpl"
+)
+
+type OffchainConfig struct {
+	// GroupPublicKey is the marshaled G2 point that verifies group signatures.
+	GroupPublicKey []byte
+	// PublicKeyShares[i] is the marshaled G2 point that verifies the
+	// signature shares of oracle i.
+	PublicKeyShares [][]byte
+}
+
+func DecodeOffchainConfig(b []byte) (OffchainConfig, error) {
+	var configProto RandomnessBeaconConfigProto
+	if err := proto.Unmarshal(b, &configProto); err != nil {
+		return OffchainConfig{}, err
+	}
+
+	return OffchainConfig{
+		configProto.GetGroupPublicKey(),
+		configProto.GetPublicKeyShares(),
+	}, nil
+}
+
+func (c OffchainConfig) Encode() []byte {
+	configProto := RandomnessBeaconConfigProto{
+		// zero-initialize protobuf built-ins
+		protoimpl.MessageState{},
+		0,
+		nil,
+		// fields
+		c.GroupPublicKey,
+		c.PublicKeyShares,
+	}
+	result, err := proto.Marshal(&configProto)
+	if err != nil {
+		// assertion
+		panic(fmt.Sprintf("unexpected error while encoding Config: %v", err))
+	}
+	return result
+}
+
+type RandomnessContract interface {
+	// LatestTransmissionDetails returns the configDigest, epoch, and round of
+	// the latest report accepted by the contract. If no report has been
+	// accepted yet, it should return zero values, not an error.
+	LatestTransmissionDetails(
+		ctx context.Context,
+	) (
+		configDigest types.ConfigDigest,
+		epoch uint32,
+		round uint8,
+		err error,
+	)
+}
+
+var _ types.ReportingPluginFactory = RandomnessBeaconFactory{}
+
+type RandomnessBeaconFactory struct {
+	ContractTransmitter RandomnessContract
+	Logger              commontypes.Logger
+	// SecretKeyShare is this oracle's share of the group secret key, see
+	// DealKeyShares.
+	SecretKeyShare *big.Int
+}
+
+func (fac RandomnessBeaconFactory) NewReportingPlugin(configuration types.ReportingPluginConfig) (types.ReportingPlugin, types.ReportingPluginInfo, error) {
+	offchainConfig, err := DecodeOffchainConfig(configuration.OffchainConfig)
+	if err != nil {
+		return nil, types.ReportingPluginInfo{}, err
+	}
+
+	groupPublicKey, err := unmarshalG2(offchainConfig.GroupPublicKey)
+	if err != nil {
+		return nil, types.ReportingPluginInfo{}, fmt.Errorf("invalid GroupPublicKey: %w", err)
+	}
+
+	if len(offchainConfig.PublicKeyShares) != configuration.N {
+		return nil, types.ReportingPluginInfo{}, fmt.Errorf("expected %v PublicKeyShares, got %v", configuration.N, len(offchainConfig.PublicKeyShares))
+	}
+	publicKeyShares := make([]*bn256.G2, 0, len(offchainConfig.PublicKeyShares))
+	for i, b := range offchainConfig.PublicKeyShares {
+		publicKeyShare, err := unmarshalG2(b)
+		if err != nil {
+			return nil, types.ReportingPluginInfo{}, fmt.Errorf("invalid PublicKeyShares[%v]: %w", i, err)
+		}
+		publicKeyShares = append(publicKeyShares, publicKeyShare)
+	}
+
+	if fac.SecretKeyShare == nil {
+		return nil, types.ReportingPluginInfo{}, fmt.Errorf("SecretKeyShare must not be nil")
+	}
+	ownPublicKeyShare := new(bn256.G2).ScalarBaseMult(fac.SecretKeyShare).Marshal()
+	if string(ownPublicKeyShare) != string(offchainConfig.PublicKeyShares[configuration.OracleID]) {
+		return nil, types.ReportingPluginInfo{}, fmt.Errorf("SecretKeyShare doesn't match PublicKeyShares[%v]", configuration.OracleID)
+	}
+
+	logger := loghelper.MakeRootLoggerWithContext(fac.Logger).MakeChild(commontypes.LogFields{
+		"configDigest":    configuration.ConfigDigest,
+		"reportingPlugin": "RandomnessBeacon",
+	})
+
+	return &randomnessBeacon{
+			fac.ContractTransmitter,
+			logger,
+			new(big.Int).Set(fac.SecretKeyShare),
+
+			configuration.ConfigDigest,
+			configuration.F,
+			groupPublicKey,
+			publicKeyShares,
+			epochRound{},
+		}, types.ReportingPluginInfo{
+			"RandomnessBeacon",
+			false,
+			types.ReportingPluginLimits{
+				0,
+				g1PointLength,
+				g1PointLength,
+			},
+		}, nil
+}
+
+var _ types.ReportingPlugin = (*randomnessBeacon)(nil)
+
+type randomnessBeacon struct {
+	contractTransmitter RandomnessContract
+	logger              loghelper.LoggerWithContext
+	secretKeyShare      *big.Int
+
+	configDigest             types.ConfigDigest
+	f                        int
+	groupPublicKey           *bn256.G2
+	publicKeyShares          []*bn256.G2
+	latestAcceptedEpochRound epochRound
+}
+
+func (rb *randomnessBeacon) Query(ctx context.Context, repts types.ReportTimestamp) (types.Query, error) {
+	return nil, nil
+}
+
+// Observation returns this oracle's signature share for repts. The share is
+// deterministic, so there is nothing for an oracle to choose.
+func (rb *randomnessBeacon) Observation(ctx context.Context, repts types.ReportTimestamp, query types.Query) (types.Observation, error) {
+	if len(query) != 0 {
+		return nil, fmt.Errorf("expected empty query")
+	}
+	return sign(rb.secretKeyShare, beaconMessage(repts)).Marshal(), nil
+}
+
+func (rb *randomnessBeacon) Report(ctx context.Context, repts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) (bool, types.Report, error) {
+	if len(query) != 0 {
+		return false, nil, fmt.Errorf("expected empty query")
+	}
+
+	msg := beaconMessage(repts)
+
+	// Sort by observer so that we verify as few shares as possible in the
+	// common case. Any f+1 valid shares yield the same group signature.
+	aos = append([]types.AttributedObservation{}, aos...)
+	sort.Slice(aos, func(i, j int) bool {
+		return aos[i].Observer < aos[j].Observer
+	})
+
+	shares := make([]signatureShare, 0, rb.f+1)
+	for _, ao := range aos {
+		if len(shares) == rb.f+1 {
+			break
+		}
+		if int(ao.Observer) >= len(rb.publicKeyShares) {
+			// should never happen since the protocol checks observers
+			continue
+		}
+		signature, err := unmarshalG1(ao.Observation)
+		if err != nil {
+			rb.logger.Warn("Report: dropping unparseable signature share", commontypes.LogFields{
+				"observer": ao.Observer,
+				"error":    err,
+			})
+			continue
+		}
+		if !verify(rb.publicKeyShares[ao.Observer], msg, signature) {
+			rb.logger.Warn("Report: dropping invalid signature share", commontypes.LogFields{
+				"observer": ao.Observer,
+			})
+			continue
+		}
+		shares = append(shares, signatureShare{ao.Observer, signature})
+	}
+
+	// By assumption, we have at most f malicious oracles, so there should be at least f+1 valid shares
+	if len(shares) < rb.f+1 {
+		return false, nil, fmt.Errorf("only received %v valid signature shares, but need at least f+1 (%v)", len(shares), rb.f+1)
+	}
+
+	groupSignature := combineSignatureShares(shares)
+	if !verify(rb.groupPublicKey, msg, groupSignature) {
+		// assertion
+		return false, nil, fmt.Errorf("combined group signature is invalid, are PublicKeyShares consistent with GroupPublicKey?")
+	}
+
+	return true, types.Report(groupSignature.Marshal()), nil
+}
+
+func (rb *randomnessBeacon) ShouldAcceptFinalizedReport(ctx context.Context, repts types.ReportTimestamp, report types.Report) (bool, error) {
+	reportEpochRound := epochRound{repts.Epoch, repts.Round}
+	if !rb.latestAcceptedEpochRound.Less(reportEpochRound) {
+		rb.logger.Debug("ShouldAcceptFinalizedReport() = false, report is stale", commontypes.LogFields{
+			"latestAcceptedEpochRound": rb.latestAcceptedEpochRound,
+			"reportEpochRound":         reportEpochRound,
+		})
+		return false, nil
+	}
+
+	stale, err := rb.isStale(ctx, reportEpochRound)
+	if err != nil {
+		return false, err
+	}
+	if stale {
+		rb.logger.Debug("ShouldAcceptFinalizedReport() = false, contract has newer report", commontypes.LogFields{
+			"reportEpochRound": reportEpochRound,
+		})
+		return false, nil
+	}
+
+	rb.latestAcceptedEpochRound = reportEpochRound
+	return true, nil
+}
+
+func (rb *randomnessBeacon) ShouldTransmitAcceptedReport(ctx context.Context, repts types.ReportTimestamp, report types.Report) (bool, error) {
+	stale, err := rb.isStale(ctx, epochRound{repts.Epoch, repts.Round})
+	if err != nil {
+		return false, err
+	}
+	return !stale, nil
+}
+
+// isStale returns true if the contract has already accepted a report from
+// reportEpochRound or later, or has moved on to a different config.
+func (rb *randomnessBeacon) isStale(ctx context.Context, reportEpochRound epochRound) (bool, error) {
+	contractConfigDigest, contractEpoch, contractRound, err := rb.contractTransmitter.LatestTransmissionDetails(ctx)
+	if err != nil {
+		return false, err
+	}
+	if contractConfigDigest != rb.configDigest {
+		return true, nil
+	}
+	return !(epochRound{contractEpoch, contractRound}).Less(reportEpochRound), nil
+}
+
+func (rb *randomnessBeacon) Close() error {
+	return nil
+}
+
+// VerifyReport checks that report contains a valid group signature for repts
+// and returns the randomness derived from it. It's a reference implementation
+// of the checks the contract needs to perform.
+func VerifyReport(groupPublicKey []byte, repts types.ReportTimestamp, report types.Report) ([32]byte, error) {
+	publicKey, err := unmarshalG2(groupPublicKey)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("invalid groupPublicKey: %w", err)
+	}
+	signature, err := unmarshalG1(report)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("invalid report: %w", err)
+	}
+	if !verify(publicKey, beaconMessage(repts), signature) {
+		return [32]byte{}, fmt.Errorf("report contains invalid group signature")
+	}
+
+	var randomness [32]byte
+	copy(randomness[:], crypto.Keccak256(report))
+	return randomness, nil
+}
+
+type epochRound struct {
+	Epoch uint32
+	Round uint8
+}
+
+func (x epochRound) Less(y epochRound) bool {
+	return x.Epoch < y.Epoch || (x.Epoch == y.Epoch && x.Round < y.Round)
+}
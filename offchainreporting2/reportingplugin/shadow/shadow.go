@@ -0,0 +1,490 @@
+// Package shadow runs a candidate ReportingPlugin in the shadow of the
+// production one, so that new plugin logic (e.g. a new ReportCodec or
+// aggregation rule) can be validated against live traffic before it is
+// switched on through the contract's configuration.
+//
+// The candidate sees the same rounds as the production plugin. Its outputs are
+// computed but never sent to other oracles or the contract; they are only
+// compared against the production plugin's outputs, and any differences are
+// logged and passed to a callback. Since the candidate's observations are never
+// sent, the candidate's Report is computed from the production plugin's query
+// and attributed observations. The candidate must therefore understand the
+// production plugin's observations.
+//
+// If the production plugin implements types.OutcomeStateReportingPlugin or
+// types.MultiReportReportingPlugin, so does the shadow plugin. A candidate
+// that implements OutcomeStateReportingPlugin, too, derives its OutcomeStates
+// from the production plugin's committed OutcomeStates, and its OutcomeStates
+// are compared like its other outputs. Reports are compared position by
+// position; a candidate that doesn't implement MultiReportReportingPlugin is
+// treated as returning the single report from Report.
+//
+// Candidate calls happen in the background, strictly in the order of the
+// production calls, and never delay or influence the production plugin. If the
+// candidate falls too far behind, calls are dropped.
+//
+// Important: The candidate is a live plugin instance and its calls are real.
+// Any side effects of the candidate happen just like those of the production
+// plugin. Build the candidate with dependencies that are isolated from the
+// production plugin's, in particular its own database tables, caches, and
+// accounts, or it may corrupt production state. For example, a directrequest
+// candidate sharing the production FulfillmentDatabase would mark requests as
+// pending or fulfilled based on its own reports, which are never transmitted.
+// Since ShouldAcceptFinalizedReport and ShouldTransmitAcceptedReport are
+// where plugins typically have side effects, the candidate's are only called
+// if ShadowFactory.CompareDecisions is set.
+package shadow
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/middleware"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/smartcontractkit/libocr/subprocesses"
+)
+
+// Maximum number of candidate calls waiting to be executed
+const maxPendingCandidateCalls = 64
+
+// Maximum number of rounds for which candidate reports are kept around for
+// comparing the candidate's ShouldAcceptFinalizedReport and
+// ShouldTransmitAcceptedReport decisions
+const maxCandidateReports = 128
+
+// Outcome is the result of a single call to a ReportingPlugin.
+type Outcome struct {
+	// Whether a report should be produced, accepted, or transmitted. For
+	// Reports, whether a report was produced at the Difference's ReportIndex.
+	// Always false for Query, Observation, and Outcome.
+	Decision bool
+	// The query, observation, report, or OutcomeState produced. Nil for
+	// ShouldAcceptFinalizedReport and ShouldTransmitAcceptedReport.
+	Output []byte
+	Err    error
+}
+
+func (o Outcome) equal(o2 Outcome, outputsEqual func(production []byte, candidate []byte) bool) bool {
+	return o.Decision == o2.Decision &&
+		outputsEqual(o.Output, o2.Output) &&
+		(o.Err == nil) == (o2.Err == nil)
+}
+
+// Difference describes a call for which the candidate's outcome differed from
+// the production plugin's.
+type Difference struct {
+	Method    middleware.Method
+	Timestamp types.ReportTimestamp
+	// Position of the report in the list returned by Reports. Zero for all
+	// other methods.
+	ReportIndex int
+	Production  Outcome
+	Candidate   Outcome
+}
+
+var _ types.ReportingPluginFactory = ShadowFactory{}
+
+// ShadowFactory creates plugins that behave exactly like those created by
+// Production, while running plugins created by Candidate in their shadow.
+type ShadowFactory struct {
+	Production types.ReportingPluginFactory
+	Candidate  types.ReportingPluginFactory
+	Logger     commontypes.Logger
+	// OnDifference is called for every difference between the outcomes of
+	// the candidate and production plugins. May be nil. Calls happen
+	// sequentially from a background goroutine, so OnDifference should return
+	// quickly.
+	OnDifference func(Difference)
+	// Equal reports whether the outputs (queries, observations, reports, or
+	// OutcomeStates) of the production and candidate plugins for method are
+	// equivalent. May be nil, in which case outputs are compared byte by
+	// byte. Use it to tolerate expected differences, e.g. observations of a
+	// price that the candidate fetched slightly later than the production
+	// plugin. Calls happen from the same goroutine as calls to OnDifference.
+	Equal func(method middleware.Method, production []byte, candidate []byte) bool
+	// CompareDecisions enables calls to the candidate's
+	// ShouldAcceptFinalizedReport and ShouldTransmitAcceptedReport. Only set
+	// it if the candidate's side effects in these functions are isolated from
+	// production, see the package documentation.
+	CompareDecisions bool
+}
+
+func (fac ShadowFactory) NewReportingPlugin(configuration types.ReportingPluginConfig) (types.ReportingPlugin, types.ReportingPluginInfo, error) {
+	production, info, err := fac.Production.NewReportingPlugin(configuration)
+	if err != nil {
+		return nil, types.ReportingPluginInfo{}, err
+	}
+
+	logger := loghelper.MakeRootLoggerWithContext(fac.Logger).MakeChild(commontypes.LogFields{
+		"configDigest":    configuration.ConfigDigest,
+		"reportingPlugin": info.Name,
+		"shadow":          true,
+	})
+
+	// A broken candidate must never affect production.
+	candidate, candidateInfo, err := fac.Candidate.NewReportingPlugin(configuration)
+	if err != nil {
+		logger.Error("ShadowFactory: error creating candidate ReportingPlugin, running without shadow", commontypes.LogFields{
+			"error": err,
+		})
+		return production, info, nil
+	}
+	logger.Info("ShadowFactory: running candidate ReportingPlugin in shadow mode", commontypes.LogFields{
+		"candidate": candidateInfo.Name,
+	})
+
+	equal := fac.Equal
+	if equal == nil {
+		equal = func(_ middleware.Method, production []byte, candidate []byte) bool {
+			return bytes.Equal(production, candidate)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sp := &shadowPlugin{
+		production,
+		candidate,
+		configuration,
+		logger,
+		fac.OnDifference,
+		equal,
+		fac.CompareDecisions,
+
+		ctx,
+		cancel,
+		subprocesses.Subprocesses{},
+		make(chan func(context.Context), maxPendingCandidateCalls),
+
+		map[types.ReportTimestamp]reportPair{},
+		nil,
+	}
+	sp.subprocesses.Go(sp.run)
+	_, outcomeStates := production.(types.OutcomeStateReportingPlugin)
+	_, multiReports := production.(types.MultiReportReportingPlugin)
+	switch {
+	case outcomeStates && multiReports:
+		return sp, info, nil
+	case outcomeStates:
+		return outcomeStatePluginOnly{sp}, info, nil
+	case multiReports:
+		return multiReportPluginOnly{sp}, info, nil
+	}
+	return reportingPluginOnly{sp}, info, nil
+}
+
+// These hide the optional extensions of types.ReportingPlugin implemented by
+// shadowPlugin if the production plugin doesn't implement them.
+
+type reportingPluginOnly struct {
+	types.ReportingPlugin
+}
+
+type outcomeStatePluginOnly struct {
+	types.OutcomeStateReportingPlugin
+}
+
+type multiReportPluginOnly struct {
+	types.MultiReportReportingPlugin
+}
+
+var _ types.OutcomeStateReportingPlugin = (*shadowPlugin)(nil)
+var _ types.MultiReportReportingPlugin = (*shadowPlugin)(nil)
+
+type shadowPlugin struct {
+	production    types.ReportingPlugin
+	candidate     types.ReportingPlugin
+	configuration types.ReportingPluginConfig
+	logger        loghelper.LoggerWithContext
+	onDifference  func(Difference)
+	equal         func(method middleware.Method, production []byte, candidate []byte) bool
+	// whether to call the candidate's ShouldAcceptFinalizedReport and
+	// ShouldTransmitAcceptedReport
+	compareDecisions bool
+
+	ctx          context.Context
+	cancel       context.CancelFunc
+	subprocesses subprocesses.Subprocesses
+	chCalls      chan func(context.Context)
+
+	// only accessed from run
+	candidateReports      map[types.ReportTimestamp]reportPair
+	candidateReportsOrder []types.ReportTimestamp
+}
+
+// reportPair holds the reports the production and candidate plugins generated
+// in the same round
+type reportPair struct {
+	production []types.Report
+	candidate  []types.Report
+}
+
+func (sp *shadowPlugin) run() {
+	for {
+		select {
+		case call := <-sp.chCalls:
+			call(sp.ctx)
+		case <-sp.ctx.Done():
+			return
+		}
+	}
+}
+
+// enqueue schedules call to be run against the candidate. call receives a
+// context that expires after the MaxDuration configured for method.
+func (sp *shadowPlugin) enqueue(method middleware.Method, ts types.ReportTimestamp, call func(ctx context.Context)) {
+	wrapped := func(ctx context.Context) {
+		if maxDuration := method.MaxDuration(sp.configuration); maxDuration > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, maxDuration)
+			defer cancel()
+		}
+		defer func() {
+			if r := recover(); r != nil {
+				sp.logger.Error("ShadowPlugin: candidate ReportingPlugin panicked", commontypes.LogFields{
+					"method":    method,
+					"timestamp": ts,
+					"panic":     r,
+				})
+			}
+		}()
+		call(ctx)
+	}
+
+	select {
+	case sp.chCalls <- wrapped:
+	default:
+		sp.logger.Warn("ShadowPlugin: candidate ReportingPlugin is falling behind, dropping call", commontypes.LogFields{
+			"method":    method,
+			"timestamp": ts,
+		})
+	}
+}
+
+func (sp *shadowPlugin) compare(method middleware.Method, ts types.ReportTimestamp, production Outcome, candidate Outcome) {
+	sp.compareReport(method, ts, 0, production, candidate)
+}
+
+func (sp *shadowPlugin) compareReport(method middleware.Method, ts types.ReportTimestamp, reportIndex int, production Outcome, candidate Outcome) {
+	outputsEqual := func(production []byte, candidate []byte) bool {
+		return sp.equal(method, production, candidate)
+	}
+	if production.equal(candidate, outputsEqual) {
+		sp.logger.Trace("ShadowPlugin: candidate agrees with production", commontypes.LogFields{
+			"method":      method,
+			"timestamp":   ts,
+			"reportIndex": reportIndex,
+		})
+		return
+	}
+	sp.logger.Info("ShadowPlugin: candidate differs from production", commontypes.LogFields{
+		"method":             method,
+		"timestamp":          ts,
+		"reportIndex":        reportIndex,
+		"productionDecision": production.Decision,
+		"candidateDecision":  candidate.Decision,
+		"productionOutput":   production.Output,
+		"candidateOutput":    candidate.Output,
+		"productionError":    production.Err,
+		"candidateError":     candidate.Err,
+	})
+	if sp.onDifference != nil {
+		sp.onDifference(Difference{method, ts, reportIndex, production, candidate})
+	}
+}
+
+func (sp *shadowPlugin) Query(ctx context.Context, ts types.ReportTimestamp) (types.Query, error) {
+	query, err := sp.production.Query(ctx, ts)
+	production := Outcome{false, query, err}
+	sp.enqueue(middleware.MethodQuery, ts, func(ctx context.Context) {
+		query, err := sp.candidate.Query(ctx, ts)
+		sp.compare(middleware.MethodQuery, ts, production, Outcome{false, query, err})
+	})
+	return query, err
+}
+
+func (sp *shadowPlugin) Observation(ctx context.Context, ts types.ReportTimestamp, query types.Query) (types.Observation, error) {
+	observation, err := sp.production.Observation(ctx, ts, query)
+	production := Outcome{false, observation, err}
+	sp.enqueue(middleware.MethodObservation, ts, func(ctx context.Context) {
+		observation, err := sp.candidate.Observation(ctx, ts, query)
+		sp.compare(middleware.MethodObservation, ts, production, Outcome{false, observation, err})
+	})
+	return observation, err
+}
+
+func (sp *shadowPlugin) Report(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) (bool, types.Report, error) {
+	shouldReport, report, err := sp.production.Report(ctx, ts, query, aos)
+	production := Outcome{shouldReport, report, err}
+	aos = append([]types.AttributedObservation{}, aos...)
+	productionReports := singleReport(shouldReport, report, err)
+	sp.enqueue(middleware.MethodReport, ts, func(ctx context.Context) {
+		shouldReport, report, err := sp.candidate.Report(ctx, ts, query, aos)
+		sp.rememberReports(ts, reportPair{productionReports, singleReport(shouldReport, report, err)})
+		sp.compare(middleware.MethodReport, ts, production, Outcome{shouldReport, report, err})
+	})
+	return shouldReport, report, err
+}
+
+func (sp *shadowPlugin) Reports(ctx context.Context, ts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) ([]types.Report, error) {
+	productionReports, productionErr := sp.production.(types.MultiReportReportingPlugin).Reports(ctx, ts, query, aos)
+	rememberedProductionReports := append([]types.Report{}, productionReports...)
+	aos = append([]types.AttributedObservation{}, aos...)
+	sp.enqueue(middleware.MethodReports, ts, func(ctx context.Context) {
+		var candidateReports []types.Report
+		var candidateErr error
+		if candidate, ok := sp.candidate.(types.MultiReportReportingPlugin); ok {
+			candidateReports, candidateErr = candidate.Reports(ctx, ts, query, aos)
+		} else {
+			var shouldReport bool
+			var report types.Report
+			shouldReport, report, candidateErr = sp.candidate.Report(ctx, ts, query, aos)
+			candidateReports = singleReport(shouldReport, report, candidateErr)
+		}
+		if candidateErr != nil {
+			candidateReports = nil
+		}
+		sp.rememberReports(ts, reportPair{rememberedProductionReports, candidateReports})
+
+		numReports := len(rememberedProductionReports)
+		if len(candidateReports) > numReports {
+			numReports = len(candidateReports)
+		}
+		if numReports == 0 {
+			// still compare errors
+			numReports = 1
+		}
+		for i := 0; i < numReports; i++ {
+			sp.compareReport(middleware.MethodReports, ts, i,
+				reportsOutcome(rememberedProductionReports, i, productionErr),
+				reportsOutcome(candidateReports, i, candidateErr),
+			)
+		}
+	})
+	return productionReports, productionErr
+}
+
+// singleReport turns the result of Report into the equivalent result of
+// Reports
+func singleReport(shouldReport bool, report types.Report, err error) []types.Report {
+	if err != nil || !shouldReport {
+		return nil
+	}
+	return []types.Report{report}
+}
+
+// reportsOutcome describes the i-th report returned by Reports. Decision is
+// false if there is no such report.
+func reportsOutcome(reports []types.Report, i int, err error) Outcome {
+	if i < len(reports) {
+		return Outcome{true, reports[i], err}
+	}
+	return Outcome{false, nil, err}
+}
+
+func (sp *shadowPlugin) Outcome(ctx context.Context, ts types.ReportTimestamp, previousOutcomeState types.OutcomeState, query types.Query, aos []types.AttributedObservation) (types.OutcomeState, error) {
+	outcomeState, err := sp.production.(types.OutcomeStateReportingPlugin).Outcome(ctx, ts, previousOutcomeState, query, aos)
+	candidate, ok := sp.candidate.(types.OutcomeStateReportingPlugin)
+	if !ok {
+		return outcomeState, err
+	}
+	production := Outcome{false, outcomeState, err}
+	aos = append([]types.AttributedObservation{}, aos...)
+	sp.enqueue(middleware.MethodOutcome, ts, func(ctx context.Context) {
+		outcomeState, err := candidate.Outcome(ctx, ts, previousOutcomeState, query, aos)
+		sp.compare(middleware.MethodOutcome, ts, production, Outcome{false, outcomeState, err})
+	})
+	return outcomeState, err
+}
+
+func (sp *shadowPlugin) OutcomeStateCommitted(ts types.ReportTimestamp, outcomeState types.OutcomeState) {
+	sp.production.(types.OutcomeStateReportingPlugin).OutcomeStateCommitted(ts, outcomeState)
+	candidate, ok := sp.candidate.(types.OutcomeStateReportingPlugin)
+	if !ok {
+		return
+	}
+	sp.enqueue(middleware.MethodOutcomeStateCommitted, ts, func(context.Context) {
+		candidate.OutcomeStateCommitted(ts, outcomeState)
+	})
+}
+
+func (sp *shadowPlugin) rememberReports(ts types.ReportTimestamp, reports reportPair) {
+	if _, ok := sp.candidateReports[ts]; !ok {
+		sp.candidateReportsOrder = append(sp.candidateReportsOrder, ts)
+	}
+	sp.candidateReports[ts] = reports
+	for len(sp.candidateReportsOrder) > maxCandidateReports {
+		delete(sp.candidateReports, sp.candidateReportsOrder[0])
+		sp.candidateReportsOrder = sp.candidateReportsOrder[1:]
+	}
+}
+
+// candidateReport returns the candidate's report corresponding to the
+// production plugin's report generated for ts, i.e. the report at the same
+// position in the list returned by Reports. It returns false if the candidate
+// didn't produce one, in which case the difference has already been reported
+// for Report or Reports.
+func (sp *shadowPlugin) candidateReport(ts types.ReportTimestamp, productionReport types.Report) (int, types.Report, bool) {
+	reports, ok := sp.candidateReports[ts]
+	if !ok {
+		return 0, nil, false
+	}
+	for i, report := range reports.production {
+		if bytes.Equal(report, productionReport) {
+			if i < len(reports.candidate) {
+				return i, reports.candidate[i], true
+			}
+			return 0, nil, false
+		}
+	}
+	return 0, nil, false
+}
+
+// For ShouldAcceptFinalizedReport and ShouldTransmitAcceptedReport, the
+// candidate decides on its own report for the same timestamp and position,
+// if it produced one and compareDecisions is set.
+func (sp *shadowPlugin) ShouldAcceptFinalizedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	shouldAccept, err := sp.production.ShouldAcceptFinalizedReport(ctx, ts, report)
+	if !sp.compareDecisions {
+		return shouldAccept, err
+	}
+	production := Outcome{shouldAccept, nil, err}
+	sp.enqueue(middleware.MethodShouldAcceptFinalizedReport, ts, func(ctx context.Context) {
+		reportIndex, candidateReport, ok := sp.candidateReport(ts, report)
+		if !ok {
+			return
+		}
+		shouldAccept, err := sp.candidate.ShouldAcceptFinalizedReport(ctx, ts, candidateReport)
+		sp.compareReport(middleware.MethodShouldAcceptFinalizedReport, ts, reportIndex, production, Outcome{shouldAccept, nil, err})
+	})
+	return shouldAccept, err
+}
+
+func (sp *shadowPlugin) ShouldTransmitAcceptedReport(ctx context.Context, ts types.ReportTimestamp, report types.Report) (bool, error) {
+	shouldTransmit, err := sp.production.ShouldTransmitAcceptedReport(ctx, ts, report)
+	if !sp.compareDecisions {
+		return shouldTransmit, err
+	}
+	production := Outcome{shouldTransmit, nil, err}
+	sp.enqueue(middleware.MethodShouldTransmitAcceptedReport, ts, func(ctx context.Context) {
+		reportIndex, candidateReport, ok := sp.candidateReport(ts, report)
+		if !ok {
+			return
+		}
+		shouldTransmit, err := sp.candidate.ShouldTransmitAcceptedReport(ctx, ts, candidateReport)
+		sp.compareReport(middleware.MethodShouldTransmitAcceptedReport, ts, reportIndex, production, Outcome{shouldTransmit, nil, err})
+	})
+	return shouldTransmit, err
+}
+
+func (sp *shadowPlugin) Close() error {
+	sp.cancel()
+	sp.subprocesses.Wait()
+	if err := sp.candidate.Close(); err != nil {
+		sp.logger.Warn("ShadowPlugin: error closing candidate ReportingPlugin", commontypes.LogFields{
+			"error": err,
+		})
+	}
+	return sp.production.Close()
+}
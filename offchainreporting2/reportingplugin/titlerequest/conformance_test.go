@@ -0,0 +1,95 @@
+package titlerequest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/smartcontractkit/libocr/gethwrappers2/ocr2titlerequest"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/conformance"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+const fakeBlockNumber = 10_000
+
+// fakeEth serves the subset of the eth JSON-RPC API used by
+// TitleRequestPlugin for a chain with a single, unfulfilled title request.
+type fakeEth struct {
+	requestLog ethtypes.Log
+}
+
+func (fakeEth) BlockNumber() hexutil.Uint64 {
+	return fakeBlockNumber
+}
+
+type filterQuery struct {
+	Topics [][]common.Hash `json:"topics"`
+}
+
+func (e fakeEth) GetLogs(q filterQuery) []ethtypes.Log {
+	if len(q.Topics) != 0 {
+		for _, topic := range q.Topics[0] {
+			if topic == e.requestLog.Topics[0] {
+				return []ethtypes.Log{e.requestLog}
+			}
+		}
+	}
+	return []ethtypes.Log{}
+}
+
+func makeRequestLog(requestID [32]byte, url string) (ethtypes.Log, error) {
+	contractABI, err := abi.JSON(strings.NewReader(ocr2titlerequest.OCR2TitleRequestABI))
+	if err != nil {
+		return ethtypes.Log{}, err
+	}
+	event := contractABI.Events["TitleRequest"]
+	data, err := event.Inputs.NonIndexed().Pack(requestID, url)
+	if err != nil {
+		return ethtypes.Log{}, err
+	}
+	return ethtypes.Log{
+		Topics:      []common.Hash{event.ID},
+		Data:        data,
+		BlockNumber: fakeBlockNumber - 2*confirmationDepth,
+	}, nil
+}
+
+func TestConformance(t *testing.T) {
+	webServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<html><head><title>Conformance</title></head></html>")
+	}))
+	defer webServer.Close()
+
+	requestLog, err := makeRequestLog([32]byte{1}, webServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", fakeEth{requestLog}); err != nil {
+		t.Fatal(err)
+	}
+	client := ethclient.NewClient(rpc.DialInProc(server))
+	defer client.Close()
+	contract, err := ocr2titlerequest.NewOCR2TitleRequest(common.Address{}, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conformance.Run(t, &TitleRequestPluginFactory{client, contract}, conformance.Options{
+		Configuration: types.ReportingPluginConfig{
+			OracleID: 0,
+			N:        4,
+			F:        1,
+		},
+	})
+}
@@ -1,3 +1,6 @@
+// Package titlerequest is a quick and dirty prototype of a ReportingPlugin that
+// fulfills requests for the titles of web pages. See package directrequest
+// for a general implementation of this request/response pattern.
 package titlerequest
 
 import (
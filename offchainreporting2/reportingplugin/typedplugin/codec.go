@@ -0,0 +1,77 @@
+package typedplugin
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec converts between values of type T and their binary representation.
+//
+// All functions on Codec should be pure and thread-safe. Decode is called on
+// data received from other oracles, so make sure to treat its input as
+// untrusted.
+type Codec[T any] interface {
+	Encode(T) ([]byte, error)
+	Decode([]byte) (T, error)
+}
+
+// Empty is the type of queries (or observations, or reports) that don't carry
+// any information, e.g. the queries of most plugins.
+type Empty struct{}
+
+var _ Codec[Empty] = EmptyCodec{}
+
+// EmptyCodec encodes Empty as the empty byte string and rejects anything else.
+type EmptyCodec struct{}
+
+func (EmptyCodec) Encode(Empty) ([]byte, error) {
+	return nil, nil
+}
+
+func (EmptyCodec) Decode(b []byte) (Empty, error) {
+	if len(b) != 0 {
+		return Empty{}, fmt.Errorf("expected empty input, got %v bytes", len(b))
+	}
+	return Empty{}, nil
+}
+
+var _ Codec[[]byte] = BytesCodec{}
+
+// BytesCodec passes byte strings through unchanged.
+type BytesCodec struct{}
+
+func (BytesCodec) Encode(b []byte) ([]byte, error) {
+	return b, nil
+}
+
+func (BytesCodec) Decode(b []byte) ([]byte, error) {
+	return b, nil
+}
+
+// NewProtoCodec returns a Codec for protobuf messages. T is the message struct
+// type, e.g. NewProtoCodec[median.NumericalMedianObservationProto]() returns a
+// Codec[*median.NumericalMedianObservationProto].
+func NewProtoCodec[T any, PT interface {
+	*T
+	proto.Message
+}]() Codec[PT] {
+	return protoCodec[T, PT]{}
+}
+
+type protoCodec[T any, PT interface {
+	*T
+	proto.Message
+}] struct{}
+
+func (protoCodec[T, PT]) Encode(m PT) ([]byte, error) {
+	return proto.Marshal(m)
+}
+
+func (protoCodec[T, PT]) Decode(b []byte) (PT, error) {
+	m := PT(new(T))
+	if err := proto.Unmarshal(b, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,248 @@
+// Package typedplugin lets authors of ReportingPlugins work with their own Go
+// types rather than raw bytes. A ReportingPlugin[Q, O, R] is turned into a
+// types.ReportingPlugin by NewReportingPluginFactory, given Codecs for its
+// queries, observations, and reports. The adapter takes care of the
+// boilerplate every plugin would otherwise have to repeat:
+//
+//   - encoding and decoding queries, observations, and reports
+//   - enforcing the plugin's ReportingPluginLimits on everything it produces
+//     and receives
+//   - defensively parsing attributed observations, dropping (and logging)
+//     oversized or malformed ones as well as duplicates from the same observer
+//   - rejecting malformed reports in ShouldAcceptFinalizedReport and
+//     ShouldTransmitAcceptedReport
+package typedplugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// AttributedObservation is the typed analogue of types.AttributedObservation.
+type AttributedObservation[O any] struct {
+	Observation O
+	Observer    commontypes.OracleID
+}
+
+// ReportingPlugin is the typed analogue of types.ReportingPlugin. See there
+// for documentation of the individual functions. The adapter guarantees that
+// query and aos have been decoded successfully, that aos contains at most one
+// observation per observer, and that every observation respects the plugin's
+// limits.
+type ReportingPlugin[Q any, O any, R any] interface {
+	Query(ctx context.Context, repts types.ReportTimestamp) (Q, error)
+
+	Observation(ctx context.Context, repts types.ReportTimestamp, query Q) (O, error)
+
+	Report(ctx context.Context, repts types.ReportTimestamp, query Q, aos []AttributedObservation[O]) (bool, R, error)
+
+	ShouldAcceptFinalizedReport(ctx context.Context, repts types.ReportTimestamp, report R) (bool, error)
+
+	ShouldTransmitAcceptedReport(ctx context.Context, repts types.ReportTimestamp, report R) (bool, error)
+
+	Close() error
+}
+
+// ReportingPluginFactory is the typed analogue of
+// types.ReportingPluginFactory.
+type ReportingPluginFactory[Q any, O any, R any] interface {
+	NewReportingPlugin(types.ReportingPluginConfig) (ReportingPlugin[Q, O, R], types.ReportingPluginInfo, error)
+}
+
+type Codecs[Q any, O any, R any] struct {
+	Query       Codec[Q]
+	Observation Codec[O]
+	Report      Codec[R]
+}
+
+// NewReportingPluginFactory wraps factory into a types.ReportingPluginFactory
+// that can be passed to the oracle.
+func NewReportingPluginFactory[Q any, O any, R any](
+	factory ReportingPluginFactory[Q, O, R],
+	codecs Codecs[Q, O, R],
+	logger commontypes.Logger,
+) types.ReportingPluginFactory {
+	return reportingPluginFactory[Q, O, R]{factory, codecs, logger}
+}
+
+type reportingPluginFactory[Q any, O any, R any] struct {
+	factory ReportingPluginFactory[Q, O, R]
+	codecs  Codecs[Q, O, R]
+	logger  commontypes.Logger
+}
+
+func (fac reportingPluginFactory[Q, O, R]) NewReportingPlugin(configuration types.ReportingPluginConfig) (types.ReportingPlugin, types.ReportingPluginInfo, error) {
+	plugin, info, err := fac.factory.NewReportingPlugin(configuration)
+	if err != nil {
+		return nil, types.ReportingPluginInfo{}, err
+	}
+
+	logger := loghelper.MakeRootLoggerWithContext(fac.logger).MakeChild(commontypes.LogFields{
+		"configDigest":    configuration.ConfigDigest,
+		"reportingPlugin": info.Name,
+	})
+
+	return &reportingPlugin[Q, O, R]{
+		plugin,
+		fac.codecs,
+		info.Limits,
+		logger,
+	}, info, nil
+}
+
+var _ types.ReportingPlugin = (*reportingPlugin[Empty, Empty, Empty])(nil)
+
+type reportingPlugin[Q any, O any, R any] struct {
+	plugin ReportingPlugin[Q, O, R]
+	codecs Codecs[Q, O, R]
+	limits types.ReportingPluginLimits
+	logger loghelper.LoggerWithContext
+}
+
+func (rp *reportingPlugin[Q, O, R]) Query(ctx context.Context, repts types.ReportTimestamp) (types.Query, error) {
+	query, err := rp.plugin.Query(ctx, repts)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := rp.codecs.Query.Encode(query)
+	if err != nil {
+		return nil, fmt.Errorf("error while encoding query: %w", err)
+	}
+	if !(len(encoded) <= rp.limits.MaxQueryLength) {
+		return nil, fmt.Errorf("query violates MaxQueryLength (%v vs %v)", len(encoded), rp.limits.MaxQueryLength)
+	}
+	return encoded, nil
+}
+
+func (rp *reportingPlugin[Q, O, R]) Observation(ctx context.Context, repts types.ReportTimestamp, query types.Query) (types.Observation, error) {
+	decodedQuery, err := rp.decodeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	observation, err := rp.plugin.Observation(ctx, repts, decodedQuery)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := rp.codecs.Observation.Encode(observation)
+	if err != nil {
+		return nil, fmt.Errorf("error while encoding observation: %w", err)
+	}
+	if !(len(encoded) <= rp.limits.MaxObservationLength) {
+		return nil, fmt.Errorf("observation violates MaxObservationLength (%v vs %v)", len(encoded), rp.limits.MaxObservationLength)
+	}
+	return encoded, nil
+}
+
+func (rp *reportingPlugin[Q, O, R]) Report(ctx context.Context, repts types.ReportTimestamp, query types.Query, aos []types.AttributedObservation) (bool, types.Report, error) {
+	decodedQuery, err := rp.decodeQuery(query)
+	if err != nil {
+		return false, nil, err
+	}
+
+	decodedAOs := make([]AttributedObservation[O], 0, len(aos))
+	seenObservers := map[commontypes.OracleID]bool{}
+	for i, ao := range aos {
+		if seenObservers[ao.Observer] {
+			rp.logger.Warn("Report: dropping duplicate observation", commontypes.LogFields{
+				"observer": ao.Observer,
+				"i":        i,
+			})
+			continue
+		}
+		seenObservers[ao.Observer] = true
+
+		if !(len(ao.Observation) <= rp.limits.MaxObservationLength) {
+			rp.logger.Warn("Report: dropping observation violating MaxObservationLength", commontypes.LogFields{
+				"observer":             ao.Observer,
+				"observationLength":    len(ao.Observation),
+				"maxObservationLength": rp.limits.MaxObservationLength,
+				"i":                    i,
+			})
+			continue
+		}
+		observation, err := rp.codecs.Observation.Decode(ao.Observation)
+		if err != nil {
+			rp.logger.Warn("Report: dropping invalid observation", commontypes.LogFields{
+				"observer": ao.Observer,
+				"error":    err,
+				"i":        i,
+			})
+			continue
+		}
+		decodedAOs = append(decodedAOs, AttributedObservation[O]{observation, ao.Observer})
+	}
+
+	should, report, err := rp.plugin.Report(ctx, repts, decodedQuery, decodedAOs)
+	if err != nil {
+		return false, nil, err
+	}
+	if !should {
+		return false, nil, nil
+	}
+	encoded, err := rp.codecs.Report.Encode(report)
+	if err != nil {
+		return false, nil, fmt.Errorf("error while encoding report: %w", err)
+	}
+	if !(len(encoded) <= rp.limits.MaxReportLength) {
+		return false, nil, fmt.Errorf("report violates MaxReportLength (%v vs %v)", len(encoded), rp.limits.MaxReportLength)
+	}
+	return true, encoded, nil
+}
+
+func (rp *reportingPlugin[Q, O, R]) ShouldAcceptFinalizedReport(ctx context.Context, repts types.ReportTimestamp, report types.Report) (bool, error) {
+	decodedReport, err := rp.decodeReport(report)
+	if err != nil {
+		rp.logger.Warn("ShouldAcceptFinalizedReport() = false, invalid report", commontypes.LogFields{
+			"timestamp": repts,
+			"error":     err,
+		})
+		return false, nil
+	}
+	return rp.plugin.ShouldAcceptFinalizedReport(ctx, repts, decodedReport)
+}
+
+func (rp *reportingPlugin[Q, O, R]) ShouldTransmitAcceptedReport(ctx context.Context, repts types.ReportTimestamp, report types.Report) (bool, error) {
+	decodedReport, err := rp.decodeReport(report)
+	if err != nil {
+		rp.logger.Warn("ShouldTransmitAcceptedReport() = false, invalid report", commontypes.LogFields{
+			"timestamp": repts,
+			"error":     err,
+		})
+		return false, nil
+	}
+	return rp.plugin.ShouldTransmitAcceptedReport(ctx, repts, decodedReport)
+}
+
+func (rp *reportingPlugin[Q, O, R]) Close() error {
+	return rp.plugin.Close()
+}
+
+func (rp *reportingPlugin[Q, O, R]) decodeQuery(query types.Query) (Q, error) {
+	if !(len(query) <= rp.limits.MaxQueryLength) {
+		var zero Q
+		return zero, fmt.Errorf("query violates MaxQueryLength (%v vs %v)", len(query), rp.limits.MaxQueryLength)
+	}
+	decoded, err := rp.codecs.Query.Decode(query)
+	if err != nil {
+		var zero Q
+		return zero, fmt.Errorf("error while decoding query: %w", err)
+	}
+	return decoded, nil
+}
+
+func (rp *reportingPlugin[Q, O, R]) decodeReport(report types.Report) (R, error) {
+	if !(len(report) <= rp.limits.MaxReportLength) {
+		var zero R
+		return zero, fmt.Errorf("report violates MaxReportLength (%v vs %v)", len(report), rp.limits.MaxReportLength)
+	}
+	decoded, err := rp.codecs.Report.Decode(report)
+	if err != nil {
+		var zero R
+		return zero, fmt.Errorf("error while decoding report: %w", err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,165 @@
+// Package transmissionschedule provides implementations of
+// types.TransmissionScheduler. Staged is the default schedule. The other
+// schedulers wrap another scheduler and can be combined, e.g.
+//
+//	scheduler := transmissionschedule.GasPriceBackoff(
+//		transmissionschedule.ExcludeAccounts(
+//			transmissionschedule.RotateByRound(transmissionschedule.Staged()),
+//			isLowBalance,
+//		),
+//		gasPrice, threshold, backoff,
+//	)
+//
+// To keep schedules consistent across oracles, callbacks should give the same
+// answers at all oracles, e.g. by reading from the chain rather than from
+// local state.
+package transmissionschedule
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// Staged returns the default TransmissionScheduler: Oracles transmit in
+// stages spaced DeltaStage apart, where stage i consists of the next S[i]
+// oracles in TransmissionOrder. Oracles beyond sum(S) don't transmit.
+func Staged() types.TransmissionScheduler {
+	return staged{}
+}
+
+type staged struct{}
+
+func (staged) Schedule(ctx context.Context, sctx types.TransmissionScheduleContext) (time.Duration, bool, error) {
+	if !(0 <= int(sctx.OracleID) && int(sctx.OracleID) < len(sctx.TransmissionOrder)) {
+		return 0, false, nil
+	}
+	position := sctx.TransmissionOrder[sctx.OracleID]
+	sum := 0
+	for i, s := range sctx.S {
+		sum += s
+		if position < sum {
+			return time.Duration(i) * sctx.DeltaStage, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// RotateByRound replaces TransmissionOrder with a rotation that advances by
+// one oracle every round, so that every oracle gets to be first in turn. This
+// spreads transmission fees evenly across oracles, but makes the order
+// predictable to outsiders. Rounds are numbered epoch*RMax + round - 1, so
+// the rotation also advances by one from the last round of an epoch to the
+// first round of the next. (Epochs that end early or are skipped leave gaps.)
+func RotateByRound(scheduler types.TransmissionScheduler) types.TransmissionScheduler {
+	return rotateByRound{scheduler}
+}
+
+type rotateByRound struct {
+	scheduler types.TransmissionScheduler
+}
+
+func (r rotateByRound) Schedule(ctx context.Context, sctx types.TransmissionScheduleContext) (time.Duration, bool, error) {
+	n := uint64(len(sctx.TransmissionOrder))
+	if n == 0 {
+		return r.scheduler.Schedule(ctx, sctx)
+	}
+	// rounds start with 1
+	rotation := (uint64(sctx.ReportContext.Epoch)*uint64(sctx.RMax) + uint64(sctx.ReportContext.Round) - 1) % n
+	order := make([]int, n)
+	for i := range order {
+		order[i] = int((uint64(i) + n - rotation) % n)
+	}
+	sctx.TransmissionOrder = order
+	return r.scheduler.Schedule(ctx, sctx)
+}
+
+// ExcludeAccounts moves oracles whose transmit account is excluded to the end
+// of TransmissionOrder, e.g. because its balance is too low to pay for a
+// transmission. If the local oracle's account is excluded, it doesn't
+// transmit at all. isExcluded is called once for each oracle. If it returns
+// an error, the account is not excluded.
+func ExcludeAccounts(
+	scheduler types.TransmissionScheduler,
+	isExcluded func(ctx context.Context, target string, account types.Account) (bool, error),
+) types.TransmissionScheduler {
+	return excludeAccounts{scheduler, isExcluded}
+}
+
+type excludeAccounts struct {
+	scheduler  types.TransmissionScheduler
+	isExcluded func(ctx context.Context, target string, account types.Account) (bool, error)
+}
+
+func (e excludeAccounts) Schedule(ctx context.Context, sctx types.TransmissionScheduleContext) (time.Duration, bool, error) {
+	if len(sctx.TransmitAccounts) != len(sctx.TransmissionOrder) {
+		return e.scheduler.Schedule(ctx, sctx)
+	}
+
+	excluded := make([]bool, len(sctx.TransmitAccounts))
+	for i, account := range sctx.TransmitAccounts {
+		isExcluded, err := e.isExcluded(ctx, sctx.Target, account)
+		excluded[i] = err == nil && isExcluded
+	}
+	if 0 <= int(sctx.OracleID) && int(sctx.OracleID) < len(excluded) && excluded[sctx.OracleID] {
+		return 0, false, nil
+	}
+
+	// byPosition[p] is the oracle at position p
+	byPosition := make([]commontypes.OracleID, len(sctx.TransmissionOrder))
+	for oracle, position := range sctx.TransmissionOrder {
+		if !(0 <= position && position < len(byPosition)) {
+			// TransmissionOrder isn't a permutation, leave it alone
+			return e.scheduler.Schedule(ctx, sctx)
+		}
+		byPosition[position] = commontypes.OracleID(oracle)
+	}
+
+	order := make([]int, len(sctx.TransmissionOrder))
+	next := 0
+	for _, includeExcluded := range []bool{false, true} {
+		for _, oracle := range byPosition {
+			if excluded[oracle] == includeExcluded {
+				order[oracle] = next
+				next++
+			}
+		}
+	}
+	sctx.TransmissionOrder = order
+	return e.scheduler.Schedule(ctx, sctx)
+}
+
+// GasPriceBackoff delays transmission by an additional backoff while the gas
+// price returned by gasPrice exceeds threshold, giving spikes a chance to
+// subside before oracles compete for inclusion. If gasPrice returns an error,
+// no backoff is applied.
+func GasPriceBackoff(
+	scheduler types.TransmissionScheduler,
+	gasPrice func(ctx context.Context, target string) (*big.Int, error),
+	threshold *big.Int,
+	backoff time.Duration,
+) types.TransmissionScheduler {
+	return gasPriceBackoff{scheduler, gasPrice, threshold, backoff}
+}
+
+type gasPriceBackoff struct {
+	scheduler types.TransmissionScheduler
+	gasPrice  func(ctx context.Context, target string) (*big.Int, error)
+	threshold *big.Int
+	backoff   time.Duration
+}
+
+func (g gasPriceBackoff) Schedule(ctx context.Context, sctx types.TransmissionScheduleContext) (time.Duration, bool, error) {
+	delay, transmit, err := g.scheduler.Schedule(ctx, sctx)
+	if err != nil || !transmit {
+		return delay, transmit, err
+	}
+	gasPrice, err := g.gasPrice(ctx, sctx.Target)
+	if err == nil && gasPrice != nil && gasPrice.Cmp(g.threshold) > 0 {
+		delay += g.backoff
+	}
+	return delay, transmit, nil
+}
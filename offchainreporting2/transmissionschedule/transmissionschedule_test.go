@@ -0,0 +1,143 @@
+package transmissionschedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// recordingScheduler records the TransmissionOrder it is called with
+type recordingScheduler struct {
+	order *[]int
+}
+
+func (r recordingScheduler) Schedule(ctx context.Context, sctx types.TransmissionScheduleContext) (time.Duration, bool, error) {
+	*r.order = sctx.TransmissionOrder
+	return 0, true, nil
+}
+
+func rotatedOrder(t *testing.T, n int, rMax uint8, epoch uint32, round uint8) []int {
+	var order []int
+	sctx := types.TransmissionScheduleContext{
+		ReportContext:     types.ReportContext{ReportTimestamp: types.ReportTimestamp{Epoch: epoch, Round: round}},
+		RMax:              rMax,
+		TransmissionOrder: make([]int, n),
+	}
+	if _, _, err := RotateByRound(recordingScheduler{&order}).Schedule(context.Background(), sctx); err != nil {
+		t.Fatal(err)
+	}
+	return order
+}
+
+// first returns the oracle at position 0 of order
+func first(t *testing.T, order []int) int {
+	firsts := 0
+	result := -1
+	seen := make([]bool, len(order))
+	for oracle, position := range order {
+		if !(0 <= position && position < len(order)) || seen[position] {
+			t.Fatalf("%v isn't a permutation", order)
+		}
+		seen[position] = true
+		if position == 0 {
+			firsts++
+			result = oracle
+		}
+	}
+	if firsts != 1 {
+		t.Fatalf("%v doesn't have exactly one first oracle", order)
+	}
+	return result
+}
+
+func TestRotateByRound(t *testing.T) {
+	const n = 4
+	const rMax = 3
+
+	for _, tc := range []struct {
+		epoch uint32
+		round uint8
+		first int
+	}{
+		{0, 1, 0},
+		{0, 2, 1},
+		{0, 3, 2},
+		// the last round of an epoch is followed by the first round of the next
+		{1, 1, 3},
+		{1, 2, 0},
+		{2, 1, 2},
+		// skipped epochs leave gaps
+		{5, 1, (5*rMax + 1 - 1) % n},
+	} {
+		order := rotatedOrder(t, n, rMax, tc.epoch, tc.round)
+		if f := first(t, order); f != tc.first {
+			t.Errorf("epoch %v, round %v: expected oracle %v first, got %v (order %v)", tc.epoch, tc.round, tc.first, f, order)
+		}
+	}
+
+	// within and across epochs, the rotation advances by one oracle per round
+	// and every oracle keeps its position relative to the first oracle
+	prev := rotatedOrder(t, n, rMax, 1, 1)
+	for epoch := uint32(1); epoch < 5; epoch++ {
+		for round := uint8(1); round <= rMax; round++ {
+			if epoch == 1 && round == 1 {
+				continue
+			}
+			order := rotatedOrder(t, n, rMax, epoch, round)
+			for oracle := range order {
+				if order[oracle] != (prev[oracle]+n-1)%n {
+					t.Fatalf("epoch %v, round %v: order %v doesn't advance previous order %v by one", epoch, round, order, prev)
+				}
+			}
+			prev = order
+		}
+	}
+}
+
+func TestRotateByRoundStaged(t *testing.T) {
+	const n = 4
+	sctx := types.TransmissionScheduleContext{
+		ReportContext:     types.ReportContext{ReportTimestamp: types.ReportTimestamp{Epoch: 1, Round: 2}},
+		S:                 []int{1, 2},
+		DeltaStage:        time.Second,
+		RMax:              3,
+		TransmissionOrder: []int{3, 2, 1, 0},
+	}
+	// rotation is 1*3 + 2 - 1 = 4 = 0 mod n, regardless of the original order
+	expected := []struct {
+		delay    time.Duration
+		transmit bool
+	}{
+		{0, true},
+		{time.Second, true},
+		{time.Second, true},
+		{0, false},
+	}
+	for oracle := 0; oracle < n; oracle++ {
+		sctx.OracleID = commontypes.OracleID(oracle)
+		delay, transmit, err := RotateByRound(Staged()).Schedule(context.Background(), sctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if delay != expected[oracle].delay || transmit != expected[oracle].transmit {
+			t.Errorf("oracle %v: expected (%v, %v), got (%v, %v)", oracle, expected[oracle].delay, expected[oracle].transmit, delay, transmit)
+		}
+	}
+}
+
+func TestRotateByRoundEmptyOrder(t *testing.T) {
+	var order []int
+	sctx := types.TransmissionScheduleContext{
+		ReportContext: types.ReportContext{ReportTimestamp: types.ReportTimestamp{Epoch: 1, Round: 1}},
+		RMax:          3,
+	}
+	if _, _, err := RotateByRound(recordingScheduler{&order}).Schedule(context.Background(), sctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(order) != 0 {
+		t.Fatalf("expected empty order, got %v", order)
+	}
+}
@@ -25,17 +25,91 @@ type Database interface {
 	ReadState(ctx context.Context, configDigest ConfigDigest) (*PersistentState, error)
 	WriteState(ctx context.Context, configDigest ConfigDigest, state PersistentState) error
 
-	StorePendingTransmission(context.Context, ReportTimestamp, PendingTransmission) error
-	PendingTransmissionsWithConfigDigest(context.Context, ConfigDigest) (map[ReportTimestamp]PendingTransmission, error)
-	DeletePendingTransmission(context.Context, ReportTimestamp) error
+	StorePendingTransmission(context.Context, PendingTransmissionKey, PendingTransmission) error
+	PendingTransmissionsWithConfigDigest(context.Context, ConfigDigest) (map[PendingTransmissionKey]PendingTransmission, error)
+	DeletePendingTransmission(context.Context, PendingTransmissionKey) error
 	DeletePendingTransmissionsOlderThan(context.Context, time.Time) error
 }
 
+// OutcomeStateDatabase is an optional extension of Database. If the Database
+// implements it, the latest committed OutcomeState survives restarts of the
+// oracle. Otherwise, oracles start out with an empty OutcomeState after a
+// restart. See OutcomeStateReportingPlugin.
+//
+// All its functions should be thread-safe.
+type OutcomeStateDatabase interface {
+	// ReadOutcomeState returns nil if no OutcomeState has been written for
+	// configDigest yet.
+	ReadOutcomeState(ctx context.Context, configDigest ConfigDigest) (*PersistentOutcomeState, error)
+	WriteOutcomeState(ctx context.Context, configDigest ConfigDigest, state PersistentOutcomeState) error
+}
+
+// ConfigHistoryDatabase is an optional extension of ConfigDatabase. If the
+// ConfigDatabase implements it, every config the oracle enacts is recorded,
+// e.g. for post-incident analysis of when each oracle switched configs. See
+// package confighistory for queries.
+//
+// All its functions should be thread-safe.
+type ConfigHistoryDatabase interface {
+	// WriteConfigHistoryEntry inserts entry, or replaces the entry with the
+	// same Sequence.
+	WriteConfigHistoryEntry(ctx context.Context, entry ConfigHistoryEntry) error
+	// ReadConfigHistory returns all entries, ordered by Sequence.
+	ReadConfigHistory(ctx context.Context) ([]ConfigHistoryEntry, error)
+}
+
+type ConfigHistoryEntry struct {
+	// Sequence numbers entries in the order in which the configs were
+	// enacted, starting at 1. A config that is enacted again, e.g. after a
+	// re-org, gets a new entry.
+	Sequence     uint64
+	ConfigDigest ConfigDigest
+	ConfigCount  uint64
+	// BlockNumber is the block in which the config was set, or zero if
+	// unknown, e.g. for a config restored from the ConfigDatabase on startup.
+	BlockNumber uint64
+	// FirstSeen is the time at which the oracle enacted the config
+	FirstSeen time.Time
+	// ReplacedBy is the digest of the config that replaced this one and
+	// ReplacedAt the time of the replacement. Both are zero while this config
+	// is active.
+	ReplacedBy ConfigDigest
+	ReplacedAt time.Time
+}
+
+type PersistentOutcomeState struct {
+	Epoch        uint32
+	Round        uint8
+	OutcomeState OutcomeState
+}
+
+// PendingTransmissionKey identifies a PendingTransmission. Since a
+// MultiReportReportingPlugin may generate several reports per round and each
+// report may be transmitted to several TransmissionTargets, the
+// ReportTimestamp alone isn't sufficient.
+type PendingTransmissionKey struct {
+	ReportTimestamp
+	ReportIndex uint8
+	// Name of the TransmissionTarget, empty for the primary target
+	Target string
+}
+
 type PendingTransmission struct {
 	Time                 time.Time
 	ExtraHash            [32]byte
 	Report               Report
 	AttributedSignatures []AttributedOnchainSignature
+	// Time at which the report was accepted for transmission, i.e. the start
+	// of its transmission schedule. Retransmissions are scheduled relative to
+	// it, so a zero AcceptedTime prevents retransmission.
+	AcceptedTime time.Time
+	// Number of times the report has been re-queued after its transmission
+	// failed, see TransmissionResultReporter.
+	Retransmissions int
+	// Set once the report has been transmitted to a TransmissionTarget whose
+	// ContractTransmitter is a TransmissionResultReporter, until the result
+	// of the transmission is reported.
+	AwaitingResult bool
 }
 
 type PersistentState struct {
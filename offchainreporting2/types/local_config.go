@@ -13,6 +13,35 @@ type LocalConfig struct {
 	// blocking forever on a chain interaction would break the oracle.)
 	BlockchainTimeout time.Duration
 
+	// When the contract configuration changes, the protocol instance for the
+	// old configuration keeps running for up to ConfigHandoverWindow while
+	// the instance for the new configuration starts up. This way, reports in
+	// flight and pending transmissions of the old instance aren't lost while
+	// the new instance sets up networking and reaches its first epoch. Once
+	// the new instance has reached its first epoch (i.e. has agreed on an
+	// epoch with the other oracles), the old instance no longer accepts new
+	// reports for transmission and works off its pending transmissions,
+	// including those whose TransmissionResult is outstanding. It is stopped
+	// as soon as it has none left or the window has passed, whichever comes
+	// first. If yet another configuration change happens in the meantime, the
+	// old instance is stopped immediately.
+	//
+	// During the handover, the old instance remains responsible for
+	// transmitting. It stops transmitting to a target as soon as the target's
+	// ContractTransmitter.LatestConfigDigestAndEpoch returns the new config
+	// digest, since the target won't accept reports for the old config from
+	// then on. The new instance doesn't transmit until the old instance has
+	// stopped: Its calls to Transmit wait for up to
+	// ContractTransmitterTransmitTimeout and fail afterwards. Likewise, a
+	// MultiAccountContractTransmitter is switched to the new configuration's
+	// account only once the old instance has stopped. Both instances share
+	// the ContractTransmitters; results from a TransmissionResultReporter
+	// are routed to the instance whose config digest they carry.
+	//
+	// Zero (the default) disables handover: the old instance is stopped
+	// before the new one is started.
+	ConfigHandoverWindow time.Duration
+
 	// Number of block confirmations to wait for before enacting an on-chain
 	// configuration change. This value doesn't need to be very high (in
 	// particular, it does not need to protect against malicious re-orgs).
@@ -54,6 +83,13 @@ type LocalConfig struct {
 	// fifteen seconds and two minutes.
 	ContractConfigTrackerPollInterval time.Duration
 
+	// Each poll of ContractConfigTracker is delayed by a random duration of up
+	// to ContractConfigTrackerPollJitter in addition to
+	// ContractConfigTrackerPollInterval. When many oracles run in the same
+	// process, this spreads their polls over time instead of having all of
+	// them hit the RPC provider at once. Zero disables jitter.
+	ContractConfigTrackerPollJitter time.Duration
+
 	// Timeout for ContractTransmitter.Transmit calls.
 	ContractTransmitterTransmitTimeout time.Duration
 
@@ -65,4 +101,9 @@ type LocalConfig struct {
 	// DANGER, this turns off all kinds of sanity checks. May be useful for testing.
 	// Set this to EnableDangerousDevelopmentMode to turn on dev mode.
 	DevelopmentMode string
+
+	// Maximum number of times a report is re-queued after a
+	// TransmissionResultReporter reports that its transmission failed. Zero
+	// disables retransmission.
+	MaxRetransmissions int
 }
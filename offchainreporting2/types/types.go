@@ -71,6 +71,9 @@ type ReportContext struct {
 	// protocol. The data itself is not needed onchain, but we still want to
 	// include it in the signature that goes onchain.
 	ExtraHash [32]byte
+	// Position of the report among the reports generated in its round. Always
+	// zero unless the ReportingPlugin implements MultiReportReportingPlugin.
+	ReportIndex uint8
 }
 
 type Report []byte
@@ -267,6 +270,92 @@ type ReportingPlugin interface {
 	Close() error
 }
 
+// OutcomeState is agreed-upon state that is replicated across rounds, see
+// OutcomeStateReportingPlugin.
+type OutcomeState []byte
+
+// OutcomeStateReportingPlugin is an optional extension of ReportingPlugin. If
+// the ReportingPlugin passed to the protocol implements it, the oracles agree
+// on an OutcomeState in every round in addition to the report. This allows
+// plugins to maintain consensus state (e.g. nonces, queues, or averaging
+// windows) without having to read it back from the contract in every round.
+//
+// Each follower derives the new OutcomeState from the latest committed one
+// and the round's query and observations using Outcome, and signs it. The
+// leader only finalizes a round once more than (n+f)/2 oracles have signed
+// the same OutcomeState, so at most one OutcomeState can be certified for any
+// (epoch, round). This also happens for rounds in which Report returns false.
+// Oracles commit certified OutcomeStates in (epoch, round) order, skipping any
+// that are older than the one they have already committed.
+//
+// A faulty leader can prevent the OutcomeState of a round from being certified
+// at all, in which case the next round starts from the previous OutcomeState.
+// Likewise, an oracle that missed the certified OutcomeState of a round will
+// compute a different OutcomeState than its peers until it has caught up
+// through the final-echo messages of subsequent rounds. Plugins must tolerate
+// both.
+//
+// Wrappers around a ReportingPlugin must implement this interface themselves
+// for outcome states to be enabled. The wrappers in reportingplugin/middleware
+// and reportingplugin/shadow do so whenever the wrapped plugin does.
+type OutcomeStateReportingPlugin interface {
+	ReportingPlugin
+
+	// Outcome deterministically derives the new OutcomeState from the
+	// previous one and the round's query and observations. Honest oracles
+	// must return the same OutcomeState given the same inputs. The result
+	// must not be longer than MaxOutcomeStateLength.
+	//
+	// previousOutcomeState is nil if no OutcomeState has been committed yet
+	// for the current configuration.
+	Outcome(ctx context.Context, repts ReportTimestamp, previousOutcomeState OutcomeState, query Query, aos []AttributedObservation) (OutcomeState, error)
+
+	// OutcomeStateCommitted notifies the plugin that outcomeState has been
+	// certified for repts and is now the latest committed OutcomeState. It is
+	// also called once upon startup with the OutcomeState restored from the
+	// database, if any.
+	//
+	// It must be cheap and thread-safe, since it may be called concurrently
+	// with the other functions of the plugin.
+	OutcomeStateCommitted(repts ReportTimestamp, outcomeState OutcomeState)
+}
+
+// Maximum length in bytes of an OutcomeState
+const MaxOutcomeStateLength = 1024 * 1024 // 1 MiB
+
+// MultiReportReportingPlugin is an optional extension of ReportingPlugin for
+// plugins that generate several reports per round, e.g. because they serve
+// several contracts. If the ReportingPlugin passed to the protocol implements
+// it, the protocol calls Reports instead of Report.
+//
+// Each report is signed, finalized, and scheduled for transmission
+// independently. ShouldAcceptFinalizedReport and
+// ShouldTransmitAcceptedReport are called once per report, and
+// ContractTransmitter.Transmit receives the report's position in the list
+// returned by Reports as ReportContext.ReportIndex.
+//
+// Wrappers around a ReportingPlugin must implement this interface themselves
+// for multiple reports to be enabled. The wrappers in
+// reportingplugin/middleware and reportingplugin/shadow do so whenever the
+// wrapped plugin does.
+type MultiReportReportingPlugin interface {
+	ReportingPlugin
+
+	// Reports returns the reports that should be generated in this round.
+	// Returning no reports is equivalent to Report returning false. At most
+	// MaxReportsPerRound reports may be returned, each of which must respect
+	// ReportingPluginLimits.MaxReportLength.
+	//
+	// You may assume that the sequence of epochs and the sequence of rounds
+	// within an epoch are strictly monotonically increasing during the lifetime
+	// of an instance of this interface.
+	Reports(context.Context, ReportTimestamp, Query, []AttributedObservation) ([]Report, error)
+}
+
+// Maximum number of reports a MultiReportReportingPlugin may generate per
+// round
+const MaxReportsPerRound = 16
+
 const (
 	twoHundredFiftySixMiB   = 256 * 1024 * 1024     // 256 MiB
 	MaxMaxQueryLength       = twoHundredFiftySixMiB // 256 MiB
@@ -329,6 +418,178 @@ type ContractTransmitter interface {
 	FromAccount() Account
 }
 
+// MultiAccountContractTransmitter is an optional extension of
+// ContractTransmitter for oracles that hold several transmit accounts, e.g.
+// while rotating from a compromised or underfunded account to a new one. The
+// oracle recognises itself in any contract configuration that lists one of
+// FromAccounts as its transmit account. Thus, an operator can add a new
+// account to the transmitter ahead of time and switch the contract
+// configuration to it whenever convenient, without having to coordinate the
+// switch with a restart of the oracle.
+type MultiAccountContractTransmitter interface {
+	ContractTransmitter
+
+	// FromAccounts returns all accounts the transmitter can transmit from.
+	// It should include FromAccount.
+	FromAccounts() []Account
+
+	// SetActiveAccount is called whenever the oracle enacts a new contract
+	// configuration, with the account that the configuration lists for this
+	// oracle. During a config handover, it is called only once the protocol
+	// instance for the old configuration has stopped (see
+	// LocalConfig.ConfigHandoverWindow). Subsequent calls to Transmit must use this account, since the
+	// contract rejects transmissions from other accounts. FromAccount should
+	// return it, too.
+	SetActiveAccount(Account) error
+}
+
+// TransmissionResultReporter is an optional extension of ContractTransmitter.
+// Without it, the protocol considers a report transmitted as soon as Transmit
+// returns without error. ContractTransmitters that implement it report whether
+// transmissions actually made it on-chain. The protocol re-queues reports
+// whose transmission failed for the next stage of the transmission schedule,
+// provided that they are still the newest report accepted for transmission,
+// the schedule hasn't ended, and LocalConfig.MaxRetransmissions permits it.
+type TransmissionResultReporter interface {
+	// TransmissionResults returns a channel on which the outcomes of earlier
+	// calls to Transmit are reported. The oracle calls it once and routes
+	// each result to the protocol instance for the config digest in its
+	// ReportContext.
+	//
+	// The returned channel should never be closed.
+	TransmissionResults() <-chan TransmissionResult
+}
+
+type TransmissionResult struct {
+	// ReportContext passed to Transmit
+	ReportContext ReportContext
+	// Confirmed is true if the transmission was included on-chain and false if
+	// it failed, e.g. because the transaction reverted or its nonce got stuck.
+	Confirmed bool
+}
+
+// TransmissionTarget is an additional destination for the reports generated by
+// an oracle, e.g. a mirror of the primary contract on another chain. Each
+// target has its own queue of pending transmissions, so that a slow or
+// failing target doesn't hold up the others.
+type TransmissionTarget struct {
+	// Name identifies the target in logs and in the Database. It must be
+	// non-empty and unique among an oracle's targets. The primary target
+	// (OracleArgs.ContractTransmitter) has the empty name.
+	Name string
+
+	ContractTransmitter ContractTransmitter
+
+	// TransmissionFilter decides whether an accepted report is transmitted
+	// to this target. It is required for additional targets, since
+	// ReportingPlugin.ShouldTransmitAcceptedReport checks the primary
+	// contract. The primary target always uses
+	// ReportingPlugin.ShouldTransmitAcceptedReport.
+	TransmissionFilter TransmissionFilter
+}
+
+// TransmissionFilter is the counterpart of
+// ReportingPlugin.ShouldTransmitAcceptedReport for a TransmissionTarget. It
+// typically checks whether the target's contract already has a report that
+// is at least as recent.
+//
+// All its functions should be thread-safe.
+type TransmissionFilter interface {
+	ShouldTransmitAcceptedReport(context.Context, ReportTimestamp, Report) (bool, error)
+}
+
+// TransmissionScheduleContext contains the information a TransmissionScheduler
+// uses to schedule the transmission of a report to a TransmissionTarget. All
+// fields except OracleID are identical across oracles.
+type TransmissionScheduleContext struct {
+	ReportContext ReportContext
+	// Name of the TransmissionTarget, empty for the primary target
+	Target string
+	// OracleID of the local oracle
+	OracleID commontypes.OracleID
+	// TransmitAccounts[i] is the account from which oracle i transmits to the
+	// primary target, as per the contract configuration.
+	TransmitAccounts []Account
+	// S, DeltaStage and RMax from the contract configuration, see
+	// confighelper.PublicConfig.
+	S          []int
+	DeltaStage time.Duration
+	RMax       uint8
+	// TransmissionOrder[i] is the position of oracle i in a pseudorandom
+	// order of all oracles. The order changes every round and is derived from
+	// a secret shared among the oracles, so outsiders can't predict it.
+	TransmissionOrder []int
+}
+
+// TransmissionScheduler determines when the local oracle transmits an
+// accepted report. By default, oracles transmit in stages of S[0], S[1], ...
+// oracles spaced DeltaStage apart, ordered by TransmissionOrder. See package
+// transmissionschedule for alternatives.
+//
+// The protocol calls Schedule once per report and TransmissionTarget. Oracles
+// should compute consistent schedules, so that the oracles that are
+// scheduled first usually succeed in transmitting and the others can skip
+// transmission in ShouldTransmitAcceptedReport.
+//
+// All its functions should be thread-safe.
+type TransmissionScheduler interface {
+	// Schedule returns the delay after acceptance of the report at which the
+	// local oracle should transmit it. If transmit is false, the local oracle
+	// doesn't transmit the report at all.
+	Schedule(ctx context.Context, sctx TransmissionScheduleContext) (delay time.Duration, transmit bool, err error)
+}
+
+// TransmissionStage identifies the point in the transmission protocol at which
+// a TransmissionRecorder records a report.
+type TransmissionStage int
+
+const (
+	_ TransmissionStage = iota
+	// The report was accepted by ShouldAcceptFinalizedReport and scheduled
+	// for transmission.
+	TransmissionStageAccepted
+	// The report was due for transmission, but ShouldTransmitAcceptedReport
+	// returned false.
+	TransmissionStageSkipped
+	// The report would have been transmitted.
+	TransmissionStageTransmit
+)
+
+func (s TransmissionStage) String() string {
+	switch s {
+	case TransmissionStageAccepted:
+		return "Accepted"
+	case TransmissionStageSkipped:
+		return "Skipped"
+	case TransmissionStageTransmit:
+		return "Transmit"
+	}
+	return "Unknown"
+}
+
+// RecordedTransmission is a report recorded by a TransmissionRecorder.
+type RecordedTransmission struct {
+	// Time at which the report was recorded
+	Time  time.Time
+	Stage TransmissionStage
+	// Name of the TransmissionTarget, empty for the primary target
+	Target               string
+	ReportContext        ReportContext
+	Report               Report
+	AttributedSignatures []AttributedOnchainSignature
+	// Time at which the report is scheduled to be transmitted. Only set for
+	// TransmissionStageAccepted.
+	ScheduledTime time.Time
+}
+
+// TransmissionRecorder records reports in place of a ContractTransmitter when
+// an oracle runs in dry-run mode.
+//
+// All its functions should be thread-safe.
+type TransmissionRecorder interface {
+	Record(context.Context, RecordedTransmission) error
+}
+
 // ContractConfigTracker tracks configuration changes of the OCR contract
 // (on-chain).
 //
@@ -337,7 +598,8 @@ type ContractConfigTracker interface {
 	// Notify may optionally emit notification events when the contract's
 	// configuration changes. This is purely used as an optimization reducing
 	// the delay between a configuration change and its enactment. Implementors
-	// who don't care about this may simply return a nil channel.
+	// who don't care about this may simply return a nil channel. Package
+	// confignotify adapts log subscriptions to Notify.
 	//
 	// The returned channel should never be closed.
 	Notify() <-chan struct{}
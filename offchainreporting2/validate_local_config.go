@@ -32,12 +32,24 @@ func SanityCheckLocalConfig(c types.LocalConfig) (err error) {
 			"blockchain timeout",
 			1*time.Second, 20*time.Second,
 		))
+	err = multierr.Append(err,
+		boundTimeDuration(
+			c.ConfigHandoverWindow,
+			"config handover window",
+			0, 10*time.Minute,
+		))
 	err = multierr.Append(err,
 		boundTimeDuration(
 			c.ContractConfigTrackerPollInterval,
 			"contract config tracker poll interval",
 			15*time.Second, 120*time.Second,
 		))
+	err = multierr.Append(err,
+		boundTimeDuration(
+			c.ContractConfigTrackerPollJitter,
+			"contract config tracker poll jitter",
+			0, c.ContractConfigTrackerPollInterval,
+		))
 	err = multierr.Append(err,
 		boundTimeDuration(
 			c.ContractTransmitterTransmitTimeout,
@@ -62,5 +74,13 @@ func SanityCheckLocalConfig(c types.LocalConfig) (err error) {
 
 	}
 
+	const maxMaxRetransmissions = 10
+	if !(0 <= c.MaxRetransmissions && c.MaxRetransmissions <= maxMaxRetransmissions) {
+		err = multierr.Append(err, errors.Errorf(
+			"max retransmissions must be between 0 and %v, but is currently %v",
+			maxMaxRetransmissions,
+			c.MaxRetransmissions))
+	}
+
 	return err
 }
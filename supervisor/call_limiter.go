@@ -0,0 +1,118 @@
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	ocr1types "github.com/smartcontractkit/libocr/offchainreporting/types"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/middleware"
+)
+
+// CallUsage accounts for the calls made through a CallLimiter
+type CallUsage struct {
+	// MaxConcurrentCalls is the limit, zero means unlimited
+	MaxConcurrentCalls int
+	// Calls is the number of calls that acquired a slot
+	Calls uint64
+	// Rejected is the number of calls whose context expired while waiting for
+	// a slot
+	Rejected uint64
+	// InFlight is the number of calls currently holding a slot
+	InFlight int
+	// WaitTime is the total time calls spent waiting for a slot
+	WaitTime time.Duration
+	// BusyTime is the total time calls spent holding a slot
+	BusyTime time.Duration
+}
+
+// CallLimiter caps the number of concurrent calls of an instance, e.g. to
+// its ReportingPlugin or DataSource, and accounts for them. Safe for
+// concurrent use.
+type CallLimiter struct {
+	slots chan struct{}
+
+	mutex sync.Mutex
+	usage CallUsage
+}
+
+var _ middleware.Limiter = (*CallLimiter)(nil)
+
+// NewCallLimiter returns a CallLimiter allowing up to maxConcurrentCalls
+// concurrent calls. If maxConcurrentCalls is zero, calls are only accounted
+// for, not limited.
+func NewCallLimiter(maxConcurrentCalls int) *CallLimiter {
+	var slots chan struct{}
+	if maxConcurrentCalls > 0 {
+		slots = make(chan struct{}, maxConcurrentCalls)
+	}
+	return &CallLimiter{
+		slots,
+		sync.Mutex{},
+		CallUsage{MaxConcurrentCalls: maxConcurrentCalls},
+	}
+}
+
+func (l *CallLimiter) Acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			l.mutex.Lock()
+			l.usage.Rejected++
+			l.usage.WaitTime += time.Since(start)
+			l.mutex.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+
+	acquired := time.Now()
+	l.mutex.Lock()
+	l.usage.Calls++
+	l.usage.InFlight++
+	l.usage.WaitTime += acquired.Sub(start)
+	l.mutex.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mutex.Lock()
+			l.usage.InFlight--
+			l.usage.BusyTime += time.Since(acquired)
+			l.mutex.Unlock()
+			if l.slots != nil {
+				<-l.slots
+			}
+		})
+	}, nil
+}
+
+// Usage returns a snapshot of the calls made through l
+func (l *CallLimiter) Usage() CallUsage {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.usage
+}
+
+// LimitDataSource wraps an OCR1 DataSource, so that every Observe call
+// acquires a slot from limiter. This is the OCR1 counterpart of
+// middleware.ConcurrencyLimit.
+func LimitDataSource(dataSource ocr1types.DataSource, limiter *CallLimiter) ocr1types.DataSource {
+	return limitedDataSource{dataSource, limiter}
+}
+
+type limitedDataSource struct {
+	dataSource ocr1types.DataSource
+	limiter    *CallLimiter
+}
+
+func (ds limitedDataSource) Observe(ctx context.Context) (ocr1types.Observation, error) {
+	release, err := ds.limiter.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("LimitDataSource: could not acquire slot: %w", err)
+	}
+	defer release()
+	return ds.dataSource.Observe(ctx)
+}
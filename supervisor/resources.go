@@ -0,0 +1,99 @@
+package supervisor
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	ocr1 "github.com/smartcontractkit/libocr/offchainreporting"
+	ocr1types "github.com/smartcontractkit/libocr/offchainreporting/types"
+	ocr2 "github.com/smartcontractkit/libocr/offchainreporting2"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/middleware"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// SharedResources are shared by all instances of a Supervisor. The caller
+// owns them, e.g. it must close the networking peer after closing the
+// Supervisor. Fields that are nil are not injected, so that instances can
+// provide their own.
+type SharedResources struct {
+	// Endpoint factories of a single networking peer, e.g.
+	// peer.OCR1BinaryNetworkEndpointFactory() and
+	// peer.OCR2BinaryNetworkEndpointFactory().
+	OCR1BinaryNetworkEndpointFactory ocr1types.BinaryNetworkEndpointFactory
+	OCR2BinaryNetworkEndpointFactory types.BinaryNetworkEndpointFactory
+	V1Bootstrappers                  []string
+	V2Bootstrappers                  []commontypes.BootstrapperLocator
+
+	// OCR1Database and OCR2Database return the Database for the instance with
+	// the given ID. A Database stores the config of a single contract, so
+	// these typically return views scoped to the instance's contract on top
+	// of a shared connection pool.
+	OCR1Database func(id string) (ocr1types.Database, error)
+	OCR2Database func(id string) (types.Database, error)
+
+	// MonitoringEndpoint receives the telemetry of all instances.
+	MonitoringEndpoint commontypes.MonitoringEndpoint
+}
+
+// InstanceResources are passed to an InstanceFactory
+type InstanceResources struct {
+	ID string
+	// Limiter caps the instance's concurrent plugin calls
+	Limiter *CallLimiter
+	Shared  SharedResources
+}
+
+// OCR2OracleArgs returns args with the shared resources injected and the
+// ReportingPluginFactory wrapped in middleware.ConcurrencyLimit(Limiter).
+func (r InstanceResources) OCR2OracleArgs(args ocr2.OracleArgs) (ocr2.OracleArgs, error) {
+	if r.Shared.OCR2BinaryNetworkEndpointFactory != nil {
+		args.BinaryNetworkEndpointFactory = r.Shared.OCR2BinaryNetworkEndpointFactory
+	}
+	if r.Shared.V2Bootstrappers != nil {
+		args.V2Bootstrappers = r.Shared.V2Bootstrappers
+	}
+	if r.Shared.OCR2Database != nil {
+		database, err := r.Shared.OCR2Database(r.ID)
+		if err != nil {
+			return ocr2.OracleArgs{}, fmt.Errorf("error getting database for instance %q: %w", r.ID, err)
+		}
+		args.Database = database
+	}
+	if r.Shared.MonitoringEndpoint != nil {
+		args.MonitoringEndpoint = r.Shared.MonitoringEndpoint
+	}
+	if args.ReportingPluginFactory != nil {
+		args.ReportingPluginFactory = middleware.WrapFactory(args.ReportingPluginFactory,
+			middleware.ConcurrencyLimit(r.Limiter),
+		)
+	}
+	return args, nil
+}
+
+// OCR1OracleArgs returns args with the shared resources injected and the
+// Datasource wrapped in LimitDataSource(Limiter).
+func (r InstanceResources) OCR1OracleArgs(args ocr1.OracleArgs) (ocr1.OracleArgs, error) {
+	if r.Shared.OCR1BinaryNetworkEndpointFactory != nil {
+		args.BinaryNetworkEndpointFactory = r.Shared.OCR1BinaryNetworkEndpointFactory
+	}
+	if r.Shared.V1Bootstrappers != nil {
+		args.V1Bootstrappers = r.Shared.V1Bootstrappers
+	}
+	if r.Shared.V2Bootstrappers != nil {
+		args.V2Bootstrappers = r.Shared.V2Bootstrappers
+	}
+	if r.Shared.OCR1Database != nil {
+		database, err := r.Shared.OCR1Database(r.ID)
+		if err != nil {
+			return ocr1.OracleArgs{}, fmt.Errorf("error getting database for instance %q: %w", r.ID, err)
+		}
+		args.Database = database
+	}
+	if r.Shared.MonitoringEndpoint != nil {
+		args.MonitoringEndpoint = r.Shared.MonitoringEndpoint
+	}
+	if args.Datasource != nil {
+		args.Datasource = LimitDataSource(args.Datasource, r.Limiter)
+	}
+	return args, nil
+}
@@ -0,0 +1,83 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	ocr1 "github.com/smartcontractkit/libocr/offchainreporting"
+	ocr1types "github.com/smartcontractkit/libocr/offchainreporting/types"
+	ocr2 "github.com/smartcontractkit/libocr/offchainreporting2"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// testDatabase records the instance it was created for. All functions panic.
+type testDatabase struct {
+	types.Database
+	id string
+}
+
+type testMonitoringEndpoint struct{}
+
+func (testMonitoringEndpoint) SendLog([]byte) {}
+
+func TestOCR2OracleArgs(t *testing.T) {
+	shared := SharedResources{
+		OCR2Database: func(id string) (types.Database, error) {
+			return testDatabase{nil, id}, nil
+		},
+		MonitoringEndpoint: testMonitoringEndpoint{},
+	}
+	own := testDatabase{nil, "own"}
+
+	args, err := InstanceResources{"feed", NewCallLimiter(1), shared}.OCR2OracleArgs(ocr2.OracleArgs{Database: own})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db, ok := args.Database.(testDatabase); !ok || db.id != "feed" {
+		t.Errorf("expected shared database for instance, got %v", args.Database)
+	}
+	if args.MonitoringEndpoint != (testMonitoringEndpoint{}) {
+		t.Errorf("expected shared MonitoringEndpoint, got %v", args.MonitoringEndpoint)
+	}
+
+	// nil shared resources aren't injected
+	args, err = InstanceResources{"feed", NewCallLimiter(1), SharedResources{}}.OCR2OracleArgs(ocr2.OracleArgs{Database: own})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args.Database != (types.Database)(own) || args.MonitoringEndpoint != nil {
+		t.Errorf("expected instance's own resources to be kept, got %v, %v", args.Database, args.MonitoringEndpoint)
+	}
+
+	shared.OCR2Database = func(string) (types.Database, error) {
+		return nil, errors.New("boom")
+	}
+	if _, err := (InstanceResources{"feed", NewCallLimiter(1), shared}).OCR2OracleArgs(ocr2.OracleArgs{}); err == nil {
+		t.Error("expected database error")
+	}
+}
+
+type testDataSource struct{}
+
+func (testDataSource) Observe(context.Context) (ocr1types.Observation, error) {
+	return big.NewInt(1), nil
+}
+
+func TestOCR1OracleArgs(t *testing.T) {
+	limiter := NewCallLimiter(1)
+	args, err := InstanceResources{"feed", limiter, SharedResources{V1Bootstrappers: []string{"bootstrapper"}}}.OCR1OracleArgs(ocr1.OracleArgs{Datasource: testDataSource{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(args.V1Bootstrappers) != 1 {
+		t.Errorf("expected shared V1Bootstrappers, got %v", args.V1Bootstrappers)
+	}
+	if _, err := args.Datasource.Observe(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if limiter.Usage().Calls != 1 {
+		t.Errorf("expected Observe to go through the instance's limiter, got %+v", limiter.Usage())
+	}
+}
@@ -0,0 +1,369 @@
+// Package supervisor runs many oracle instances, OCR1 and OCR2 alike, in one
+// process.
+//
+// Instances share expensive resources: The SharedResources passed to
+// NewSupervisor, i.e. the endpoint factories of a single networking peer,
+// databases backed by a single connection pool, and a single
+// MonitoringEndpoint, are handed to every InstanceFactory. Contracts,
+// ReportingPlugins and keyrings stay per instance.
+//
+// Each instance gets a CallLimiter that caps its concurrent plugin calls and
+// accounts for them, so that a single slow feed can't starve the others.
+// InstanceResources.OCR2OracleArgs and OCR1OracleArgs inject the shared
+// resources and apply the CallLimiter, e.g.
+//
+//	err := s.Add("eth-usd", 4, func(resources supervisor.InstanceResources) (supervisor.Instance, error) {
+//		args, err := resources.OCR2OracleArgs(args)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return offchainreporting2.NewOracle(args)
+//	})
+package supervisor
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/internal/loghelper"
+	"github.com/smartcontractkit/libocr/subprocesses"
+	"go.uber.org/multierr"
+)
+
+// Instance is an oracle instance, e.g. an offchainreporting.Oracle or an
+// offchainreporting2.Oracle. Instances are started at most once, so the
+// Supervisor creates a fresh Instance every time it starts one.
+type Instance interface {
+	Start() error
+	Close() error
+}
+
+// InstanceFactory creates an Instance that uses the shared resources and
+// whose plugin calls go through resources.Limiter
+type InstanceFactory func(resources InstanceResources) (Instance, error)
+
+type InstanceState int
+
+const (
+	InstanceStateStopped InstanceState = iota
+	InstanceStateRunning
+	// the last attempt to create or start the instance failed
+	InstanceStateFailed
+)
+
+func (s InstanceState) String() string {
+	switch s {
+	case InstanceStateStopped:
+		return "stopped"
+	case InstanceStateRunning:
+		return "running"
+	case InstanceStateFailed:
+		return "failed"
+	}
+	return fmt.Sprintf("InstanceState(%d)", int(s))
+}
+
+// InstanceStatus describes an instance managed by a Supervisor
+type InstanceStatus struct {
+	ID    string
+	State InstanceState
+	// Since is the time of the last state change
+	Since time.Time
+	// Err is the error from the last failed operation on the instance, if any.
+	// A running instance with Err set failed to stop.
+	Err error
+	// Usage accounts for the instance's plugin calls since it was added
+	Usage CallUsage
+}
+
+type managedInstance struct {
+	// held during Start and Stop, which may block for a while, so that
+	// operations on other instances can proceed
+	lock sync.Mutex
+
+	id       string
+	factory  InstanceFactory
+	limiter  *CallLimiter
+	instance Instance
+	state    InstanceState
+	since    time.Time
+	err      error
+	removed  bool
+}
+
+// Supervisor manages the lifecycle of many instances, identified by unique
+// IDs. Safe for concurrent use.
+type Supervisor struct {
+	logger loghelper.LoggerWithContext
+	shared SharedResources
+
+	lock      sync.Mutex
+	instances map[string]*managedInstance
+	closed    bool
+}
+
+func NewSupervisor(logger commontypes.Logger, shared SharedResources) *Supervisor {
+	return &Supervisor{
+		loghelper.MakeRootLoggerWithContext(logger),
+		shared,
+		sync.Mutex{},
+		map[string]*managedInstance{},
+		false,
+	}
+}
+
+// Add registers an instance without starting it. maxConcurrentPluginCalls
+// caps the concurrent calls through the instance's CallLimiter, zero means
+// unlimited.
+func (s *Supervisor) Add(id string, maxConcurrentPluginCalls int, factory InstanceFactory) error {
+	if maxConcurrentPluginCalls < 0 {
+		return fmt.Errorf("maxConcurrentPluginCalls must not be negative, but is %v", maxConcurrentPluginCalls)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return fmt.Errorf("Supervisor is closed")
+	}
+	if _, ok := s.instances[id]; ok {
+		return fmt.Errorf("duplicate instance id %q", id)
+	}
+	s.instances[id] = &managedInstance{
+		sync.Mutex{},
+		id,
+		factory,
+		NewCallLimiter(maxConcurrentPluginCalls),
+		nil,
+		InstanceStateStopped,
+		time.Now(),
+		nil,
+		false,
+	}
+	return nil
+}
+
+func (s *Supervisor) get(id string) (*managedInstance, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	mi, ok := s.instances[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown instance id %q", id)
+	}
+	return mi, nil
+}
+
+// Start creates and starts the instance. Starting a running instance is an
+// error.
+func (s *Supervisor) Start(id string) error {
+	mi, err := s.get(id)
+	if err != nil {
+		return err
+	}
+
+	mi.lock.Lock()
+	defer mi.lock.Unlock()
+
+	s.lock.Lock()
+	closed := s.closed
+	s.lock.Unlock()
+	if closed {
+		return fmt.Errorf("Supervisor is closed")
+	}
+	if mi.removed {
+		return fmt.Errorf("unknown instance id %q", id)
+	}
+	if mi.state == InstanceStateRunning {
+		if mi.err != nil {
+			return fmt.Errorf("instance %q failed to stop and can't be restarted until Stop succeeds: %w", id, mi.err)
+		}
+		return fmt.Errorf("instance %q is already running", id)
+	}
+
+	instance, err := mi.factory(InstanceResources{id, mi.limiter, s.shared})
+	if err == nil {
+		err = instance.Start()
+	}
+	if err != nil {
+		err = fmt.Errorf("error while starting instance %q: %w", id, err)
+		mi.setState(InstanceStateFailed, err)
+		s.logger.Error("Supervisor: failed to start instance", commontypes.LogFields{
+			"id":    id,
+			"error": err,
+		})
+		return err
+	}
+	mi.instance = instance
+	mi.setState(InstanceStateRunning, nil)
+	s.logger.Info("Supervisor: started instance", commontypes.LogFields{
+		"id": id,
+	})
+	return nil
+}
+
+// Stop closes the instance, blocking until it has shut down. Stopping an
+// instance that isn't running is a no-op. If Close fails, the instance may
+// still hold on to its resources, so it stays running (with Err set) and can't
+// be restarted until a retried Stop succeeds.
+func (s *Supervisor) Stop(id string) error {
+	mi, err := s.get(id)
+	if err != nil {
+		return err
+	}
+
+	mi.lock.Lock()
+	defer mi.lock.Unlock()
+
+	return s.stop(mi)
+}
+
+// stop must be called with mi.lock held
+func (s *Supervisor) stop(mi *managedInstance) error {
+	if mi.state != InstanceStateRunning {
+		return nil
+	}
+
+	if err := mi.instance.Close(); err != nil {
+		err = fmt.Errorf("error while stopping instance %q: %w", mi.id, err)
+		// keep the instance, so that Stop can be retried
+		mi.err = err
+		s.logger.Error("Supervisor: failed to stop instance cleanly", commontypes.LogFields{
+			"id":    mi.id,
+			"error": err,
+		})
+		return err
+	}
+	mi.instance = nil
+	mi.setState(InstanceStateStopped, nil)
+	s.logger.Info("Supervisor: stopped instance", commontypes.LogFields{
+		"id": mi.id,
+	})
+	return nil
+}
+
+// Remove stops the instance and unregisters it. If the instance fails to
+// stop, it stays registered, so that Stop or Remove can be retried.
+func (s *Supervisor) Remove(id string) error {
+	mi, err := s.get(id)
+	if err != nil {
+		return err
+	}
+
+	mi.lock.Lock()
+	defer mi.lock.Unlock()
+
+	if err := s.stop(mi); err != nil {
+		return err
+	}
+	mi.removed = true
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.instances, id)
+	return nil
+}
+
+// Status reports on the instance
+func (s *Supervisor) Status(id string) (InstanceStatus, error) {
+	mi, err := s.get(id)
+	if err != nil {
+		return InstanceStatus{}, err
+	}
+
+	mi.lock.Lock()
+	defer mi.lock.Unlock()
+
+	return mi.status(), nil
+}
+
+// Statuses reports on all instances, ordered by ID. Instances that are being
+// started or stopped are reported once that has finished.
+func (s *Supervisor) Statuses() []InstanceStatus {
+	instances := s.sortedInstances()
+	statuses := make([]InstanceStatus, 0, len(instances))
+	for _, mi := range instances {
+		mi.lock.Lock()
+		if !mi.removed {
+			statuses = append(statuses, mi.status())
+		}
+		mi.lock.Unlock()
+	}
+	return statuses
+}
+
+// TotalUsage sums up the plugin call accounting of all instances
+func (s *Supervisor) TotalUsage() CallUsage {
+	var total CallUsage
+	for _, mi := range s.sortedInstances() {
+		usage := mi.limiter.Usage()
+		total.MaxConcurrentCalls += usage.MaxConcurrentCalls
+		total.Calls += usage.Calls
+		total.Rejected += usage.Rejected
+		total.InFlight += usage.InFlight
+		total.WaitTime += usage.WaitTime
+		total.BusyTime += usage.BusyTime
+	}
+	return total
+}
+
+func (s *Supervisor) sortedInstances() []*managedInstance {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	instances := make([]*managedInstance, 0, len(s.instances))
+	for _, mi := range s.instances {
+		instances = append(instances, mi)
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].id < instances[j].id
+	})
+	return instances
+}
+
+// Close stops all instances in parallel and prevents further instances from
+// being added.
+func (s *Supervisor) Close() error {
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return fmt.Errorf("Supervisor is already closed")
+	}
+	s.closed = true
+	s.lock.Unlock()
+
+	instances := s.sortedInstances()
+	errs := make([]error, len(instances))
+	subs := subprocesses.Subprocesses{}
+	for i, mi := range instances {
+		i, mi := i, mi
+		subs.Go(func() {
+			mi.lock.Lock()
+			defer mi.lock.Unlock()
+			errs[i] = s.stop(mi)
+		})
+	}
+	subs.Wait()
+	return multierr.Combine(errs...)
+}
+
+// setState must be called with mi.lock held
+func (mi *managedInstance) setState(state InstanceState, err error) {
+	mi.state = state
+	mi.since = time.Now()
+	mi.err = err
+}
+
+// status must be called with mi.lock held
+func (mi *managedInstance) status() InstanceStatus {
+	return InstanceStatus{
+		mi.id,
+		mi.state,
+		mi.since,
+		mi.err,
+		mi.limiter.Usage(),
+	}
+}
@@ -0,0 +1,90 @@
+package supervisor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Trace(string, commontypes.LogFields)    {}
+func (nopLogger) Debug(string, commontypes.LogFields)    {}
+func (nopLogger) Info(string, commontypes.LogFields)     {}
+func (nopLogger) Warn(string, commontypes.LogFields)     {}
+func (nopLogger) Error(string, commontypes.LogFields)    {}
+func (nopLogger) Critical(string, commontypes.LogFields) {}
+
+// testInstance fails to close while closeErr is set
+type testInstance struct {
+	closeErr *error
+	closed   *int
+}
+
+func (testInstance) Start() error { return nil }
+
+func (i testInstance) Close() error {
+	if *i.closeErr != nil {
+		return *i.closeErr
+	}
+	*i.closed++
+	return nil
+}
+
+func TestSupervisorStopFailure(t *testing.T) {
+	s := NewSupervisor(nopLogger{}, SharedResources{})
+	var closeErr error
+	closed, created := 0, 0
+	if err := s.Add("feed", 0, func(InstanceResources) (Instance, error) {
+		created++
+		return testInstance{&closeErr, &closed}, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Start("feed"); err != nil {
+		t.Fatal(err)
+	}
+
+	closeErr = errors.New("boom")
+	if err := s.Stop("feed"); err == nil {
+		t.Fatal("expected Stop to fail")
+	}
+	status, err := s.Status("feed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != InstanceStateRunning || status.Err == nil {
+		t.Fatalf("expected running instance with error, got %v (%v)", status.State, status.Err)
+	}
+	if err := s.Start("feed"); err == nil || created != 1 {
+		t.Fatal("expected Start to refuse restarting an instance that failed to stop")
+	}
+	if err := s.Remove("feed"); err == nil {
+		t.Fatal("expected Remove to fail")
+	}
+	if _, err := s.Status("feed"); err != nil {
+		t.Fatal("expected instance to stay registered after failed Remove")
+	}
+
+	// retrying Stop closes the same instance
+	closeErr = nil
+	if err := s.Stop("feed"); err != nil {
+		t.Fatal(err)
+	}
+	if closed != 1 {
+		t.Fatalf("expected instance to be closed once, got %v", closed)
+	}
+	if status, _ := s.Status("feed"); status.State != InstanceStateStopped || status.Err != nil {
+		t.Fatalf("expected stopped instance without error, got %v (%v)", status.State, status.Err)
+	}
+	if err := s.Start("feed"); err != nil || created != 2 {
+		t.Fatalf("expected restart with a fresh instance: %v", err)
+	}
+	if err := s.Remove("feed"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Status("feed"); err == nil {
+		t.Fatal("expected instance to be unregistered")
+	}
+}